@@ -0,0 +1,89 @@
+package dawg
+
+// Get the words of the DAWG using exactly all the given letters, in lexicographic order.
+// At most max words are returned (all of them if max <= 0).
+func (dawg *DAWG) Anagrams(letters string, max int) []string {
+	return dawg.anagrams(letters, max, false)
+}
+
+// Get the words of the DAWG using some of the given letters (each letter at most as many
+// times as it is given), in lexicographic order.
+// At most max words are returned (all of them if max <= 0).
+func (dawg *DAWG) SubAnagrams(letters string, max int) []string {
+	return dawg.anagrams(letters, max, true)
+}
+
+// Get the words of the DAWG which can be played with the letters of a Scrabble rack
+// (each letter at most as many times as it is in the rack), in lexicographic order.
+// Each of the blanks can replace any letter.
+// At most max words are returned (all of them if max <= 0).
+func (dawg *DAWG) RackWords(letters string, blanks int, max int) []string {
+	return dawg.rackWords(letters, blanks, max, true)
+}
+
+func (dawg *DAWG) anagrams(letters string, max int, allowShorter bool) []string {
+	return dawg.rackWords(letters, 0, max, allowShorter)
+}
+
+func (dawg *DAWG) rackWords(letters string, blanks int, max int, allowShorter bool) []string {
+	rack := newRack(letters, blanks)
+	words := []string{}
+	rack.walk(dawg.initialState, nil, allowShorter, func(word []rune) bool {
+		words = append(words, string(word))
+		return max <= 0 || len(words) < max
+	})
+	return words
+}
+
+// Letters available to build words
+type rack struct {
+	counts    map[rune]int
+	blanks    int // Letters which can replace any letter
+	remaining int // Number of letters and blanks
+}
+
+// Create a new rack from the letters and the blanks
+func newRack(letters string, blanks int) *rack {
+	rack := &rack{counts: make(map[rune]int), blanks: blanks, remaining: blanks}
+	for _, char := range letters {
+		rack.counts[char]++
+		rack.remaining++
+	}
+	return rack
+}
+
+// Call fn for each word under the state (reached by prefix) which can be completed with the
+// letters of the rack, in lexicographic order.
+// The letters are taken from the rack while descending, and given back afterwards.
+// A blank is only used when the letter is not in the rack: keeping the blank is always at least as good.
+// The walk stops as soon as fn returns false.
+func (rack *rack) walk(curState *state, prefix []rune, allowShorter bool, fn func(word []rune) bool) bool {
+	if curState.final && len(prefix) > 0 && (allowShorter || rack.remaining == 0) && !fn(prefix) {
+		return false
+	}
+	if rack.remaining == 0 {
+		return true
+	}
+	for _, curLetter := range curState.letters {
+		var ok bool
+		if rack.counts[curLetter.char] > 0 {
+			rack.counts[curLetter.char]--
+			rack.remaining--
+			ok = rack.walk(curLetter.state, append(prefix, curLetter.char), allowShorter, fn)
+			rack.counts[curLetter.char]++
+			rack.remaining++
+		} else if rack.blanks > 0 {
+			rack.blanks--
+			rack.remaining--
+			ok = rack.walk(curLetter.state, append(prefix, curLetter.char), allowShorter, fn)
+			rack.blanks++
+			rack.remaining++
+		} else {
+			continue
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
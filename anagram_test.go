@@ -0,0 +1,34 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnagrams(t *testing.T) {
+	dawg := CreateDAWG([]string{"listen", "silent", "enlist", "tinsel", "list", "lists", "net", "ten", "tent"})
+
+	if words := strings.Join(dawg.Anagrams("nestil", 0), " "); words != "enlist listen silent tinsel" {
+		t.Error("Anagrams failed:", words)
+	}
+	if words := dawg.Anagrams("nestil", 2); len(words) != 2 || words[1] != "listen" {
+		t.Error("Anagrams max failed")
+	}
+	if words := strings.Join(dawg.SubAnagrams("nestil", 0), " "); words != "enlist list listen net silent ten tinsel" {
+		t.Error("SubAnagrams failed:", words)
+	}
+}
+
+func TestRackWords(t *testing.T) {
+	dawg := CreateDAWG([]string{"quiz", "quit", "suit", "it", "zit", "quits", "qi"})
+
+	if words := strings.Join(dawg.RackWords("tiqu", 0, 0), " "); words != "it qi quit" {
+		t.Error("RackWords failed:", words)
+	}
+	if words := strings.Join(dawg.RackWords("tiqu", 1, 0), " "); words != "it qi quit quits quiz suit zit" {
+		t.Error("RackWords with blank failed:", words)
+	}
+	if words := strings.Join(dawg.RackWords("", 2, 0), " "); words != "it qi" {
+		t.Error("RackWords with blanks only failed:", words)
+	}
+}
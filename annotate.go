@@ -0,0 +1,42 @@
+package dawg
+
+import "strings"
+
+// AnnotateOptions holds the markers put around the unknown words by Annotate
+type AnnotateOptions struct {
+	Open  string
+	Close string
+}
+
+// Common markers
+var (
+	HTMLMarkers     = AnnotateOptions{Open: "<mark>", Close: "</mark>"}     // The text is not escaped
+	TerminalMarkers = AnnotateOptions{Open: "\x1b[4;31m", Close: "\x1b[0m"} // Red and underlined
+)
+
+// Span is the position of an unknown word in a text
+type Span struct {
+	Word  string
+	Start int // Offset of the first byte of the word in the text
+	End   int // Offset of the byte following the word in the text
+}
+
+// Surround the words of the text which are not in the DAWG with the markers.
+// The positions of these words in the original text are returned as well.
+func (dawg *DAWG) Annotate(text string, options AnnotateOptions) (annotated string, spans []Span) {
+	var builder strings.Builder
+	previousEnd := 0
+	scanWords(text, func(word string, start int, end int) {
+		if dawg.Check(word) {
+			return
+		}
+		spans = append(spans, Span{Word: word, Start: start, End: end})
+		builder.WriteString(text[previousEnd:start])
+		builder.WriteString(options.Open)
+		builder.WriteString(word)
+		builder.WriteString(options.Close)
+		previousEnd = end
+	})
+	builder.WriteString(text[previousEnd:])
+	return builder.String(), spans
+}
@@ -0,0 +1,15 @@
+package dawg
+
+import "testing"
+
+func TestAnnotate(t *testing.T) {
+	dawg := CreateDAWG([]string{"the", "cat", "sat", "on", "mat"})
+
+	annotated, spans := dawg.Annotate("the cat sat on teh mat, yes", HTMLMarkers)
+	if annotated != "the cat sat on <mark>teh</mark> mat, <mark>yes</mark>" {
+		t.Error("Annotate failed")
+	}
+	if len(spans) != 2 || spans[0] != (Span{Word: "teh", Start: 15, End: 18}) || spans[1] != (Span{Word: "yes", Start: 24, End: 27}) {
+		t.Error("Annotate spans failed")
+	}
+}
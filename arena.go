@@ -0,0 +1,98 @@
+package dawg
+
+// Number of states, or of letters, allocated at once by an arena
+const arenaSlabSize = 1024
+
+// An arena allocates the states and the letters of a DAWG being built from slabs instead of one by one,
+// to reduce the number of allocations and the work of the garbage collector, and to keep the states
+// close to each other in memory. The states given back by the builder are reused.
+type arena struct {
+	states      []state
+	letters     []letter
+	pointers    []*letter // For the slices of the letters of the states
+	freeStates  []*state
+	freeLetters []*letter
+}
+
+// Get a new state
+func (arena *arena) newState() *state {
+	if len(arena.freeStates) > 0 {
+		newState := arena.freeStates[len(arena.freeStates)-1]
+		arena.freeStates = arena.freeStates[:len(arena.freeStates)-1]
+		return newState
+	}
+	if len(arena.states) == 0 {
+		arena.states = make([]state, arenaSlabSize)
+	}
+	newState := &arena.states[0]
+	arena.states = arena.states[1:]
+	return newState
+}
+
+// Get a new letter
+func (arena *arena) newLetter(char rune, target *state) *letter {
+	var newLetter *letter
+	if len(arena.freeLetters) > 0 {
+		newLetter = arena.freeLetters[len(arena.freeLetters)-1]
+		arena.freeLetters = arena.freeLetters[:len(arena.freeLetters)-1]
+	} else {
+		if len(arena.letters) == 0 {
+			arena.letters = make([]letter, arenaSlabSize)
+		}
+		newLetter = &arena.letters[0]
+		arena.letters = arena.letters[1:]
+	}
+	newLetter.char, newLetter.state = char, target
+	return newLetter
+}
+
+// Add a letter to the state, keeping the letters sorted. When the slice of the letters of the state is full,
+// a larger one is taken from a slab (the full one is only freed with the slab, see relocate).
+func (arena *arena) addLetter(curState *state, newLetter *letter) {
+	if len(curState.letters) == cap(curState.letters) {
+		capacity := max(1, 2*len(curState.letters))
+		if len(arena.pointers) < capacity {
+			arena.pointers = make([]*letter, max(arenaSlabSize, capacity))
+		}
+		letters := append(arena.pointers[:0:capacity], curState.letters...)
+		arena.pointers = arena.pointers[capacity:]
+		curState.letters = letters
+	}
+	curState.addLetter(newLetter)
+}
+
+// Give back a state which is not used anymore, with its letters, so they can be reused
+func (arena *arena) free(curState *state) {
+	for _, curLetter := range curState.letters {
+		*curLetter = letter{}
+		arena.freeLetters = append(arena.freeLetters, curLetter)
+	}
+	*curState = state{}
+	arena.freeStates = append(arena.freeStates, curState)
+}
+
+// Copy the states and the letters under initialState into slabs of the exact size, and return the copy
+// of initialState. The slabs of an arena stay in memory as long as one of their states is used: once the
+// DAWG is built, its states are copied so the memory of the states deleted by the minimization is freed.
+func relocate(initialState *state) *state {
+	states, numbers := numberStates(initialState)
+	lettersCount := 0
+	for _, curState := range states {
+		lettersCount += len(curState.letters)
+	}
+	newStates := make([]state, len(states))
+	newLetters := make([]letter, lettersCount)
+	newPointers := make([]*letter, lettersCount)
+	for i, curState := range states {
+		newStates[i].final, newStates[i].wordsCount = curState.final, curState.wordsCount
+		count := len(curState.letters)
+		stateLetters := newPointers[:count:count] // Full slice, so adding a letter can't overwrite the next state
+		for j, curLetter := range curState.letters {
+			newLetters[j] = letter{char: curLetter.char, state: &newStates[numbers[curLetter.state]]}
+			stateLetters[j] = &newLetters[j]
+		}
+		newStates[i].letters = stateLetters
+		newLetters, newPointers = newLetters[count:], newPointers[count:]
+	}
+	return &newStates[0]
+}
@@ -0,0 +1,54 @@
+package dawg
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestArena(t *testing.T) {
+	var arena arena
+	first := arena.newState()
+	first.final = true
+	first.letters = []*letter{arena.newLetter('a', arena.newState()), arena.newLetter('b', nil)}
+	arena.free(first)
+	if len(arena.freeStates) != 1 || len(arena.freeLetters) != 2 {
+		t.Error("free failed")
+	}
+	if reused := arena.newState(); reused != first || reused.final || reused.letters != nil {
+		t.Error("State reuse failed")
+	}
+	if reused := arena.newLetter('c', first); reused.char != 'c' || reused.state != first {
+		t.Error("Letter reuse failed")
+	}
+}
+
+func TestRelocate(t *testing.T) {
+	words := []string{"note", "notes", "test", "tests", "vote", "votes"}
+	dawg := CreateDAWG(words)
+	relocated := &DAWG{initialState: relocate(dawg.initialState)}
+	if !relocated.Equal(dawg) || relocated.CountWithPrefix("") != 6 || relocated.Compact().WordsCount() != 6 {
+		t.Error("relocate failed")
+	}
+	if relocated.initialState == dawg.initialState || !slices.Equal(slices.Collect(relocated.Words()), slices.Collect(dawg.Words())) {
+		t.Error("relocate failed")
+	}
+}
+
+func TestBuildAllocations(t *testing.T) {
+	words := make([]string, 2000)
+	for i := range words {
+		words[i] = strconv.Itoa(i * 7919)
+	}
+	var builder *builder
+	allocations := testing.AllocsPerRun(5, func() {
+		builder = newBuilder(BuildOptions{})
+		for i, word := range words {
+			builder.add(word, i+1)
+		}
+	})
+	// Far less than one allocation per state and letter of the trie
+	if allocations >= float64(builder.nbNodes)/100 {
+		t.Error("Trie allocations failed")
+	}
+}
@@ -0,0 +1,134 @@
+package dawg
+
+import "iter"
+
+// LevenshteinAutomaton accepts the words within a Levenshtein distance of a query.
+// It is built once for a query, and can be intersected with several graphs (see DAWG.Intersect), or stepped
+// manually letter by letter to combine it with other constraints.
+type LevenshteinAutomaton struct {
+	query     []rune
+	distance  int
+	transpose bool
+}
+
+// LevenshteinState is a state of a LevenshteinAutomaton, reached by reading some letters.
+// The states are immutable, so a state can be stepped with several letters.
+type LevenshteinState struct {
+	row      []int // row[j] is the distance between the letters read and the first j letters of the query
+	previous []int // Row before the last letter read, for the transpositions
+	last     rune  // Last letter read
+}
+
+// Create an automaton accepting the words at most at the given distance of the query.
+// If transpose is set, swapping two adjacent letters ("teh" -> "the") counts as one edit.
+func NewLevenshteinAutomaton(query string, distance int, transpose bool) *LevenshteinAutomaton {
+	return &LevenshteinAutomaton{query: []rune(query), distance: distance, transpose: transpose}
+}
+
+// Get the state of the automaton before reading any letter
+func (automaton *LevenshteinAutomaton) Start() LevenshteinState {
+	row := make([]int, len(automaton.query)+1)
+	for j := range row {
+		row[j] = j
+	}
+	return LevenshteinState{row: row}
+}
+
+// Get the state reached from the state by reading the letter
+func (automaton *LevenshteinAutomaton) Step(from LevenshteinState, char rune) LevenshteinState {
+	query := automaton.query
+	row := make([]int, len(query)+1)
+	row[0] = from.row[0] + 1
+	for j := 1; j <= len(query); j++ {
+		cost := 1
+		if query[j-1] == char {
+			cost = 0
+		}
+		row[j] = min(from.row[j]+1, row[j-1]+1, from.row[j-1]+cost)
+		if automaton.transpose && j > 1 && from.previous != nil && query[j-1] == from.last && query[j-2] == char {
+			row[j] = min(row[j], from.previous[j-2]+1)
+		}
+	}
+	return LevenshteinState{row: row, previous: from.row, last: char}
+}
+
+// Check if the letters read are a word accepted by the automaton
+func (automaton *LevenshteinAutomaton) IsMatch(state LevenshteinState) bool {
+	return state.row[len(automaton.query)] <= automaton.distance
+}
+
+// Check if some words starting with the letters read can be accepted by the automaton
+func (automaton *LevenshteinAutomaton) CanMatch(state LevenshteinState) bool {
+	// A transposition can still lower the distance of the next row by one
+	slack := 0
+	if automaton.transpose {
+		slack = 1
+	}
+	for _, distance := range state.row {
+		if distance <= automaton.distance+slack {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the distance between the letters read and the query
+func (automaton *LevenshteinAutomaton) Distance(state LevenshteinState) int {
+	return state.row[len(automaton.query)]
+}
+
+// Iterate over the words of the DAWG accepted by the automaton, with their distance, in lexicographic order
+func (dawg *DAWG) Intersect(automaton *LevenshteinAutomaton) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		automaton.walk(dawg.initialState, nil, automaton.Start(), yield)
+	}
+}
+
+// Call fn for each word under the state accepted by the automaton, in lexicographic order.
+// The walk stops as soon as fn returns false.
+func (automaton *LevenshteinAutomaton) walk(curState *state, word []rune, from LevenshteinState, fn func(Match) bool) bool {
+	if curState.final && automaton.IsMatch(from) && !fn(newMatch(string(word), automaton.Distance(from), runesSize(automaton.query))) {
+		return false
+	}
+	for _, curLetter := range curState.letters {
+		next := automaton.Step(from, curLetter.char)
+		if automaton.CanMatch(next) && !automaton.walk(curLetter.state, append(word, curLetter.char), next, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Iterate over the words of the DAWG accepted by the automaton, with their distance, in lexicographic order
+func (compact *CompactDAWG) Intersect(automaton *LevenshteinAutomaton) iter.Seq[Match] {
+	return flatIntersect(compact, automaton)
+}
+
+// Iterate over the words of the DAWG accepted by the automaton, with their distance, in lexicographic order
+func (succinct *SuccinctDAWG) Intersect(automaton *LevenshteinAutomaton) iter.Seq[Match] {
+	return flatIntersect(succinct, automaton)
+}
+
+// Iterate over the words of the graph accepted by the automaton, in lexicographic order
+func flatIntersect(graph flatGraph, automaton *LevenshteinAutomaton) iter.Seq[Match] {
+	return func(yield func(Match) bool) {
+		flatWalkAutomaton(graph, 0, nil, automaton, automaton.Start(), yield)
+	}
+}
+
+// Call fn for each word under the node accepted by the automaton, in lexicographic order.
+// The walk stops as soon as fn returns false.
+func flatWalkAutomaton(graph flatGraph, node uint32, word []rune, automaton *LevenshteinAutomaton, from LevenshteinState, fn func(Match) bool) bool {
+	if graph.final(node) && automaton.IsMatch(from) && !fn(newMatch(string(word), automaton.Distance(from), runesSize(automaton.query))) {
+		return false
+	}
+	first, last := graph.edges(node)
+	for edge := first; edge < last; edge++ {
+		char := graph.char(edge)
+		next := automaton.Step(from, char)
+		if automaton.CanMatch(next) && !flatWalkAutomaton(graph, graph.target(edge), append(word, char), automaton, next, fn) {
+			return false
+		}
+	}
+	return true
+}
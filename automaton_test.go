@@ -0,0 +1,42 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLevenshteinAutomaton(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tests", "tset", "nest", "tent", "te", "note"})
+	automaton := NewLevenshteinAutomaton("test", 1, false)
+
+	matches := slices.Collect(dawg.Intersect(automaton))
+	expected := []Match{newMatch("nest", 1, 4), newMatch("tent", 1, 4), newMatch("test", 0, 4), newMatch("tests", 1, 4)}
+	if !slices.Equal(matches, expected) {
+		t.Error("Intersect failed:", matches)
+	}
+	// The same automaton on other graphs
+	compact := dawg.Compact()
+	if !slices.Equal(slices.Collect(compact.Intersect(automaton)), expected) || !slices.Equal(slices.Collect(compact.Succinct().Intersect(automaton)), expected) {
+		t.Error("Intersect of the flat graphs failed")
+	}
+
+	transposing := NewLevenshteinAutomaton("test", 1, true)
+	if matches := slices.Collect(dawg.Intersect(transposing)); len(matches) != 5 || matches[4] != newMatch("tset", 1, 4) {
+		t.Error("Intersect with transpositions failed:", matches)
+	}
+
+	// Stepped manually
+	state := automaton.Start()
+	for _, char := range "tex" {
+		state = automaton.Step(state, char)
+	}
+	if automaton.IsMatch(state) || !automaton.CanMatch(state) || automaton.Distance(state) != 2 {
+		t.Error("Step failed")
+	}
+	if next := automaton.Step(state, 't'); !automaton.IsMatch(next) || automaton.Distance(next) != 1 {
+		t.Error("Step failed")
+	}
+	if next := automaton.Step(automaton.Step(state, 'x'), 'x'); automaton.CanMatch(next) {
+		t.Error("CanMatch failed")
+	}
+}
@@ -0,0 +1,52 @@
+package dawg
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+// Search each query with the options (see SearchWithOptions), and return the words found for each of them,
+// at the same index. The queries are spread over parallel workers sharing the DAWG, which must not be changed
+// during the call.
+func (dawg *DAWG) SearchBatch(queries []string, options SearchOptions) [][]Match {
+	results, _ := dawg.SearchBatchContext(context.Background(), queries, options) // Can't fail without a context
+	return results
+}
+
+// Same as SearchBatch, aborted with the error of the context as soon as it is done
+func (dawg *DAWG) SearchBatchContext(ctx context.Context, queries []string, options SearchOptions) ([][]Match, error) {
+	results := make([][]Match, len(queries))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var err error
+	for i := 0; i < min(runtime.GOMAXPROCS(0), len(queries)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range indexes {
+				matches, _, searchErr := dawg.search(ctx, queries[index], options)
+				if searchErr != nil {
+					errOnce.Do(func() { err = searchErr })
+				}
+				results[index] = matches
+			}
+		}()
+	}
+	for i := range queries {
+		if ctx.Err() != nil {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,37 @@
+package dawg
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestSearchBatch(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tests", "rest", "nest", "note", "text"})
+	queries := []string{"tast", "nose", "xxxxxx", "test"}
+	for i := range 100 {
+		queries = append(queries, fmt.Sprint("te", i))
+	}
+	options := SearchOptions{Distance: 2, MaxResults: 3, AllowAdd: true}
+
+	results := dawg.SearchBatch(queries, options)
+	if len(results) != len(queries) {
+		t.Fatal("SearchBatch failed:", len(results))
+	}
+	for i, query := range queries {
+		expected, _ := dawg.SearchWithOptions(query, options)
+		if !slices.Equal(results[i], expected) {
+			t.Error("SearchBatch failed for", query, results[i], expected)
+		}
+	}
+	if results = dawg.SearchBatch(nil, options); len(results) != 0 {
+		t.Error("SearchBatch failed without queries")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := dawg.SearchBatchContext(ctx, queries, options); err != context.Canceled {
+		t.Error("SearchBatchContext should fail with a canceled context:", err)
+	}
+}
@@ -0,0 +1,283 @@
+package dawg
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+)
+
+// The binary format is made of 4 parts, all integers being little endian:
+//   - a header: the magic bytes "DAWG", the version of the format (one byte, BinaryVersion), 3 bytes reserved
+//     for future flags (0), the number of nodes (uint32) and the number of edges (uint32)
+//   - the nodes: for each node, the index of its first edge (uint32) and its number of edges
+//     shifted by one, the lowest bit being set if the node is final (uint32)
+//   - the edges: for each edge, its rune (int32) and the index of the node it leads to (uint32)
+//   - a footer: the CRC-32 (Castagnoli) of all the bytes before it (uint32)
+//
+// The initial state is the node 0. The nodes are numbered in breadth-first order, and the edges
+// of a node are sorted by rune, so the file only depends on the words of the DAWG.
+// A new version of the format will only be used for changes which can't be read by the readers of
+// the previous versions; they return ErrVersion for the files of a later version.
+const (
+	binaryHeaderSize = 16
+	binaryNodeSize   = 8
+	binaryEdgeSize   = 8
+	binaryFooterSize = 4
+)
+
+// Version of the binary format written by Save and WriteTo
+const BinaryVersion = 1
+
+// Magic bytes at the start of the binary format
+var binaryMagic = []byte("DAWG")
+
+// Table of the checksums of the binary format
+var checksumTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Errors returned when reading data which is not a DAWG in the binary format (see LoadDAWG)
+var (
+	ErrBadMagic = errors.New("Incorrect binary format : not a DAWG.")
+	ErrVersion  = errors.New("Incorrect binary format : unsupported version.")
+	ErrChecksum = errors.New("Incorrect binary format : incorrect checksum, the data is corrupted.")
+)
+
+// Get the header of the binary format for the numbers of nodes and edges
+func appendBinaryHeader(buffer []byte, nodesCount uint32, edgesCount uint32) []byte {
+	buffer = append(buffer, binaryMagic...)
+	buffer = append(buffer, BinaryVersion, 0, 0, 0)
+	buffer = binary.LittleEndian.AppendUint32(buffer, nodesCount)
+	return binary.LittleEndian.AppendUint32(buffer, edgesCount)
+}
+
+// Get the numbers of nodes and edges from the header of the binary format
+func parseBinaryHeader(header []byte) (nodesCount uint32, edgesCount uint32, err error) {
+	if !bytes.Equal(header[:len(binaryMagic)], binaryMagic) {
+		return 0, 0, ErrBadMagic
+	}
+	if header[4] != BinaryVersion {
+		return 0, 0, ErrVersion
+	}
+	nodesCount = binary.LittleEndian.Uint32(header[8:])
+	edgesCount = binary.LittleEndian.Uint32(header[12:])
+	if nodesCount == 0 {
+		return 0, 0, errors.New("Incorrect binary format : no initial state.")
+	}
+	return
+}
+
+// Read the nodes and edges following the header of the binary format, and check the checksum of the footer.
+// The buffers grow with the bytes read, so the counts of a corrupted header can't allocate more than the data.
+func readBinaryBody(r io.Reader, header []byte, nodesCount uint32, edgesCount uint32) (nodes []byte, edges []byte, err error) {
+	if nodes, err = readBytes(r, binaryNodeSize*uint64(nodesCount)); err != nil {
+		return nil, nil, err
+	}
+	if edges, err = readBytes(r, binaryEdgeSize*uint64(edgesCount)); err != nil {
+		return nil, nil, err
+	}
+	footer := make([]byte, binaryFooterSize)
+	if _, err = io.ReadFull(r, footer); err != nil {
+		return nil, nil, unexpectedEOF(err)
+	}
+	checksum := crc32.Update(crc32.Update(crc32.Checksum(header, checksumTable), checksumTable, nodes), checksumTable, edges)
+	if checksum != binary.LittleEndian.Uint32(footer) {
+		return nil, nil, ErrChecksum
+	}
+	return
+}
+
+// Size of the buffers allocated at once by readBytes, the larger buffers growing with the bytes read
+const readChunkSize = 1 << 20
+
+// Read size bytes from r. The size being read from the data, which may be corrupted, only the buffers
+// up to readChunkSize are allocated before reading: the larger ones grow with the bytes actually read.
+func readBytes(r io.Reader, size uint64) ([]byte, error) {
+	if size <= readChunkSize {
+		buffer := make([]byte, size)
+		if _, err := io.ReadFull(r, buffer); err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		return buffer, nil
+	}
+	var buffer bytes.Buffer
+	if n, err := io.CopyN(&buffer, r, int64(size)); uint64(n) < size {
+		return nil, unexpectedEOF(err)
+	}
+	return buffer.Bytes(), nil
+}
+
+// Save the DAWG to a file in a compact binary format, to load it later with LoadDAWG
+func (dawg *DAWG) Save(fileName string) (err error) {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	writer := bufio.NewWriter(file)
+	if _, err = dawg.WriteTo(writer); err != nil {
+		return
+	}
+	return writer.Flush()
+}
+
+// Load from a file a DAWG saved by Save
+func LoadDAWG(fileName string) (*DAWG, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadDAWG(bufio.NewReader(file))
+}
+
+// Number the states of the DAWG in breadth-first order, following the letters in rune order.
+// The numbers only depend on the words, as a DAWG is minimal: the serializations of a set of words are the same bytes
+// whatever the order the words were added in, or the goroutines which minimized the DAWG.
+func numberStates(initialState *state) (states []*state, numbers map[*state]uint32) {
+	states = []*state{initialState}
+	numbers = map[*state]uint32{initialState: 0}
+	for i := 0; i < len(states); i++ {
+		for _, curLetter := range states[i].letters {
+			if _, ok := numbers[curLetter.state]; !ok {
+				numbers[curLetter.state] = uint32(len(states))
+				states = append(states, curLetter.state)
+			}
+		}
+	}
+	return
+}
+
+// Write the DAWG to w in the binary format used by Save, and return the number of bytes written
+func (dawg *DAWG) WriteTo(w io.Writer) (n int64, err error) {
+	states, numbers := numberStates(dawg.initialState)
+	var edgesCount uint32
+	for _, curState := range states {
+		edgesCount += uint32(len(curState.letters))
+	}
+
+	buffer := appendBinaryHeader(make([]byte, 0, binaryHeaderSize+binaryNodeSize*len(states)), uint32(len(states)), edgesCount)
+	var firstEdge uint32
+	for _, curState := range states {
+		flags := uint32(len(curState.letters)) << 1
+		if curState.final {
+			flags |= 1
+		}
+		buffer = binary.LittleEndian.AppendUint32(buffer, firstEdge)
+		buffer = binary.LittleEndian.AppendUint32(buffer, flags)
+		firstEdge += uint32(len(curState.letters))
+	}
+	checksum := crc32.Checksum(buffer, checksumTable)
+	written, err := w.Write(buffer)
+	n += int64(written)
+	if err != nil {
+		return
+	}
+
+	for _, curState := range states {
+		buffer = buffer[:0]
+		for _, curLetter := range curState.letters {
+			buffer = binary.LittleEndian.AppendUint32(buffer, uint32(curLetter.char))
+			buffer = binary.LittleEndian.AppendUint32(buffer, numbers[curLetter.state])
+		}
+		checksum = crc32.Update(checksum, checksumTable, buffer)
+		written, err = w.Write(buffer)
+		n += int64(written)
+		if err != nil {
+			return
+		}
+	}
+	written, err = w.Write(binary.LittleEndian.AppendUint32(buffer[:0], checksum))
+	n += int64(written)
+	return
+}
+
+// Read from r a DAWG written by WriteTo (or saved by Save).
+// Only the bytes of the DAWG are read, so several DAWGs can be read from the same stream.
+// The error is ErrBadMagic if the data is not a DAWG, ErrVersion if it was written by a later version
+// of the package in a format it can't read, and ErrChecksum if the data is corrupted.
+func ReadDAWG(r io.Reader) (*DAWG, error) {
+	header := make([]byte, binaryHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	nodesCount, edgesCount, err := parseBinaryHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	nodes, edges, err := readBinaryBody(r, header, nodesCount, edgesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	states := make([]state, nodesCount)
+	letters := make([]letter, edgesCount)
+	pointers := make([]*letter, edgesCount)
+	for i := range states {
+		node := nodes[i*binaryNodeSize:]
+		firstEdge := binary.LittleEndian.Uint32(node[0:])
+		flags := binary.LittleEndian.Uint32(node[4:])
+		lastEdge := uint64(firstEdge) + uint64(flags>>1)
+		if lastEdge > uint64(edgesCount) {
+			return nil, errors.New("Incorrect binary format : edge out of range.")
+		}
+		states[i].final = flags&1 != 0
+		stateLetters := pointers[firstEdge:firstEdge:lastEdge]
+		for j := firstEdge; j < uint32(lastEdge); j++ {
+			edge := edges[j*binaryEdgeSize:]
+			target := binary.LittleEndian.Uint32(edge[4:])
+			if target >= nodesCount {
+				return nil, errors.New("Incorrect binary format : node out of range.")
+			}
+			letters[j] = letter{char: rune(binary.LittleEndian.Uint32(edge[0:])), state: &states[target]}
+			if j > firstEdge && letters[j].char <= letters[j-1].char {
+				return nil, errors.New("Incorrect binary format : edges not sorted.")
+			}
+			stateLetters = append(stateLetters, &letters[j])
+		}
+		states[i].letters = stateLetters
+	}
+
+	initialState := &states[0]
+	if _, found := findCycle(initialState, nil, make(map[*state]bool)); found {
+		return nil, errors.New("Incorrect binary format : cycle.")
+	}
+	countWords(initialState, make(map[*state]bool))
+	return &DAWG{initialState: initialState, nodesCount: uint64(nodesCount), maxWordSize: longestWord(initialState, make(map[*state]int))}, nil
+}
+
+// Encode the DAWG in the binary format used by Save (implements encoding.BinaryMarshaler, used by encoding/gob)
+func (dawg *DAWG) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+	if _, err := dawg.WriteTo(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Decode a DAWG encoded by MarshalBinary, replacing the content of this DAWG
+// (implements encoding.BinaryUnmarshaler, used by encoding/gob)
+func (dawg *DAWG) UnmarshalBinary(data []byte) error {
+	reader := bytes.NewReader(data)
+	decoded, err := ReadDAWG(reader)
+	if err != nil {
+		return err
+	}
+	if reader.Len() != 0 {
+		return errors.New("Incorrect binary format : unexpected data after the DAWG.")
+	}
+	dawg.initialState = decoded.initialState
+	dawg.nodesCount = decoded.nodesCount
+	dawg.trieNodesCount = decoded.trieNodesCount
+	dawg.maxWordSize = decoded.maxWordSize
+	dawg.mutable = nil
+	dawg.frequencies, dawg.maxFrequency, dawg.flags = nil, 0, nil
+	dawg.resetCaches()
+	return nil
+}
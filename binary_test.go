@@ -0,0 +1,200 @@
+package dawg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSaveLoadDAWG(t *testing.T) {
+	words := []string{"test", "tese", "nest", "test2", "tes", "note", "日本"}
+	dawg := CreateDAWG(words)
+	fileName := filepath.Join(t.TempDir(), "words.dawg")
+	if err := dawg.Save(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadDAWG(fileName)
+	if err != nil || loaded.nodesCount != dawg.nodesCount || !loaded.Equal(dawg) {
+		t.Fatal("LoadDAWG failed")
+	}
+	for _, word := range words {
+		if !loaded.Check(word) {
+			t.Error("LoadDAWG failed")
+		}
+	}
+	test, err := loaded.Search("test", 1, 10, true, true)
+	if err != nil || len(test) != 5 {
+		t.Error("Search on a loaded DAWG failed")
+	}
+
+	// The file only depends on the words
+	otherFileName := filepath.Join(t.TempDir(), "other.dawg")
+	if err := CreateDAWG([]string{"日本", "note", "tes", "test2", "nest", "tese", "test"}).Save(otherFileName); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(fileName)
+	otherContent, _ := os.ReadFile(otherFileName)
+	if string(content) != string(otherContent) {
+		t.Error("Save is not deterministic")
+	}
+
+	if err := os.WriteFile(fileName, content[:len(content)-1], 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDAWG(fileName); err == nil {
+		t.Error("LoadDAWG should fail on a truncated file")
+	}
+}
+
+func TestWriteToReadDAWG(t *testing.T) {
+	a := CreateDAWG([]string{"test", "tese", "nest"})
+	b := CreateDAWG([]string{"note", "日本"})
+
+	var buffer bytes.Buffer
+	n, err := a.WriteTo(&buffer)
+	if err != nil || n != int64(buffer.Len()) {
+		t.Fatal("WriteTo failed")
+	}
+	if _, err = b.WriteTo(&buffer); err != nil {
+		t.Fatal("WriteTo failed")
+	}
+
+	readA, err := ReadDAWG(&buffer)
+	if err != nil || !readA.Equal(a) {
+		t.Error("ReadDAWG failed")
+	}
+	readB, err := ReadDAWG(&buffer)
+	if err != nil || !readB.Equal(b) || buffer.Len() != 0 {
+		t.Error("ReadDAWG failed")
+	}
+}
+
+func TestReproducibleSerialization(t *testing.T) {
+	words := []string{"test", "tests", "tese", "nest", "nests", "note", "日本", "a"}
+	serialize := func(dawg *DAWG) (binary []byte, text []byte, jsonText []byte) {
+		var buffer bytes.Buffer
+		if _, err := dawg.WriteTo(&buffer); err != nil {
+			t.Fatal(err)
+		}
+		fileName := filepath.Join(t.TempDir(), "words.dawg")
+		if err := dawg.SaveToFile(fileName); err != nil {
+			t.Fatal(err)
+		}
+		text, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if jsonText, err = dawg.MarshalJSON(); err != nil {
+			t.Fatal(err)
+		}
+		return buffer.Bytes(), text, jsonText
+	}
+	binary, text, jsonText := serialize(CreateDAWG(words))
+
+	// The words in another order, added one by one, or loaded from a file saved in another order
+	reversed := slices.Clone(words)
+	slices.Reverse(reversed)
+	added := CreateDAWG([]string{"x"})
+	for _, word := range reversed {
+		added.Add(word)
+	}
+	added.Remove("x")
+	fileName := filepath.Join(t.TempDir(), "words.dawg")
+	if err := added.SaveToFile(fileName); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := LoadDAWGFromFile(fileName)
+	if err != nil || !loaded.Equal(added) {
+		t.Fatal("LoadDAWGFromFile failed", err)
+	}
+	for _, dawg := range []*DAWG{CreateDAWG(reversed), added, loaded} {
+		otherBinary, otherText, otherJSON := serialize(dawg)
+		if !bytes.Equal(otherBinary, binary) || !bytes.Equal(otherText, text) || !bytes.Equal(otherJSON, jsonText) {
+			t.Error("Serialization of the same words failed")
+		}
+	}
+	// Saving twice gives the same file
+	if _, otherText, _ := serialize(loaded); !bytes.Equal(otherText, text) {
+		t.Error("SaveToFile twice failed")
+	}
+}
+
+func TestReadDAWGErrors(t *testing.T) {
+	var buffer bytes.Buffer
+	CreateDAWG([]string{"test", "tese", "nest"}).WriteTo(&buffer)
+	data := buffer.Bytes()
+	if string(data[:4]) != "DAWG" || data[4] != BinaryVersion {
+		t.Error("WriteTo header failed")
+	}
+
+	for _, test := range []struct {
+		offset   int
+		expected error
+	}{{0, ErrBadMagic}, {4, ErrVersion}, {binaryHeaderSize, ErrChecksum}, {len(data) - 1, ErrChecksum}} {
+		corrupted := bytes.Clone(data)
+		corrupted[test.offset]++
+		if _, err := ReadDAWG(bytes.NewReader(corrupted)); err != test.expected {
+			t.Error("ReadDAWG of corrupted data failed", test.offset, err)
+		}
+		if _, err := NewMappedDAWG(corrupted); test.expected != ErrChecksum && err != test.expected {
+			t.Error("NewMappedDAWG of corrupted data failed", test.offset, err)
+		}
+	}
+	if _, err := ReadDAWG(bytes.NewReader(data[:len(data)-1])); err != io.ErrUnexpectedEOF {
+		t.Error("ReadDAWG of truncated data failed", err)
+	}
+
+	if _, err := ReadDAWG(bytes.NewReader(cycleBinary())); err == nil {
+		t.Error("ReadDAWG of a cycle failed")
+	}
+	if _, err := ReadDAWG(bytes.NewReader(hugeBinary())); err != io.ErrUnexpectedEOF {
+		t.Error("ReadDAWG of huge counts failed", err)
+	}
+}
+
+// Get the binary format of a final state with a letter leading to itself, with a correct checksum
+func cycleBinary() []byte {
+	data := appendBinaryHeader(nil, 1, 1)
+	data = binary.LittleEndian.AppendUint32(data, 0)
+	data = binary.LittleEndian.AppendUint32(data, 1<<1|1)
+	data = binary.LittleEndian.AppendUint32(data, 'a')
+	data = binary.LittleEndian.AppendUint32(data, 0)
+	return binary.LittleEndian.AppendUint32(data, crc32.Checksum(data, checksumTable))
+}
+
+// Get a header of the binary format with counts much larger than the data following it
+func hugeBinary() []byte {
+	return append(appendBinaryHeader(nil, 0x7fffffff, 0x7fffffff), make([]byte, 100)...)
+}
+
+func TestGob(t *testing.T) {
+	type dictionary struct {
+		Name  string
+		Words *DAWG
+	}
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "日本"})
+
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(dictionary{Name: "test", Words: dawg}); err != nil {
+		t.Fatal(err)
+	}
+	var decoded dictionary
+	if err := gob.NewDecoder(&buffer).Decode(&decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Name != "test" || decoded.Words == nil || !decoded.Words.Equal(dawg) || decoded.Words.nodesCount != dawg.nodesCount {
+		t.Error("Gob failed")
+	}
+
+	data, _ := dawg.MarshalBinary()
+	if err := new(DAWG).UnmarshalBinary(append(data, 0)); err == nil {
+		t.Error("UnmarshalBinary should fail with trailing data")
+	}
+}
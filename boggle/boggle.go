@@ -0,0 +1,101 @@
+// Package boggle finds the words of a DAWG which can be placed on a grid of letters, as in the Boggle game:
+// a word is a path of adjacent cells (horizontally, vertically or diagonally), each cell being used at most once.
+// The grid is walked depth-first from each cell with a dawg.Cursor, so only the paths which are the prefix of a word
+// are followed.
+package boggle
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/ftbe/dawg"
+)
+
+// Position is a cell of the grid
+type Position struct {
+	Row    int
+	Column int
+}
+
+// Word is a word found on the grid, with the cells of its letters in order
+type Word struct {
+	Word string
+	Path []Position
+}
+
+// Options configures the words found on the grid
+type Options struct {
+	MinLength  int  // Minimum length of the words, in runes (3 in Boggle, 0 for no limit)
+	NoDiagonal bool // The letters of a word can't follow each other diagonally
+}
+
+// Get the words of the DAWG which can be placed on the grid, each word only once (with the first path found,
+// walking the cells row by row), sorted lexicographically. The rows of the grid must have the same length.
+func Solve(d *dawg.DAWG, grid [][]rune, options Options) ([]Word, error) {
+	for _, row := range grid {
+		if len(row) != len(grid[0]) {
+			return nil, errors.New("Incorrect grid : the rows don't have the same length.")
+		}
+	}
+	solver := &solver{grid: grid, options: options, found: make(map[string]bool), words: []Word{}}
+	for row := range grid {
+		solver.used = append(solver.used, make([]bool, len(grid[row])))
+	}
+	for row := range grid {
+		for column := range grid[row] {
+			solver.walk(d.Root(), Position{row, column})
+		}
+	}
+	slices.SortFunc(solver.words, func(a Word, b Word) int {
+		return strings.Compare(a.Word, b.Word)
+	})
+	return solver.words, nil
+}
+
+// Offsets of the cells adjacent to a cell, the diagonals last
+var neighbors = []Position{{-1, 0}, {0, -1}, {0, 1}, {1, 0}, {-1, -1}, {-1, 1}, {1, -1}, {1, 1}}
+
+// A solver holds the state of the walk of a grid
+type solver struct {
+	grid    [][]rune
+	options Options
+	used    [][]bool   // The cells of the current path
+	path    []Position // The current path
+	letters []rune     // The letters of the current path
+	found   map[string]bool
+	words   []Word
+}
+
+// Extend the current path, leading to the cursor, with the cell, and walk the paths starting with it
+func (solver *solver) walk(cursor dawg.Cursor, cell Position) {
+	cursor, ok := cursor.Advance(solver.grid[cell.Row][cell.Column])
+	if !ok {
+		return
+	}
+	solver.used[cell.Row][cell.Column] = true
+	solver.path = append(solver.path, cell)
+	solver.letters = append(solver.letters, solver.grid[cell.Row][cell.Column])
+	defer func() {
+		solver.used[cell.Row][cell.Column] = false
+		solver.path = solver.path[:len(solver.path)-1]
+		solver.letters = solver.letters[:len(solver.letters)-1]
+	}()
+
+	if cursor.IsFinal() && len(solver.letters) >= solver.options.MinLength {
+		if word := string(solver.letters); !solver.found[word] {
+			solver.found[word] = true
+			solver.words = append(solver.words, Word{Word: word, Path: slices.Clone(solver.path)})
+		}
+	}
+	offsets := neighbors
+	if solver.options.NoDiagonal {
+		offsets = neighbors[:4]
+	}
+	for _, offset := range offsets {
+		next := Position{cell.Row + offset.Row, cell.Column + offset.Column}
+		if next.Row >= 0 && next.Row < len(solver.grid) && next.Column >= 0 && next.Column < len(solver.grid[next.Row]) && !solver.used[next.Row][next.Column] {
+			solver.walk(cursor, next)
+		}
+	}
+}
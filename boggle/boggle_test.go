@@ -0,0 +1,59 @@
+package boggle
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ftbe/dawg"
+)
+
+func TestSolve(t *testing.T) {
+	d := dawg.CreateDAWG([]string{"cat", "cats", "act", "tact", "sat", "at", "dog", "scat", "taco"})
+	grid := [][]rune{
+		[]rune("cat"),
+		[]rune("xso"),
+	}
+
+	words, err := Solve(d, grid, Options{MinLength: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found []string
+	for _, word := range words {
+		found = append(found, word.Word)
+	}
+	// "act" has no "t" next to the "c", "tact" needs the "t" twice, "dog" has no "d"
+	if !slices.Equal(found, []string{"cat", "cats", "sat", "scat"}) {
+		t.Error("Solve failed", found)
+	}
+	for _, word := range words {
+		for i, cell := range word.Path {
+			if grid[cell.Row][cell.Column] != []rune(word.Word)[i] {
+				t.Error("Solve path failed", word)
+			}
+			if i > 0 && (max(cell.Row-word.Path[i-1].Row, word.Path[i-1].Row-cell.Row) > 1 || max(cell.Column-word.Path[i-1].Column, word.Path[i-1].Column-cell.Column) > 1) {
+				t.Error("Solve path of non adjacent cells", word)
+			}
+		}
+	}
+	if !slices.Equal(words[0].Path, []Position{{0, 0}, {0, 1}, {0, 2}}) {
+		t.Error("Solve path failed", words[0])
+	}
+
+	// "cats" and "scat" need diagonals
+	words, _ = Solve(d, grid, Options{MinLength: 3, NoDiagonal: true})
+	found = nil
+	for _, word := range words {
+		found = append(found, word.Word)
+	}
+	if !slices.Equal(found, []string{"cat", "sat"}) {
+		t.Error("Solve without diagonals failed", found)
+	}
+
+	if words, _ = Solve(d, grid, Options{}); len(words) != 5 || words[0].Word != "at" {
+		t.Error("Solve without MinLength failed", words)
+	}
+	if _, err := Solve(d, [][]rune{[]rune("ab"), []rune("c")}, Options{}); err == nil {
+		t.Error("Solve of an incorrect grid failed")
+	}
+}
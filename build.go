@@ -0,0 +1,263 @@
+package dawg
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// BuildOptions configures how a DAWG is built from a word list
+type BuildOptions struct {
+	MaxWordLength int            // Maximum length of a word, in runes (0 for no limit)
+	MaxLineSize   int            // Maximum size of a line of a file, in bytes (0 for the bufio.Scanner default, 64 KiB)
+	Decompressors []Decompressor // Compression formats of the files, besides gzip (see Decompressor)
+	// Normalization of the words, the queries must be normalized the same way (see SearchOptions).
+	// Mixing normalization forms would add the same word several times, with different letters.
+	Normalize       func(word string) string // Unicode normalization form, norm.NFC.String of golang.org/x/text for example
+	StripDiacritics bool                     // Remove the diacritics of the words (see StripDiacritics)
+	FoldCase        bool                     // Fold the case of the words (see FoldCase)
+	// Separators of the words of the phrases, to add entries of several words ("New York", "state-of-the-art"):
+	// the runs of separators are collapsed and the separators at the ends removed (see NormalizeSeparators),
+	// so a phrase written with extra spaces is added once. " -" for the spaces and the hyphens, for example.
+	Separators string
+	// Validation of the words: in strict mode, a word which is not valid UTF-8, starts or ends with a space,
+	// or contains a control character is an error (ErrInvalidUTF8, ErrSpace or ErrControlCharacter, in a LineError)
+	Strict      bool
+	SkipInvalid bool                 // In strict mode, skip the invalid words instead of returning an error
+	OnInvalid   func(err *LineError) // If not nil, called for each invalid word skipped (to count or log them)
+	// Filtering of the lines, before their validation and normalization
+	SkipBlankLines bool                             // Skip the empty lines, and the lines of spaces
+	SkipComments   bool                             // Skip the lines starting with '#'
+	Filter         func(word string) (string, bool) // If not nil, get the word to add for each line, or false to skip the line
+	// Handling of the words found on several lines (after their normalization): see DuplicatePolicy
+	Duplicates DuplicatePolicy
+
+	Progress func(progress BuildProgress) // If not nil, called regularly while the words are read, then at each phase
+	Metrics  Metrics                      // If not nil, observes the build, then the searches of the DAWG (see DAWG.SetMetrics)
+
+	// Limits of the build, to build word lists from untrusted sources: the build stops with ErrTooManyNodes or
+	// ErrTooMuchMemory (in a LineError, for the word exceeding the limit) instead of using all the memory
+	MaxNodes  uint64 // Maximum number of nodes held while building (0 for no limit)
+	MaxMemory uint64 // Maximum memory of the nodes held while building, in bytes, as estimated by Stats (0 for no limit)
+}
+
+// DuplicatePolicy tells what to do with the words found on several lines of a word list or a CSV file.
+// A word is always added once to the DAWG: the policies differ by the frequency and the tag kept for it
+// in a CSV file (see CreateDAWGFromCSV and CreateMapFromCSV).
+type DuplicatePolicy int
+
+const (
+	SumDuplicates    DuplicatePolicy = iota // The frequencies of the lines are added up, the last tag is kept (the default)
+	IgnoreDuplicates                        // The lines after the first line of the word are ignored
+	RejectDuplicates                        // A word found again is an error (ErrDuplicate, in a LineError)
+	MergeDuplicates                         // The frequencies and tags are merged by CSVOptions.Merge
+)
+
+// ErrDuplicate is returned (wrapped in a LineError) for a word found again with RejectDuplicates
+var ErrDuplicate = errors.New("Duplicate word.")
+
+// ErrWordTooLong is returned (wrapped in a LineError) when a word is longer than BuildOptions.MaxWordLength
+var ErrWordTooLong = errors.New("Word too long.")
+
+// ErrTooManyNodes is returned (wrapped in a LineError) when a build needs more than BuildOptions.MaxNodes nodes
+var ErrTooManyNodes = errors.New("Too many nodes, the build exceeds the maximum number of nodes.")
+
+// ErrTooMuchMemory is returned (wrapped in a LineError) when a build needs more than BuildOptions.MaxMemory bytes
+var ErrTooMuchMemory = errors.New("Too much memory, the build exceeds the maximum memory.")
+
+// LineError is returned when a word of a word list can't be added to a DAWG
+type LineError struct {
+	Line int // Number of the line of the file (or index of the word in the array, plus one)
+	Byte int // Position of the invalid byte in the word, counting from 1 (0 if the error is not about a byte)
+	Err  error
+}
+
+func (err *LineError) Error() string {
+	if err.Byte > 0 {
+		return "Line " + strconv.Itoa(err.Line) + ", byte " + strconv.Itoa(err.Byte) + ": " + err.Err.Error()
+	}
+	return "Line " + strconv.Itoa(err.Line) + ": " + err.Err.Error()
+}
+
+func (err *LineError) Unwrap() error {
+	return err.Err
+}
+
+// Create a new DAWG by loading the words from a file, with options.
+// The file must be UTF-8 encoded, one word per line. It may be compressed (see CreateDAWGFromReader).
+func CreateDAWGFromFileWithOptions(fileName string, options BuildOptions) (dawg *DAWG, err error) {
+	return CreateDAWGFromFileContext(context.Background(), fileName, options)
+}
+
+// Create a new DAWG by reading the words from r, with options.
+// The words must be UTF-8 encoded, one word per line. If they are compressed with gzip, or with one
+// of the formats of options.Decompressors, they are decompressed on the fly.
+func CreateDAWGFromReader(r io.Reader, options BuildOptions) (dawg *DAWG, err error) {
+	return CreateDAWGFromReaderContext(context.Background(), r, options)
+}
+
+// Call fn for each line read from r (decompressed if needed), with its number, until fn returns an error
+func scanLines(r io.Reader, options BuildOptions, fn func(text string, line int) error) error {
+	reader, err := decompress(bufio.NewReader(r), options.Decompressors)
+	if err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(reader)
+	if options.MaxLineSize > 0 {
+		scanner.Buffer(make([]byte, 0, min(options.MaxLineSize, bufio.MaxScanTokenSize)), options.MaxLineSize)
+	}
+	line := 0
+	for scanner.Scan() {
+		line++
+		if err = fn(scanner.Text(), line); err != nil {
+			return err
+		}
+	}
+	if err = scanner.Err(); err == bufio.ErrTooLong {
+		err = &LineError{Line: line + 1, Err: err}
+	}
+	return err
+}
+
+// Create a new DAWG by loading the words from an array, with options.
+func CreateDAWGWithOptions(words []string, options BuildOptions) (*DAWG, error) {
+	start := time.Now()
+	builder := newAdaptiveBuilder(options)
+	for i, word := range words {
+		if err := builder.add(word, i+1); err != nil {
+			return nil, err
+		}
+	}
+	dawg := builder.dawg()
+	options.observeBuild(start, dawg)
+	return dawg, nil
+}
+
+// A builder adds words to a trie, and compresses it into a DAWG at the end
+type builder struct {
+	options      BuildOptions
+	arena        arena
+	initialState *state
+	nbNodes      uint64
+	maxWordSize  int
+}
+
+// Create a new builder with an empty trie
+func newBuilder(options BuildOptions) *builder {
+	return &builder{options: options, initialState: &state{final: false}, nbNodes: 1}
+}
+
+// Add the word found at the given line to the trie
+func (builder *builder) add(word string, line int) error {
+	if builder.options.MaxWordLength > 0 && utf8.RuneCountInString(word) > builder.options.MaxWordLength {
+		return &LineError{Line: line, Err: ErrWordTooLong}
+	}
+	if builder.options.Duplicates == RejectDuplicates {
+		if curState := builder.initialState.follow(word); curState != nil && curState.final {
+			return &LineError{Line: line, Err: ErrDuplicate}
+		}
+	}
+	_, size, createdNodes := addWord(&builder.arena, builder.initialState, word)
+	if size > builder.maxWordSize {
+		builder.maxWordSize = size
+	}
+	builder.nbNodes += createdNodes
+	return nil
+}
+
+// Compress the trie into a DAWG
+func (builder *builder) dawg() *DAWG {
+	dawg, _ := builder.dawgContext(context.Background()) // Can't fail without a deadline
+	return dawg
+}
+
+// Same as dawg, aborted with the error of the context as soon as it is done
+func (builder *builder) dawgContext(ctx context.Context) (*DAWG, error) {
+	trieNodes := builder.nbNodes
+	deletedNodes, err := compressTrie(ctx, builder.initialState, builder.maxWordSize)
+	if err != nil {
+		return nil, err
+	}
+	countWords(builder.initialState, make(map[*state]bool))
+	return &DAWG{initialState: relocate(builder.initialState), nodesCount: trieNodes - deletedNodes, trieNodesCount: trieNodes, maxWordSize: builder.maxWordSize}, nil
+}
+
+// An adaptiveBuilder builds the DAWG incrementally while the words are sorted (see CreateDAWGFromSorted),
+// and through a trie as soon as they are not
+type adaptiveBuilder struct {
+	sorted *sortedBuilder
+	trie   *builder // nil while the words are sorted
+}
+
+// Create a new builder of an empty DAWG
+func newAdaptiveBuilder(options BuildOptions) *adaptiveBuilder {
+	return &adaptiveBuilder{sorted: newSortedBuilder(options)}
+}
+
+// Add the word found at the given line
+func (builder *adaptiveBuilder) add(word string, line int) error {
+	word, skip, err := builder.sorted.options.prepare(word, line)
+	if skip || err != nil {
+		return err
+	}
+	if builder.trie == nil {
+		err = builder.sorted.add(word, line)
+		if errors.Is(err, ErrNotSorted) {
+			builder.trie = builder.sorted.trieBuilder()
+			err = builder.trie.add(word, line)
+		}
+	} else {
+		err = builder.trie.add(word, line)
+	}
+	if err != nil {
+		return err
+	}
+	return builder.checkLimits(line)
+}
+
+// Check that the nodes held after adding the word of the line don't exceed the limits of the options
+func (builder *adaptiveBuilder) checkLimits(line int) error {
+	options := builder.sorted.options
+	if options.MaxNodes == 0 && options.MaxMemory == 0 {
+		return nil
+	}
+	// The states which are not minimized yet, and the states of the trie, have one letter each
+	nodes := builder.sorted.nbNodes
+	if builder.trie != nil {
+		nodes = builder.trie.nbNodes
+	}
+	if options.MaxNodes > 0 && nodes > options.MaxNodes {
+		return &LineError{Line: line, Err: ErrTooManyNodes}
+	}
+	if options.MaxMemory > 0 && graphBytes(nodes, nodes) > options.MaxMemory {
+		return &LineError{Line: line, Err: ErrTooMuchMemory}
+	}
+	return nil
+}
+
+// Get the number of nodes created, before the minimization
+func (builder *adaptiveBuilder) nodes() uint64 {
+	if builder.trie == nil {
+		return builder.sorted.trieNodes
+	}
+	return builder.trie.nbNodes
+}
+
+// Get the minimal DAWG of the words added
+func (builder *adaptiveBuilder) dawg() *DAWG {
+	dawg, _ := builder.dawgContext(context.Background()) // Can't fail without a deadline
+	return dawg
+}
+
+// Same as dawg, aborted with the error of the context as soon as it is done while the trie is compressed
+// (the words added in order being already minimized)
+func (builder *adaptiveBuilder) dawgContext(ctx context.Context) (*DAWG, error) {
+	if builder.trie == nil {
+		return builder.sorted.dawg(), nil
+	}
+	return builder.trie.dawgContext(ctx)
+}
@@ -0,0 +1,7 @@
+//go:build !tinygo
+
+package dawg
+
+// The builds use goroutines: the subtries are analysed in parallel by compressTrie, and the files of
+// CreateDAWGFromFiles are loaded and merged in parallel
+const parallelBuild = true
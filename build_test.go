@@ -0,0 +1,143 @@
+package dawg
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreateDAWGWithOptions(t *testing.T) {
+	_, err := CreateDAWGWithOptions([]string{"test", "testing", "tests"}, BuildOptions{MaxWordLength: 5})
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 || !errors.Is(err, ErrWordTooLong) {
+		t.Error("MaxWordLength failed")
+	}
+
+	dawg, err := CreateDAWGWithOptions([]string{"test", "tests"}, BuildOptions{MaxWordLength: 5})
+	if err != nil || dawg.nodesCount != 6 {
+		t.Error("MaxWordLength failed")
+	}
+}
+
+func TestBuildDuplicates(t *testing.T) {
+	// Sorted, then not sorted
+	for _, words := range [][]string{{"nest", "test", "test", "tests"}, {"test", "nest", "test", "tests"}} {
+		if dawg, err := CreateDAWGWithOptions(words, BuildOptions{}); err != nil || dawg.WordsCount() != 3 {
+			t.Error("Duplicates failed", words, err)
+		}
+		_, err := CreateDAWGWithOptions(words, BuildOptions{Duplicates: RejectDuplicates})
+		var lineErr *LineError
+		if !errors.As(err, &lineErr) || lineErr.Line != 3 || !errors.Is(err, ErrDuplicate) {
+			t.Error("RejectDuplicates failed", words, err)
+		}
+	}
+	// The words are duplicates once normalized
+	_, err := CreateDAWGFromReader(strings.NewReader("Test\ntest\n"), BuildOptions{FoldCase: true, Duplicates: RejectDuplicates})
+	if !errors.Is(err, ErrDuplicate) {
+		t.Error("RejectDuplicates of normalized words failed", err)
+	}
+}
+
+func TestBuildLimits(t *testing.T) {
+	// The nodes of the words are held until they are minimized: 9 after "nest" and "test",
+	// 10 after "test", "tests" and "nest"
+	for _, test := range []struct {
+		words []string
+		line  int
+	}{{[]string{"nest", "test", "tests"}, 2}, {[]string{"test", "tests", "nest"}, 3}} {
+		words := test.words
+		_, err := CreateDAWGWithOptions(words, BuildOptions{MaxNodes: 8})
+		var lineErr *LineError
+		if !errors.As(err, &lineErr) || lineErr.Line != test.line || !errors.Is(err, ErrTooManyNodes) {
+			t.Error("MaxNodes failed", err)
+		}
+		if _, err := CreateDAWGWithOptions(words, BuildOptions{MaxNodes: 10}); err != nil {
+			t.Error("MaxNodes failed", err)
+		}
+
+		_, err = CreateDAWGWithOptions(words, BuildOptions{MaxMemory: graphBytes(8, 8)})
+		if !errors.Is(err, ErrTooMuchMemory) {
+			t.Error("MaxMemory failed", err)
+		}
+		if _, err := CreateDAWGWithOptions(words, BuildOptions{MaxMemory: graphBytes(10, 10)}); err != nil {
+			t.Error("MaxMemory failed", err)
+		}
+	}
+
+	_, err := CreateDAWGFromReader(strings.NewReader(strings.Repeat("a", 100)+"\n"), BuildOptions{MaxNodes: 50})
+	if !errors.Is(err, ErrTooManyNodes) {
+		t.Error("MaxNodes of CreateDAWGFromReader failed", err)
+	}
+}
+
+func TestCreateDAWGFromFileWithOptions(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "words.txt")
+	long := strings.Repeat("a", 2*bufio.MaxScanTokenSize)
+	if err := os.WriteFile(fileName, []byte("test\n"+long+"\nnote\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := CreateDAWGFromFile(fileName)
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 || !errors.Is(err, bufio.ErrTooLong) {
+		t.Error("Default line size failed")
+	}
+
+	dawg, err := CreateDAWGFromFileWithOptions(fileName, BuildOptions{MaxLineSize: 3 * bufio.MaxScanTokenSize})
+	if err != nil || dawg.maxWordSize != len(long) {
+		t.Fatal("MaxLineSize failed")
+	}
+
+	// The search of a long word should stop as soon as it can't match anymore
+	words, err := dawg.Search(strings.Repeat("b", 3*bufio.MaxScanTokenSize), 2, 10, true, true)
+	if err != nil || len(words) != 0 {
+		t.Error("Long search failed")
+	}
+	words, err = dawg.Search(long[1:], 1, 10, true, false)
+	if err != nil || len(words) != 1 || words[0] != long {
+		t.Error("Long search failed")
+	}
+}
+
+// Get the words of 3 lowercase letters, sorted: each state has 26 letters
+func sortedBenchmarkWords() []string {
+	var words []string
+	for a := 'a'; a <= 'z'; a++ {
+		for b := 'a'; b <= 'z'; b++ {
+			for c := 'a'; c <= 'z'; c++ {
+				words = append(words, string([]rune{a, b, c}))
+			}
+		}
+	}
+	return words
+}
+
+func BenchmarkTrieSorted(b *testing.B) {
+	words := sortedBenchmarkWords()
+	for b.Loop() {
+		builder := newBuilder(BuildOptions{})
+		for i, word := range words {
+			builder.add(word, i+1)
+		}
+	}
+}
+
+func BenchmarkCreateDAWGSorted(b *testing.B) {
+	words := sortedBenchmarkWords()
+	for b.Loop() {
+		CreateDAWG(words)
+	}
+}
+
+func BenchmarkFollowSorted(b *testing.B) {
+	words := sortedBenchmarkWords()
+	dawg := CreateDAWG(words)
+	i := 0
+	for b.Loop() {
+		dawg.initialState.follow(words[i%len(words)])
+		i++
+	}
+}
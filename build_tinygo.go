@@ -0,0 +1,9 @@
+//go:build tinygo
+
+package dawg
+
+// Compiled by TinyGo (for WebAssembly in a browser, or a microcontroller), the builds don't start goroutines:
+// the subtries and the files are handled one after the other. ExpvarMetrics, which needs net/http, is left out.
+// To only answer queries (a client-side autocomplete), load a CompactDAWG (ReadCompactDAWG) or a SuccinctDAWG,
+// which are stored in a few flat slices, rather than building a DAWG.
+const parallelBuild = false
@@ -0,0 +1,58 @@
+package dawg
+
+import (
+	"bufio"
+	"io"
+	"iter"
+	"strings"
+	"unicode/utf8"
+)
+
+// CheckOptions configures the spell-checking of a text
+type CheckOptions struct {
+	Suggestions    int            // Number of suggestions for each misspelling, 0 for none
+	SuggestOptions SuggestOptions // Options used to search the suggestions
+}
+
+// Misspelling is a word of a text which is not in the DAWG
+type Misspelling struct {
+	Word        string
+	Line        int // Line of the word, starting at 1
+	Column      int // Column of the word in the line, in runes, starting at 1
+	Suggestions []Suggestion
+
+	// If the text could not be read, the sequence ends with a Misspelling holding only the error
+	Err error
+}
+
+// Spell-check the text read from r, yielding the words which are not in the DAWG as soon as
+// their line is read
+func (dawg *DAWG) CheckReader(r io.Reader, options CheckOptions) iter.Seq[Misspelling] {
+	return func(yield func(Misspelling) bool) {
+		reader := bufio.NewReader(r)
+		for lineNumber := 1; ; lineNumber++ {
+			line, err := reader.ReadString('\n')
+			stopped := false
+			scanWords(strings.TrimRight(line, "\r\n"), func(word string, start int, end int) {
+				if stopped || dawg.Check(word) {
+					return
+				}
+				misspelling := Misspelling{Word: word, Line: lineNumber, Column: utf8.RuneCountInString(line[:start]) + 1}
+				if options.Suggestions > 0 {
+					misspelling.Suggestions = dawg.SuggestWithOptions(word, options.Suggestions, options.SuggestOptions)
+				}
+				stopped = !yield(misspelling)
+			})
+			if stopped {
+				return
+			}
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				yield(Misspelling{Err: err})
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestCheckReader(t *testing.T) {
+	dawg := CreateDAWG([]string{"the", "cat", "sat", "on", "mat"})
+
+	var misspellings []Misspelling
+	for misspelling := range dawg.CheckReader(strings.NewReader("the cat sat\r\nön thw mat\nthe cat"), CheckOptions{Suggestions: 1}) {
+		misspellings = append(misspellings, misspelling)
+	}
+	if len(misspellings) != 2 || misspellings[0].Word != "ön" || misspellings[0].Line != 2 || misspellings[0].Column != 1 {
+		t.Fatal("CheckReader failed")
+	}
+	if misspellings[1].Word != "thw" || misspellings[1].Column != 4 || len(misspellings[1].Suggestions) != 1 || misspellings[1].Suggestions[0].Word != "the" {
+		t.Error("CheckReader failed")
+	}
+
+	misspellings = nil
+	for misspelling := range dawg.CheckReader(iotest.TimeoutReader(strings.NewReader("the cat sat on the mat\n")), CheckOptions{}) {
+		misspellings = append(misspellings, misspelling)
+	}
+	if len(misspellings) != 1 || misspellings[0].Err == nil {
+		t.Error("CheckReader error failed")
+	}
+}
@@ -0,0 +1,296 @@
+// Command dawg builds and inspects DAWGs.
+//
+// Usage:
+//
+//	dawg build -o output words
+//	dawg search [-words] [-d distance] [-n max] file word
+//	dawg stats [-words] [-report] file
+//	dawg export [-words] [-dot | -mermaid | -json] file
+//	dawg generate [-words] [-package name] [-name name] -o output file
+//	dawg verify [-words] file
+//	dawg equal [-words] file1 file2
+//
+// The files are DAWGs saved by SaveToFile (as built by dawg build), or word lists (UTF-8 encoded,
+// one word per line) with -words. The flags can be given after the files.
+// search prints the words close to the word with their distance, best first. export prints the words
+// of the DAWG, or its graph in the Graphviz DOT format with -dot, as a Mermaid flowchart with -mermaid, or as
+// lists of nodes and edges in JSON with -json. generate writes a Go source file embedding
+// the DAWG (see DAWG.WriteGo), and can be used with go:generate.
+// stats -report prints the suffixes shared the most (see DAWG.Report).
+// verify exits with a non-zero status if the DAWG is not minimal, equal if the DAWGs don't contain the same words.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ftbe/dawg"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	var err error
+	switch os.Args[1] {
+	case "build":
+		err = build(os.Args[2:])
+	case "search":
+		err = search(os.Args[2:])
+	case "export":
+		err = export(os.Args[2:])
+	case "generate":
+		err = generate(os.Args[2:])
+	case "stats":
+		err = stats(os.Args[2:])
+	case "verify":
+		err = verify(os.Args[2:])
+	case "equal":
+		err = equal(os.Args[2:])
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: dawg build -o output words")
+	fmt.Fprintln(os.Stderr, "       dawg search [-words] [-d distance] [-n max] file word")
+	fmt.Fprintln(os.Stderr, "       dawg stats [-words] [-report] file")
+	fmt.Fprintln(os.Stderr, "       dawg export [-words] [-dot | -mermaid | -json] file")
+	fmt.Fprintln(os.Stderr, "       dawg generate [-words] [-package name] [-name name] -o output file")
+	fmt.Fprintln(os.Stderr, "       dawg verify [-words] file")
+	fmt.Fprintln(os.Stderr, "       dawg equal [-words] file1 file2")
+	os.Exit(2)
+}
+
+// Load the DAWG from a file saved by SaveToFile, or build it from a word list
+func load(fileName string, words bool) (*dawg.DAWG, error) {
+	if words {
+		return dawg.CreateDAWGFromFile(fileName)
+	}
+	return dawg.LoadDAWGFromFile(fileName)
+}
+
+// Parse the arguments, the flags being allowed before and after the other arguments.
+// Get the other arguments.
+func parse(flags *flag.FlagSet, args []string) []string {
+	var others []string
+	for {
+		flags.Parse(args)
+		if flags.NArg() == 0 {
+			return others
+		}
+		others = append(others, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+}
+
+// Build a DAWG from a word list, and save it
+func build(args []string) error {
+	flags := flag.NewFlagSet("build", flag.ExitOnError)
+	output := flags.String("o", "", "file the DAWG is saved to")
+	files := parse(flags, args)
+	if len(files) != 1 || *output == "" {
+		usage()
+	}
+
+	graph, err := dawg.CreateDAWGFromFile(files[0])
+	if err != nil {
+		return err
+	}
+	if err = graph.SaveToFile(*output); err != nil {
+		return err
+	}
+	fmt.Printf("%s: %d words, %d nodes\n", *output, graph.WordsCount(), graph.NodesCount())
+	return nil
+}
+
+// Print the words of a DAWG close to a word
+func search(args []string) error {
+	flags := flag.NewFlagSet("search", flag.ExitOnError)
+	words := flags.Bool("words", false, "the file is a word list instead of a saved DAWG")
+	distance := flags.Int("d", 1, "maximum number of edits (substitutions, insertions, deletions, transpositions)")
+	maxResults := flags.Int("n", 20, "maximum number of words printed (0 for no limit)")
+	others := parse(flags, args)
+	if len(others) != 2 {
+		usage()
+	}
+
+	graph, err := load(others[0], *words)
+	if err != nil {
+		return err
+	}
+	matches, err := graph.SearchWithOptions(others[1], dawg.SearchOptions{Distance: *distance, MaxResults: *maxResults, AllowAdd: true, AllowDelete: true, Transpose: true})
+	if err != nil {
+		return err
+	}
+	for _, match := range matches {
+		fmt.Printf("%s\t%d\n", match.Word, match.Distance)
+	}
+	return nil
+}
+
+// Print the words of a DAWG, or its graph
+func export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ExitOnError)
+	words := flags.Bool("words", false, "the file is a word list instead of a saved DAWG")
+	dot := flags.Bool("dot", false, "print the graph in the Graphviz DOT format instead of the words")
+	mermaid := flags.Bool("mermaid", false, "print the graph as a Mermaid flowchart instead of the words")
+	graphJSON := flags.Bool("json", false, "print the graph as JSON nodes and edges instead of the words")
+	files := parse(flags, args)
+	if len(files) != 1 {
+		usage()
+	}
+
+	graph, err := load(files[0], *words)
+	if err != nil {
+		return err
+	}
+	output := bufio.NewWriter(os.Stdout)
+	switch {
+	case *dot:
+		err = graph.WriteDOT(output)
+	case *mermaid:
+		err = graph.WriteMermaid(output)
+	case *graphJSON:
+		err = graph.WriteGraphJSON(output)
+	default:
+		err = graph.WriteWords(output)
+	}
+	if err != nil {
+		return err
+	}
+	return output.Flush()
+}
+
+// Write a Go source file embedding a DAWG
+func generate(args []string) (err error) {
+	flags := flag.NewFlagSet("generate", flag.ExitOnError)
+	words := flags.Bool("words", false, "the file is a word list instead of a saved DAWG")
+	packageName := flags.String("package", "main", "package of the Go file")
+	name := flags.String("name", "Dictionary", "name of the function returning the DAWG")
+	output := flags.String("o", "", "Go file written")
+	files := parse(flags, args)
+	if len(files) != 1 || *output == "" {
+		usage()
+	}
+
+	graph, err := load(files[0], *words)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	return graph.WriteGo(file, *packageName, *name)
+}
+
+// Print the statistics of a DAWG
+func stats(args []string) error {
+	flags := flag.NewFlagSet("stats", flag.ExitOnError)
+	words := flags.Bool("words", false, "the file is a word list instead of a saved DAWG")
+	report := flags.Bool("report", false, "print the suffixes shared the most instead (see DAWG.Report)")
+	files := parse(flags, args)
+	if len(files) != 1 {
+		usage()
+	}
+
+	graph, err := load(files[0], *words)
+	if err != nil {
+		return err
+	}
+	if *report {
+		printReport(graph.Report())
+		return nil
+	}
+	stats := graph.Stats()
+	fmt.Printf("nodes:       %d\n", stats.Nodes)
+	fmt.Printf("transitions: %d\n", stats.Transitions)
+	fmt.Printf("words:       %d\n", stats.Words)
+	fmt.Printf("bytes:       %d\n", stats.Bytes)
+	if stats.TrieNodes != 0 {
+		fmt.Printf("trie nodes:  %d (%.1f%% removed)\n", stats.TrieNodes, 100*float64(stats.TrieNodes-stats.Nodes)/float64(stats.TrieNodes))
+		fmt.Printf("trie bytes:  %d (%d saved)\n", stats.TrieBytes, stats.BytesSaved())
+	}
+	fmt.Println("\ndepth\tstates\ttransitions")
+	for depth, level := range stats.Levels {
+		fmt.Printf("%d\t%d\t%d\n", depth, level.States, level.Transitions)
+	}
+	fmt.Println("\nlength\twords")
+	for length, count := range stats.WordLengths {
+		if count != 0 {
+			fmt.Printf("%d\t%d\n", length, count)
+		}
+	}
+	return nil
+}
+
+// Print the report on the sharing of the suffixes of a DAWG
+func printReport(report dawg.Report) {
+	fmt.Printf("trie nodes:     %d\n", report.TrieNodes)
+	fmt.Printf("nodes:          %d\n", report.Nodes)
+	fmt.Printf("edges:          %d\n", report.Edges)
+	fmt.Printf("words:          %d\n", report.Words)
+	fmt.Printf("sharing:        %.1f%%\n", 100*report.SharingRatio)
+	fmt.Printf("bytes per word: %.1f\n", report.BytesPerWord)
+	fmt.Println("\nstate\tweight\tprefixes\twords\tprefix\tsuffixes")
+	for _, suffix := range report.SharedSuffixes {
+		fmt.Printf("%d\t%d\t%d\t%d\t%q\t%q\n", suffix.State, suffix.Weight, suffix.Prefixes, suffix.Words, suffix.Prefix, suffix.Suffixes)
+	}
+}
+
+// Check that a DAWG is minimal
+func verify(args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ExitOnError)
+	words := flags.Bool("words", false, "the file is a word list instead of a saved DAWG")
+	files := parse(flags, args)
+	if len(files) != 1 {
+		usage()
+	}
+
+	graph, err := load(files[0], *words)
+	if err != nil {
+		return err
+	}
+	if err = graph.VerifyMinimal(); err != nil {
+		return err
+	}
+	fmt.Printf("%s: ok (%x)\n", files[0], graph.Fingerprint())
+	return nil
+}
+
+// Check that two DAWGs contain the same words
+func equal(args []string) error {
+	flags := flag.NewFlagSet("equal", flag.ExitOnError)
+	words := flags.Bool("words", false, "the files are word lists instead of saved DAWGs")
+	files := parse(flags, args)
+	if len(files) != 2 {
+		usage()
+	}
+
+	a, err := load(files[0], *words)
+	if err != nil {
+		return err
+	}
+	b, err := load(files[1], *words)
+	if err != nil {
+		return err
+	}
+	if !a.Equal(b) {
+		return fmt.Errorf("%s (%x) and %s (%x) are different", files[0], a.Fingerprint(), files[1], b.Fingerprint())
+	}
+	fmt.Printf("%s and %s are equal (%x)\n", files[0], files[1], a.Fingerprint())
+	return nil
+}
@@ -0,0 +1,50 @@
+package dawg
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Number of bytes per line of the literal written by WriteGo
+const goBytesPerLine = 32
+
+// Write to w a Go source file of the package embedding the DAWG in its binary format, with a function
+// returning it as a MappedDAWG, so a binary ships a ready-to-use dictionary without any data file.
+// The bytes are not decoded at startup, the function only wraps them on its first call.
+// With the dawg command, the file is generated by:
+//
+//	//go:generate dawg generate -words -package words -name Dictionary -o dictionary.go words.txt
+func (dawg *DAWG) WriteGo(w io.Writer, packageName string, name string) error {
+	var data bytes.Buffer
+	if _, err := dawg.WriteTo(&data); err != nil {
+		return err
+	}
+	// The variables are not exported
+	first, size := utf8.DecodeRuneInString(name)
+	variable := string(unicode.ToLower(first)) + name[size:]
+	writer := bufio.NewWriter(w)
+	fmt.Fprintf(writer, "// Code generated by dawg generate. DO NOT EDIT.\n\n")
+	fmt.Fprintf(writer, "package %s\n\n", packageName)
+	fmt.Fprintf(writer, "import (\n\t\"sync\"\n\n\t\"github.com/ftbe/dawg\"\n)\n\n")
+	fmt.Fprintf(writer, "// Get the DAWG of %d words embedded in the binary\n", dawg.WordsCount())
+	fmt.Fprintf(writer, "func %s() *dawg.MappedDAWG {\n\treturn %sOnce()\n}\n\n", name, variable)
+	fmt.Fprintf(writer, "var %sOnce = sync.OnceValue(func() *dawg.MappedDAWG {\n", variable)
+	fmt.Fprintf(writer, "\tmapped, err := dawg.NewMappedDAWG(%sData)\n\tif err != nil {\n\t\tpanic(err)\n\t}\n\treturn mapped\n})\n\n", variable)
+	fmt.Fprintf(writer, "// The DAWG in the binary format of Save\n")
+	fmt.Fprintf(writer, "var %sData = []byte(\"\" +", variable)
+	for i, b := range data.Bytes() {
+		if i%goBytesPerLine == 0 {
+			if i > 0 {
+				writer.WriteString("\" +")
+			}
+			writer.WriteString("\n\t\"")
+		}
+		fmt.Fprintf(writer, "\\x%02x", b)
+	}
+	writer.WriteString("\")\n")
+	return writer.Flush()
+}
@@ -0,0 +1,36 @@
+package dawg
+
+import (
+	"bytes"
+	"go/format"
+	"strings"
+	"testing"
+)
+
+func TestWriteGo(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tests", "nest", "note", "no"})
+
+	var source bytes.Buffer
+	if err := dawg.WriteGo(&source, "words", "Dictionary"); err != nil {
+		t.Fatal("WriteGo failed:", err)
+	}
+	// The source is valid and formatted
+	formatted, err := format.Source(source.Bytes())
+	if err != nil || !bytes.Equal(formatted, source.Bytes()) {
+		t.Fatal("WriteGo source not formatted:", err)
+	}
+	if !strings.Contains(source.String(), "package words\n") || !strings.Contains(source.String(), "func Dictionary() *dawg.MappedDAWG {") ||
+		!strings.Contains(source.String(), "var dictionaryData = []byte(") {
+		t.Error("WriteGo failed")
+	}
+
+	var data bytes.Buffer
+	dawg.WriteTo(&data)
+	mapped, err := NewMappedDAWG(data.Bytes())
+	if err != nil || !mapped.Contains("tests") || mapped.Contains("tes") {
+		t.Error("NewMappedDAWG failed")
+	}
+	if _, err := NewMappedDAWG(data.Bytes()[:20]); err == nil {
+		t.Error("NewMappedDAWG should fail")
+	}
+}
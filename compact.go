@@ -0,0 +1,250 @@
+package dawg
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"iter"
+	"os"
+)
+
+// CompactDAWG is an immutable DAWG stored in a few flat slices instead of a graph of structs:
+// about 8 bytes per transition and 12 bytes per state, without any pointer for the garbage collector to scan.
+// Its layout is the binary format of Save, so it is written and read without any conversion.
+// It supports the exact queries and the approximate searches of SearchWithOptions.
+type CompactDAWG struct {
+	firstEdges  []uint32 // The edges of the node i are the edges firstEdges[i] to firstEdges[i+1] (excluded)
+	finals      []uint64 // Bit set of the final nodes
+	chars       []rune   // Rune of each edge, the edges of a node being sorted by rune
+	targets     []uint32 // Node each edge leads to
+	wordsCounts []uint64 // Number of words under each node
+}
+
+// Create the compact version of the DAWG (see CompactDAWG). The node 0 is the initial state.
+func (dawg *DAWG) Compact() *CompactDAWG {
+	states, numbers := numberStates(dawg.initialState)
+	compact := &CompactDAWG{
+		firstEdges:  make([]uint32, 1, len(states)+1),
+		finals:      make([]uint64, (len(states)+63)/64),
+		wordsCounts: make([]uint64, len(states)),
+	}
+	for i, curState := range states {
+		for _, curLetter := range curState.letters {
+			compact.chars = append(compact.chars, curLetter.char)
+			compact.targets = append(compact.targets, numbers[curLetter.state])
+		}
+		compact.firstEdges = append(compact.firstEdges, uint32(len(compact.chars)))
+		if curState.final {
+			compact.finals[i/64] |= 1 << (i % 64)
+		}
+		compact.wordsCounts[i] = curState.wordsCount
+	}
+	return compact
+}
+
+// Load from a file a DAWG saved by Save, in its compact version
+func LoadCompactDAWG(fileName string) (*CompactDAWG, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadCompactDAWG(bufio.NewReader(file))
+}
+
+// Read from r a DAWG written by WriteTo (of a DAWG or of a CompactDAWG), in its compact version.
+// Only the bytes of the DAWG are read, so several DAWGs can be read from the same stream.
+// The errors are the same as for ReadDAWG.
+func ReadCompactDAWG(r io.Reader) (*CompactDAWG, error) {
+	header := make([]byte, binaryHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	nodesCount, edgesCount, err := parseBinaryHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	nodes, edges, err := readBinaryBody(r, header, nodesCount, edgesCount)
+	if err != nil {
+		return nil, err
+	}
+
+	compact := &CompactDAWG{
+		firstEdges:  make([]uint32, nodesCount+1),
+		finals:      make([]uint64, (nodesCount+63)/64),
+		chars:       make([]rune, edgesCount),
+		targets:     make([]uint32, edgesCount),
+		wordsCounts: make([]uint64, nodesCount),
+	}
+	for i := range nodesCount {
+		node := nodes[i*binaryNodeSize:]
+		firstEdge := binary.LittleEndian.Uint32(node[0:])
+		flags := binary.LittleEndian.Uint32(node[4:])
+		// The edges of the nodes must follow each other
+		if firstEdge != compact.firstEdges[i] || uint64(firstEdge)+uint64(flags>>1) > uint64(edgesCount) {
+			return nil, errors.New("Incorrect binary format : edge out of range.")
+		}
+		compact.firstEdges[i+1] = firstEdge + flags>>1
+		if flags&1 != 0 {
+			compact.finals[i/64] |= 1 << (i % 64)
+		}
+	}
+	if compact.firstEdges[nodesCount] != edgesCount {
+		return nil, errors.New("Incorrect binary format : edge out of range.")
+	}
+	for j := range edgesCount {
+		edge := edges[j*binaryEdgeSize:]
+		compact.chars[j] = rune(binary.LittleEndian.Uint32(edge[0:]))
+		compact.targets[j] = binary.LittleEndian.Uint32(edge[4:])
+		if compact.targets[j] >= nodesCount {
+			return nil, errors.New("Incorrect binary format : node out of range.")
+		}
+	}
+	for i := range nodesCount {
+		for j := compact.firstEdges[i] + 1; j < compact.firstEdges[i+1]; j++ {
+			if compact.chars[j] <= compact.chars[j-1] {
+				return nil, errors.New("Incorrect binary format : edges not sorted.")
+			}
+		}
+	}
+
+	if compact.hasCycle(0, make([]uint8, nodesCount)) {
+		return nil, errors.New("Incorrect binary format : cycle.")
+	}
+	compact.countWords(0, make([]bool, nodesCount))
+	return compact, nil
+}
+
+// Check if a path under the node leads back to a node of the path, the nodes being marked in visits
+// (1 while they are on the path, 2 once the nodes under them are checked)
+func (compact *CompactDAWG) hasCycle(node uint32, visits []uint8) bool {
+	switch visits[node] {
+	case 1:
+		return true
+	case 2:
+		return false
+	}
+	visits[node] = 1
+	for j := compact.firstEdges[node]; j < compact.firstEdges[node+1]; j++ {
+		if compact.hasCycle(compact.targets[j], visits) {
+			return true
+		}
+	}
+	visits[node] = 2
+	return false
+}
+
+// Count the words under the node, and under the nodes under it
+func (compact *CompactDAWG) countWords(node uint32, counted []bool) {
+	if counted[node] {
+		return
+	}
+	counted[node] = true
+	if compact.final(node) {
+		compact.wordsCounts[node] = 1
+	}
+	for j := compact.firstEdges[node]; j < compact.firstEdges[node+1]; j++ {
+		target := compact.targets[j]
+		compact.countWords(target, counted)
+		compact.wordsCounts[node] += compact.wordsCounts[target]
+	}
+}
+
+// Write the DAWG to w in the binary format used by Save, and return the number of bytes written
+func (compact *CompactDAWG) WriteTo(w io.Writer) (n int64, err error) {
+	nodesCount := len(compact.wordsCounts)
+	buffer := make([]byte, 0, binaryHeaderSize+binaryNodeSize*nodesCount+binaryEdgeSize*len(compact.chars)+binaryFooterSize)
+	buffer = appendBinaryHeader(buffer, uint32(nodesCount), uint32(len(compact.chars)))
+	for i := range uint32(nodesCount) {
+		flags := (compact.firstEdges[i+1] - compact.firstEdges[i]) << 1
+		if compact.final(i) {
+			flags |= 1
+		}
+		buffer = binary.LittleEndian.AppendUint32(buffer, compact.firstEdges[i])
+		buffer = binary.LittleEndian.AppendUint32(buffer, flags)
+	}
+	for j, char := range compact.chars {
+		buffer = binary.LittleEndian.AppendUint32(buffer, uint32(char))
+		buffer = binary.LittleEndian.AppendUint32(buffer, compact.targets[j])
+	}
+	buffer = binary.LittleEndian.AppendUint32(buffer, crc32.Checksum(buffer, checksumTable))
+	written, err := w.Write(buffer)
+	return int64(written), err
+}
+
+// Check if the node is final
+func (compact *CompactDAWG) final(node uint32) bool {
+	return compact.finals[node/64]&(1<<(node%64)) != 0
+}
+
+// Get the range of the edges of the node
+func (compact *CompactDAWG) edges(node uint32) (first uint32, last uint32) {
+	return compact.firstEdges[node], compact.firstEdges[node+1]
+}
+
+// Get the rune of the edge
+func (compact *CompactDAWG) char(edge uint32) rune {
+	return compact.chars[edge]
+}
+
+// Get the node the edge leads to
+func (compact *CompactDAWG) target(edge uint32) uint32 {
+	return compact.targets[edge]
+}
+
+// Get the number of words under the node
+func (compact *CompactDAWG) wordsCount(node uint32) uint64 {
+	return compact.wordsCounts[node]
+}
+
+// Check if the word is in the DAWG
+func (compact *CompactDAWG) Contains(word string) bool {
+	return flatContains(compact, word)
+}
+
+// Check if at least one word of the DAWG starts with the prefix
+func (compact *CompactDAWG) HasPrefix(prefix string) bool {
+	_, ok := flatPrefixNode(compact, prefix)
+	return ok
+}
+
+// Count the words of the DAWG starting with the prefix (the prefix itself included)
+func (compact *CompactDAWG) CountWithPrefix(prefix string) int {
+	return flatCountWithPrefix(compact, prefix)
+}
+
+// Get the number of words of the DAWG
+func (compact *CompactDAWG) WordsCount() uint64 {
+	return compact.wordsCounts[0]
+}
+
+// Get the words of the DAWG starting with the prefix, in lexicographic order.
+// At most max words are returned (all of them if max <= 0).
+func (compact *CompactDAWG) Completions(prefix string, max int) []string {
+	return flatCompletions(compact, prefix, max)
+}
+
+// Iterate over all the words of the DAWG, in lexicographic order
+func (compact *CompactDAWG) Words() iter.Seq[string] {
+	return flatWords(compact)
+}
+
+// Get the index of the word among the words of the DAWG sorted in lexicographic order (see DAWG.Index)
+func (compact *CompactDAWG) Index(word string) (index uint, ok bool) {
+	return flatIndex(compact, word)
+}
+
+// Get the word at the index among the words of the DAWG sorted in lexicographic order (the reverse of Index)
+func (compact *CompactDAWG) WordAt(index uint) (word string, ok bool) {
+	return flatWordAt(compact, index)
+}
+
+// Approximate string searching in the DAWG, with the edits of the options (Distance, AllowAdd, AllowDelete, Transpose,
+// ExactPrefix, IgnoreCase), the lengths of the words found (MinLength, MaxLength) and the order of the words of a
+// same distance (Compare). The other options are ignored.
+// The words are sorted by distance, then lexicographically, and the MaxResults first ones are returned.
+func (compact *CompactDAWG) SearchWithOptions(word string, options SearchOptions) []Match {
+	return flatSearchWithOptions(compact, word, options)
+}
@@ -0,0 +1,101 @@
+package dawg
+
+import (
+	"bytes"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCompact(t *testing.T) {
+	words := []string{"", "note", "notes", "test", "tested", "testing", "tests", "vote", "voted", "été"}
+	dawg := CreateDAWG(words)
+	compact := dawg.Compact()
+
+	for _, word := range words {
+		if !compact.Contains(word) {
+			t.Error("Contains failed")
+		}
+	}
+	if compact.Contains("tes") || compact.Contains("testss") || !compact.HasPrefix("tes") || compact.HasPrefix("x") {
+		t.Error("Contains failed")
+	}
+	if compact.WordsCount() != 10 || compact.CountWithPrefix("test") != 4 || compact.CountWithPrefix("x") != 0 {
+		t.Error("CountWithPrefix failed")
+	}
+	if !slices.Equal(compact.Completions("test", 3), []string{"test", "tested", "testing"}) || len(compact.Completions("x", 0)) != 0 {
+		t.Error("Completions failed")
+	}
+	if !slices.Equal(slices.Collect(compact.Words()), slices.Collect(dawg.Words())) {
+		t.Error("Words failed")
+	}
+	for i, word := range slices.Collect(dawg.Words()) {
+		if index, ok := compact.Index(word); !ok || index != uint(i) {
+			t.Error("Index failed")
+		}
+		if found, ok := compact.WordAt(uint(i)); !ok || found != word {
+			t.Error("WordAt failed")
+		}
+	}
+	if _, ok := compact.Index("tes"); ok {
+		t.Error("Index failed")
+	}
+	if _, ok := compact.WordAt(10); ok {
+		t.Error("WordAt failed")
+	}
+
+	for _, word := range []string{"tset", "vot", "ete", "NOTE", ""} {
+		for _, options := range []SearchOptions{
+			{Distance: 1},
+			{Distance: 2, AllowAdd: true, AllowDelete: true, MaxResults: 3},
+			{Distance: 2, AllowAdd: true, AllowDelete: true, Transpose: true, ExactPrefix: 1, MinLength: 4, MaxLength: 6},
+			{Distance: 0, IgnoreCase: true},
+		} {
+			expected, err := dawg.SearchWithOptions(word, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matches := compact.SearchWithOptions(word, options); !slices.Equal(matches, expected) {
+				t.Error("SearchWithOptions failed", word, options, matches, expected)
+			}
+		}
+	}
+	if matches := compact.SearchWithOptions("test", SearchOptions{Distance: -1}); len(matches) != 0 {
+		t.Error("SearchWithOptions of a negative distance failed")
+	}
+}
+
+func TestCompactBinary(t *testing.T) {
+	dawg := CreateDAWG([]string{"note", "notes", "test", "tests", "vote", "été"})
+	var fromDAWG, fromCompact bytes.Buffer
+	dawg.WriteTo(&fromDAWG)
+	n, err := dawg.Compact().WriteTo(&fromCompact)
+	if err != nil || n != int64(fromCompact.Len()) || !bytes.Equal(fromDAWG.Bytes(), fromCompact.Bytes()) {
+		t.Error("CompactDAWG.WriteTo failed")
+	}
+
+	fileName := filepath.Join(t.TempDir(), "words.dawg")
+	if err := dawg.Save(fileName); err != nil {
+		t.Fatal(err)
+	}
+	compact, err := LoadCompactDAWG(fileName)
+	if err != nil || !slices.Equal(slices.Collect(compact.Words()), slices.Collect(dawg.Words())) || compact.CountWithPrefix("not") != 2 {
+		t.Error("LoadCompactDAWG failed")
+	}
+
+	data := fromDAWG.Bytes()
+	if _, err := ReadCompactDAWG(bytes.NewReader(data[:len(data)-1])); err == nil {
+		t.Error("ReadCompactDAWG of truncated data failed")
+	}
+	corrupted := bytes.Clone(data)
+	corrupted[len(corrupted)-binaryFooterSize-1] = 0xff // Node of the last edge
+	if _, err := ReadCompactDAWG(bytes.NewReader(corrupted)); err != ErrChecksum {
+		t.Error("ReadCompactDAWG of corrupted data failed")
+	}
+	if _, err := ReadCompactDAWG(bytes.NewReader(cycleBinary())); err == nil {
+		t.Error("ReadCompactDAWG of a cycle failed")
+	}
+	if _, err := ReadCompactDAWG(bytes.NewReader(hugeBinary())); err == nil {
+		t.Error("ReadCompactDAWG of huge counts failed")
+	}
+}
@@ -0,0 +1,40 @@
+package dawg
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+)
+
+// Decompressor decompresses the word lists of a compression format, recognized by their first bytes.
+// gzip is supported out of the box, other formats can be plugged through BuildOptions.Decompressors,
+// zstd for example:
+//
+//	dawg.Decompressor{Magic: []byte{0x28, 0xb5, 0x2f, 0xfd}, NewReader: func(r io.Reader) (io.Reader, error) {
+//		return zstd.NewReader(r)
+//	}}
+type Decompressor struct {
+	Magic     []byte                               // First bytes of the compressed data
+	NewReader func(r io.Reader) (io.Reader, error) // Get a reader of the decompressed data
+}
+
+// Decompressor of the gzip format
+var gzipDecompressor = Decompressor{Magic: []byte{0x1f, 0x8b}, NewReader: func(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}}
+
+// Get a reader of the decompressed data if the data starts with the magic bytes of one of the decompressors
+// (or of gzip), the reader itself otherwise
+func decompress(reader *bufio.Reader, decompressors []Decompressor) (io.Reader, error) {
+	for _, decompressor := range append(decompressors[:len(decompressors):len(decompressors)], gzipDecompressor) {
+		if len(decompressor.Magic) == 0 {
+			continue
+		}
+		magic, _ := reader.Peek(len(decompressor.Magic)) // Shorter data can't match
+		if bytes.Equal(magic, decompressor.Magic) {
+			return decompressor.NewReader(reader)
+		}
+	}
+	return reader, nil
+}
@@ -0,0 +1,52 @@
+package dawg
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCreateDAWGFromCompressedFile(t *testing.T) {
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	writer.Write([]byte("test\ntests\nnote\n"))
+	writer.Close()
+	fileName := filepath.Join(t.TempDir(), "words.txt.gz")
+	if err := os.WriteFile(fileName, compressed.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dawg, err := CreateDAWGFromFile(fileName)
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"test", "tests", "note"})) {
+		t.Error("gzip decompression failed")
+	}
+
+	if _, err := CreateDAWGFromReader(bytes.NewReader(compressed.Bytes()[:10]), BuildOptions{}); err == nil {
+		t.Error("Truncated gzip data failed")
+	}
+}
+
+func TestDecompressors(t *testing.T) {
+	// A format where the words are preceded by "REV\n" and reversed
+	reversed := Decompressor{Magic: []byte("REV\n"), NewReader: func(r io.Reader) (io.Reader, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(reverse([]rune(string(data[4:])))), nil
+	}}
+
+	dawg, err := CreateDAWGFromReader(strings.NewReader("REV\nset\netov"), BuildOptions{Decompressors: []Decompressor{reversed}})
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"vote", "tes"})) {
+		t.Error("Decompressor failed")
+	}
+
+	dawg, err = CreateDAWGFromReader(strings.NewReader("a\nb\n"), BuildOptions{Decompressors: []Decompressor{reversed}})
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"a", "b"})) {
+		t.Error("Uncompressed data failed")
+	}
+}
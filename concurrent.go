@@ -0,0 +1,88 @@
+package dawg
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ConcurrentDAWG is a DAWG whose words can be changed while other goroutines search it.
+// The readers get an immutable snapshot of the DAWG without any lock, and each change publishes a new
+// snapshot atomically: the states of the changed word are copied instead of being changed (copy-on-write),
+// the other states being shared with the previous snapshot. So the readers never wait for the writers,
+// and the searches in progress on a previous snapshot are never disturbed.
+type ConcurrentDAWG struct {
+	snapshot atomic.Pointer[DAWG]
+	writer   sync.Mutex // Serializes the changes
+	// Index of the states of the current snapshot, only used by the writers: it is kept out of the snapshots,
+	// so the readers never see it change (nil until the first change)
+	index *mutableIndex
+}
+
+// Create a ConcurrentDAWG whose first snapshot is the DAWG, which must not be changed directly anymore
+func NewConcurrentDAWG(dawg *DAWG) *ConcurrentDAWG {
+	concurrent := &ConcurrentDAWG{index: dawg.mutable}
+	dawg.mutable = nil
+	concurrent.snapshot.Store(dawg)
+	return concurrent
+}
+
+// Get the current snapshot of the DAWG, to query it. The snapshot must not be changed (with Add or Remove).
+func (concurrent *ConcurrentDAWG) Snapshot() *DAWG {
+	return concurrent.snapshot.Load()
+}
+
+// Add a word, publishing a new snapshot
+func (concurrent *ConcurrentDAWG) Add(word string) {
+	concurrent.writer.Lock()
+	defer concurrent.writer.Unlock()
+	current := concurrent.snapshot.Load()
+	if current.Contains(word) {
+		return
+	}
+	next := concurrent.copyForWrite(current)
+	next.add(word, true)
+	concurrent.publish(next)
+}
+
+// Remove a word, publishing a new snapshot. Return false if the word wasn't in the DAWG.
+func (concurrent *ConcurrentDAWG) Remove(word string) bool {
+	concurrent.writer.Lock()
+	defer concurrent.writer.Unlock()
+	current := concurrent.snapshot.Load()
+	if !current.Contains(word) {
+		return false
+	}
+	next := concurrent.copyForWrite(current)
+	next.remove(word, true)
+	concurrent.publish(next)
+	return true
+}
+
+// Get a DAWG sharing the states of the current snapshot, except its initial state, to change it with copy-on-write.
+// The index of the states is lent to the copy until it is published.
+func (concurrent *ConcurrentDAWG) copyForWrite(current *DAWG) *DAWG {
+	if concurrent.index == nil {
+		concurrent.index = newMutableIndex(current.initialState)
+	}
+	index := concurrent.index
+	initialState := index.clone(current.initialState)
+	index.remove(current.initialState)
+	return &DAWG{
+		initialState:   initialState,
+		nodesCount:     current.nodesCount,
+		trieNodesCount: current.trieNodesCount,
+		maxWordSize:    current.maxWordSize,
+		mutable:        index,
+		frequencies:    slices.Clone(current.frequencies), // Changed in place by Add and Remove
+		maxFrequency:   current.maxFrequency,
+		flags:          slices.Clone(current.flags),
+		metrics:        current.metrics,
+	}
+}
+
+// Publish the changed copy as the new snapshot, taking its index back
+func (concurrent *ConcurrentDAWG) publish(next *DAWG) {
+	concurrent.index, next.mutable = next.mutable, nil
+	concurrent.snapshot.Store(next)
+}
@@ -0,0 +1,76 @@
+package dawg
+
+import (
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentDAWG(t *testing.T) {
+	words := []string{"test", "tests", "nest", "note", "no"}
+	concurrent := NewConcurrentDAWG(CreateDAWG(words))
+	first := concurrent.Snapshot()
+
+	concurrent.Add("testing")
+	concurrent.Add("nests")
+	if !concurrent.Remove("note") || concurrent.Remove("note") {
+		t.Error("Remove failed")
+	}
+	concurrent.Add("test")
+
+	// The first snapshot is unchanged
+	if !slices.Equal(slices.Collect(first.Words()), []string{"nest", "no", "note", "test", "tests"}) || first.WordsCount() != 5 {
+		t.Error("Snapshot changed:", slices.Collect(first.Words()))
+	}
+	last := concurrent.Snapshot()
+	expected := CreateDAWG([]string{"nest", "nests", "no", "test", "testing", "tests"})
+	if !slices.Equal(slices.Collect(last.Words()), slices.Collect(expected.Words())) || last.NodesCount() != expected.NodesCount() || last.WordsCount() != 6 {
+		t.Error("ConcurrentDAWG changes failed:", slices.Collect(last.Words()))
+	}
+	if err := last.VerifyMinimal(); err != nil {
+		t.Error("ConcurrentDAWG not minimal:", err)
+	}
+}
+
+func TestConcurrentDAWGReaders(t *testing.T) {
+	concurrent := NewConcurrentDAWG(CreateDAWG([]string{"word"}))
+	var readers sync.WaitGroup
+	done := make(chan struct{})
+	for range 4 {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				// A snapshot never changes during a search
+				snapshot := concurrent.Snapshot()
+				count := snapshot.WordsCount()
+				if found := len(snapshot.Completions("", 0)); uint64(found) != count {
+					t.Error("Snapshot changed during a search")
+					return
+				}
+				snapshot.SearchWithOptions("word1", SearchOptions{Distance: 1})
+				if err := snapshot.Verify(); err != nil {
+					t.Error("Snapshot incorrect during a change:", err)
+					return
+				}
+			}
+		}()
+	}
+	for i := range 200 {
+		concurrent.Add("word" + strconv.Itoa(i))
+		if i%3 == 0 {
+			concurrent.Remove("word" + strconv.Itoa(i/2))
+		}
+	}
+	close(done)
+	readers.Wait()
+	if err := concurrent.Snapshot().VerifyMinimal(); err != nil {
+		t.Error("ConcurrentDAWG not minimal:", err)
+	}
+}
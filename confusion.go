@@ -0,0 +1,83 @@
+package dawg
+
+// ConfusionSet holds graphemes that are commonly confused with each other (ph/f, c/k, ei/ie...).
+// During fuzzy searches, replacing one of them by the other costs the cost given when registering them,
+// usually lower than the cost of the equivalent letter by letter edits.
+type ConfusionSet struct {
+	confusions []confusion
+}
+
+// One direction of a registered confusion
+type confusion struct {
+	from []rune // Graphemes in the query
+	to   []rune // Graphemes in the word of the DAWG
+	cost float64
+}
+
+// Create a new empty confusion set
+func NewConfusionSet() *ConfusionSet {
+	return &ConfusionSet{}
+}
+
+// Register two graphemes that can be confused with each other, in both directions
+func (set *ConfusionSet) AddPair(a string, b string, cost float64) {
+	if a == b || a == "" || b == "" {
+		return
+	}
+	set.confusions = append(set.confusions, confusion{from: []rune(a), to: []rune(b), cost: cost}, confusion{from: []rune(b), to: []rune(a), cost: cost})
+}
+
+// Register a group of graphemes that can all be confused with each other
+func (set *ConfusionSet) AddGroup(cost float64, graphemes ...string) {
+	for i := range graphemes {
+		for j := i + 1; j < len(graphemes); j++ {
+			set.AddPair(graphemes[i], graphemes[j], cost)
+		}
+	}
+}
+
+// Update the row of word (whose rows of the previous depths are in rows) with the confusions ending
+// at the last letter of word
+func (set *ConfusionSet) updateRow(query []rune, word []rune, rows [][]float64, row []float64) {
+	for _, c := range set.confusions {
+		if !hasSuffix(word, c.to) {
+			continue
+		}
+		previousRow := rows[len(word)-len(c.to)]
+		for j := len(c.from); j <= len(query); j++ {
+			if cost := previousRow[j-len(c.from)] + c.cost; cost < row[j] && hasSuffix(query[:j], c.from) {
+				row[j] = cost
+			}
+		}
+	}
+}
+
+// Lowest cost that can still be reached by completing a confusion started at the end of word
+func (set *ConfusionSet) pendingCost(word []rune, rows [][]float64) (pending float64, ok bool) {
+	for _, c := range set.confusions {
+		for size := 1; size < len(c.to) && size <= len(word); size++ {
+			if hasSuffix(word, c.to[:size]) {
+				for _, cost := range rows[len(word)-size] {
+					if !ok || cost+c.cost < pending {
+						pending, ok = cost+c.cost, true
+					}
+				}
+			}
+		}
+	}
+	return
+}
+
+// Check if word ends with suffix
+func hasSuffix(word []rune, suffix []rune) bool {
+	if len(suffix) > len(word) {
+		return false
+	}
+	word = word[len(word)-len(suffix):]
+	for i := range suffix {
+		if word[i] != suffix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package dawg
+
+import (
+	"context"
+	"slices"
+)
+
+// Count the words of the DAWG within levenshteinDistance of word, without building them
+func (dawg *DAWG) CountWithin(word string, levenshteinDistance int) int {
+	return dawg.CountWithinPrefix(word, levenshteinDistance, 0)
+}
+
+// Count the words of the DAWG within levenshteinDistance of word and starting with the
+// prefixSize first letters of word, without building them (a negative prefixSize counts as 0)
+func (dawg *DAWG) CountWithinPrefix(word string, levenshteinDistance int, prefixSize int) (count int) {
+	query := []rune(word)
+	if prefixSize > len(query) {
+		return 0
+	}
+	prefixSize = max(prefixSize, 0)
+	curState := dawg.initialState
+	for _, char := range query[:prefixSize] {
+		curLetter := curState.getletter(char)
+		if curLetter == nil {
+			return 0
+		}
+		curState = curLetter.state
+	}
+	walkWeighted(curState, query[prefixSize:], float64(levenshteinDistance), unitCosts, func(_ []rune, _ float64) bool {
+		count++
+		return true
+	})
+	return
+}
+
+// Count the words of the DAWG found by SearchWithOptions with the options, without building nor sorting them:
+// the DAWG is walked once with the edit distances of the prefixes, each word being counted once, and in prefix
+// mode the words under a matching prefix are counted without being walked. If options.MaxResults > 0, the count
+// stops at MaxResults (enough to show "more than 100 words", and quicker). Compare, Parallel, Ranker and MaxDuration
+// are ignored.
+// With Graphemes, the words are found by the search on graphemes, and with separate budgets of edits
+// (MaxSubstitutions, MaxInsertions, MaxDeletions) by the approximate search.
+func (dawg *DAWG) CountMatches(word string, options SearchOptions) (count int) {
+	if options.Graphemes || options.hasEditBudgets() {
+		options.Stop, options.Ranker, options.Parallel, options.MaxDuration = nil, nil, false, 0
+		dawg.searchFunc(context.Background(), word, options, func(Match) bool {
+			count++
+			return true
+		})
+		return
+	}
+	word = options.normalize(word)
+	counter := &matchCounter{editRows: editRows{options: options, query: []rune(word)}, dawg: dawg}
+	firstRow := counter.firstRow()
+	counter.visit(dawg.initialState, firstRow[len(counter.query)])
+	return counter.count
+}
+
+// Check if the options have separate budgets for the kinds of edits
+func (options SearchOptions) hasEditBudgets() bool {
+	return options.MaxSubstitutions != 0 || options.MaxInsertions != 0 || options.MaxDeletions != 0
+}
+
+// A matchCounter counts the words of a DAWG close to a query, walking the DAWG depth-first with the edit distances
+// of the prefixes of the words
+type matchCounter struct {
+	editRows
+	dawg  *DAWG
+	count int
+}
+
+// Count the words under the state, the row of the letters leading to it being computed. In prefix mode,
+// best is the lowest distance between the query and the prefixes of the word. Return false once MaxResults is reached.
+func (counter *matchCounter) visit(curState *state, best int) bool {
+	options := counter.options
+	depth := len(counter.word)
+	row := counter.rows[depth]
+	distance := row[len(counter.query)]
+	if options.Prefix {
+		distance = best
+		if best <= options.Distance && options.MinLength == 0 && options.MaxLength == 0 && options.Filter == nil && !options.filtersFlags() {
+			return counter.add(int(curState.wordsCount)) // All the words under the state match
+		}
+	}
+	if curState.final && distance <= options.Distance && depth >= options.MinLength && counter.allowedFlags() && !counter.add(1) {
+		return false
+	}
+	if options.MaxLength > 0 && depth >= options.MaxLength || slices.Min(row) > options.Distance && distance > options.Distance {
+		return true
+	}
+	for _, curLetter := range curState.letters {
+		counter.word = append(counter.word[:depth], curLetter.char)
+		if options.Filter != nil && !options.Filter(string(counter.word)) {
+			continue
+		}
+		nextRow := counter.nextRow()
+		if !counter.visit(curLetter.state, min(best, nextRow[len(counter.query)])) {
+			return false
+		}
+	}
+	counter.word = counter.word[:depth]
+	return true
+}
+
+// Check if the flags of the word allow it to be counted
+func (counter *matchCounter) allowedFlags() bool {
+	options := counter.options
+	return !options.filtersFlags() || allowedFlags(counter.dawg.Flags(string(counter.word)), options.RequireFlags, options.ExcludeFlags)
+}
+
+// Add words to the count. Return false once MaxResults is reached.
+func (counter *matchCounter) add(words int) bool {
+	counter.count += words
+	if counter.options.MaxResults > 0 && counter.count >= counter.options.MaxResults {
+		counter.count = counter.options.MaxResults
+		return false
+	}
+	return true
+}
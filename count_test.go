@@ -0,0 +1,56 @@
+package dawg
+
+import "testing"
+
+func TestCountWithin(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note"})
+
+	if count := dawg.CountWithin("test", 0); count != 1 {
+		t.Error("CountWithin failed")
+	}
+	if count := dawg.CountWithin("test", 1); count != 5 {
+		t.Error("CountWithin failed")
+	}
+	if count := dawg.CountWithinPrefix("test", 1, 1); count != 4 {
+		t.Error("CountWithinPrefix failed")
+	}
+	if count := dawg.CountWithinPrefix("test", 1, 5); count != 0 {
+		t.Error("CountWithinPrefix failed")
+	}
+	if count := dawg.CountWithinPrefix("test", 1, -1); count != 5 {
+		t.Error("CountWithinPrefix with a negative prefix size failed", count)
+	}
+}
+
+func TestCountMatches(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "tset", "Test", "testing", "t", "est", "été"})
+
+	for _, query := range []string{"test", "tes", "tset", "TEST", "ete", "", "x"} {
+		for distance := range 3 {
+			for _, options := range []SearchOptions{
+				{},
+				{AllowAdd: true},
+				{AllowDelete: true},
+				{AllowAdd: true, AllowDelete: true, Transpose: true},
+				{AllowAdd: true, AllowDelete: true, ExactPrefix: 1},
+				{AllowAdd: true, AllowDelete: true, Transpose: true, ExactPrefix: 2},
+				{AllowAdd: true, MinLength: 4, MaxLength: 5},
+				{Prefix: true},
+				{Prefix: true, AllowDelete: true, MaxLength: 4},
+				{Prefix: true, AllowAdd: true, Filter: func(prefix string) bool { return prefix[0] != 'n' }},
+				{AllowDelete: true, IgnoreCase: true},
+				{AllowAdd: true, Graphemes: true},
+			} {
+				options.Distance = distance
+				matches, _ := dawg.SearchWithOptions(query, options)
+				if count := dawg.CountMatches(query, options); count != len(matches) {
+					t.Error("CountMatches failed", query, options, count, len(matches))
+				}
+				options.MaxResults = 2
+				if count := dawg.CountMatches(query, options); count != min(len(matches), 2) {
+					t.Error("CountMatches with MaxResults failed", query, options, count)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package dawg
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// ErrMissingColumn is returned (wrapped in a LineError) when a line of a CSV file doesn't have the configured columns
+var ErrMissingColumn = errors.New("Missing column.")
+
+// CSVOptions configures how words are read from a CSV or TSV file.
+// The columns are counted from 1, 0 meaning the column isn't in the file.
+type CSVOptions struct {
+	BuildOptions         // MaxLineSize is not used
+	Comma           rune // Field separator (',' if 0, '\t' for a TSV file)
+	Header          bool // If set, the first line holds the names of the columns and is skipped
+	WordColumn      int  // Column of the words (the first one if 0)
+	FrequencyColumn int  // Column of the frequencies of the words (see CreateDAWGWithFrequencies)
+	TagColumn       int  // Column of the tags of the words (see CreateMapFromCSV)
+	// With MergeDuplicates, called for each line of a word found before, with the frequency and the tag kept
+	// for the word so far and those of the line, to get the ones kept (nil for SumDuplicates)
+	Merge func(word string, kept CSVEntry, line CSVEntry) CSVEntry
+}
+
+// CSVEntry is the frequency and the tag of a word read from a CSV file (0 and "" without their column)
+type CSVEntry struct {
+	Frequency uint64
+	Tag       string
+}
+
+// Create a new DAWG by reading the words from a CSV or TSV file, with their frequencies if options.FrequencyColumn is set.
+// The frequencies of the lines of the same word are added up, unless options.Duplicates says otherwise.
+func CreateDAWGFromCSV(r io.Reader, options CSVOptions) (*DAWG, error) {
+	entries, err := readCSVEntries(r, options)
+	if err != nil {
+		return nil, err
+	}
+	if options.FrequencyColumn > 0 {
+		frequencies := make(map[string]uint64, len(entries))
+		for word, entry := range entries {
+			frequencies[word] = entry.Frequency
+		}
+		return CreateDAWGWithFrequencies(frequencies), nil
+	}
+	words := make([]string, 0, len(entries))
+	for word := range entries {
+		words = append(words, word)
+	}
+	return CreateDAWG(words), nil
+}
+
+// Create a new Map from the words of a CSV or TSV file to their tags (the column options.TagColumn).
+// If a word is on several lines, its last tag is kept, unless options.Duplicates says otherwise.
+func CreateMapFromCSV(r io.Reader, options CSVOptions) (*Map[string], error) {
+	entries, err := readCSVEntries(r, options)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]string, len(entries))
+	for word, entry := range entries {
+		tags[word] = entry.Tag
+	}
+	return CreateMap(tags), nil
+}
+
+// Read the entries of the words of a CSV file, the lines of the same word being handled by options.Duplicates
+func readCSVEntries(r io.Reader, options CSVOptions) (map[string]CSVEntry, error) {
+	entries := make(map[string]CSVEntry)
+	err := readCSV(r, options, func(word string, entry CSVEntry, line int) error {
+		kept, found := entries[word]
+		switch {
+		case !found:
+			entries[word] = entry
+		case options.Duplicates == IgnoreDuplicates:
+		case options.Duplicates == RejectDuplicates:
+			return &LineError{Line: line, Err: ErrDuplicate}
+		case options.Duplicates == MergeDuplicates && options.Merge != nil:
+			entries[word] = options.Merge(word, kept, entry)
+		default:
+			entries[word] = CSVEntry{Frequency: kept.Frequency + entry.Frequency, Tag: entry.Tag}
+		}
+		return nil
+	})
+	return entries, err
+}
+
+// Call fn for each line of a CSV file, with its word, its entry and its number, until fn returns an error
+func readCSV(r io.Reader, options CSVOptions, fn func(word string, entry CSVEntry, line int) error) error {
+	decompressed, err := decompress(bufio.NewReader(r), options.Decompressors)
+	if err != nil {
+		return err
+	}
+	reader := csv.NewReader(decompressed)
+	if options.Comma != 0 {
+		reader.Comma = options.Comma
+	}
+	if options.SkipComments {
+		reader.Comment = '#'
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+	wordColumn := max(options.WordColumn, 1)
+	columns := max(wordColumn, options.FrequencyColumn, options.TagColumn)
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if first && options.Header {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		if len(record) < columns {
+			return &LineError{Line: line, Err: ErrMissingColumn}
+		}
+		word, skip, err := options.prepare(record[wordColumn-1], line)
+		if err != nil {
+			return err
+		} else if skip {
+			continue
+		}
+		if options.MaxWordLength > 0 && utf8.RuneCountInString(word) > options.MaxWordLength {
+			return &LineError{Line: line, Err: ErrWordTooLong}
+		}
+		var frequency uint64
+		if options.FrequencyColumn > 0 {
+			if frequency, err = strconv.ParseUint(record[options.FrequencyColumn-1], 10, 64); err != nil {
+				return &LineError{Line: line, Err: err}
+			}
+		}
+		tag := ""
+		if options.TagColumn > 0 {
+			tag = record[options.TagColumn-1]
+		}
+		if err = fn(word, CSVEntry{Frequency: frequency, Tag: tag}, line); err != nil {
+			return err
+		}
+	}
+}
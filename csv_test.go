@@ -0,0 +1,77 @@
+package dawg
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+const frequenciesTSV = "word\tcount\tpos\nthe\t50000\tdet\nhate\t40\tverb\nhe\t3000\tpron\nhate\t2\tnoun\n"
+
+func TestCreateDAWGFromCSV(t *testing.T) {
+	options := CSVOptions{Comma: '\t', Header: true, FrequencyColumn: 2}
+	dawg, err := CreateDAWGFromCSV(strings.NewReader(frequenciesTSV), options)
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"the", "hate", "he"})) || dawg.Frequency("the") != 50000 || dawg.Frequency("hate") != 42 {
+		t.Error("CreateDAWGFromCSV failed")
+	}
+
+	dawg, err = CreateDAWGFromCSV(strings.NewReader("the,det\n\"he, him\",pron\n"), CSVOptions{})
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"the", "he, him"})) || dawg.frequencies != nil {
+		t.Error("CreateDAWGFromCSV without frequencies failed")
+	}
+
+	var lineErr *LineError
+	_, err = CreateDAWGFromCSV(strings.NewReader("the,1\nhe,lots\n"), CSVOptions{FrequencyColumn: 2})
+	var numErr *strconv.NumError
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 || !errors.As(err, &numErr) {
+		t.Error("Invalid frequency failed")
+	}
+	_, err = CreateDAWGFromCSV(strings.NewReader("the,1\nhe\n"), CSVOptions{FrequencyColumn: 2})
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 || !errors.Is(err, ErrMissingColumn) {
+		t.Error("Missing column failed")
+	}
+}
+
+func TestCreateMapFromCSV(t *testing.T) {
+	tags, err := CreateMapFromCSV(strings.NewReader(frequenciesTSV), CSVOptions{Comma: '\t', Header: true, TagColumn: 3})
+	if err != nil || tags.Len() != 3 {
+		t.Fatal("CreateMapFromCSV failed")
+	}
+	if tag, ok := tags.Get("hate"); !ok || tag != "noun" {
+		t.Error("CreateMapFromCSV failed")
+	}
+}
+
+func TestCSVDuplicates(t *testing.T) {
+	options := CSVOptions{Comma: '\t', Header: true, FrequencyColumn: 2, TagColumn: 3}
+	entry := func(duplicates DuplicatePolicy) (uint64, string) {
+		options.Duplicates = duplicates
+		dawg, err := CreateDAWGFromCSV(strings.NewReader(frequenciesTSV), options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tags, _ := CreateMapFromCSV(strings.NewReader(frequenciesTSV), options)
+		tag, _ := tags.Get("hate")
+		return dawg.Frequency("hate"), tag
+	}
+	if frequency, tag := entry(SumDuplicates); frequency != 42 || tag != "noun" {
+		t.Error("SumDuplicates failed", frequency, tag)
+	}
+	if frequency, tag := entry(IgnoreDuplicates); frequency != 40 || tag != "verb" {
+		t.Error("IgnoreDuplicates failed", frequency, tag)
+	}
+	options.Merge = func(word string, kept CSVEntry, line CSVEntry) CSVEntry {
+		return CSVEntry{Frequency: max(kept.Frequency, line.Frequency), Tag: kept.Tag + "," + line.Tag}
+	}
+	if frequency, tag := entry(MergeDuplicates); frequency != 40 || tag != "verb,noun" {
+		t.Error("MergeDuplicates failed", frequency, tag)
+	}
+
+	options.Duplicates = RejectDuplicates
+	_, err := CreateDAWGFromCSV(strings.NewReader(frequenciesTSV), options)
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 5 || !errors.Is(err, ErrDuplicate) {
+		t.Error("RejectDuplicates failed", err)
+	}
+}
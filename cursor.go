@@ -0,0 +1,42 @@
+package dawg
+
+// Cursor is a read-only position in a DAWG, to walk it letter by letter (to solve word games for example).
+// A Cursor is a small value: advancing returns a new Cursor and leaves the original one unchanged,
+// so backtracking is free. The zero Cursor is exhausted: it isn't final and no letter follows it.
+type Cursor struct {
+	state *state
+}
+
+// Get a cursor on the initial state of the DAWG (the empty prefix)
+func (dawg *DAWG) Root() Cursor {
+	return Cursor{state: dawg.initialState}
+}
+
+// Follow the letter from the cursor. ok is false if no word continues with this letter.
+func (cursor Cursor) Advance(char rune) (next Cursor, ok bool) {
+	if cursor.state == nil {
+		return cursor, false
+	}
+	curLetter := cursor.state.getletter(char)
+	if curLetter == nil {
+		return cursor, false
+	}
+	return Cursor{state: curLetter.state}, true
+}
+
+// Check if the letters followed to reach the cursor form a word
+func (cursor Cursor) IsFinal() bool {
+	return cursor.state != nil && cursor.state.final
+}
+
+// Get the letters which can follow the cursor, sorted
+func (cursor Cursor) Edges() []rune {
+	if cursor.state == nil {
+		return []rune{}
+	}
+	edges := make([]rune, 0, len(cursor.state.letters))
+	for _, curLetter := range cursor.state.letters {
+		edges = append(edges, curLetter.char)
+	}
+	return edges
+}
@@ -0,0 +1,34 @@
+package dawg
+
+import "testing"
+
+func TestCursor(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tea", "ten", "no"})
+
+	root := dawg.Root()
+	if edges := root.Edges(); len(edges) != 2 || edges[0] != 'n' || edges[1] != 't' || root.IsFinal() {
+		t.Error("Root failed")
+	}
+
+	te, ok := root.Advance('t')
+	if !ok {
+		t.Fatal("Advance failed")
+	}
+	if te, ok = te.Advance('e'); !ok {
+		t.Fatal("Advance failed")
+	}
+	if edges := te.Edges(); len(edges) != 3 || edges[0] != 'a' || edges[1] != 'n' || edges[2] != 's' || te.IsFinal() {
+		t.Error("Edges failed")
+	}
+	if ten, ok := te.Advance('n'); !ok || !ten.IsFinal() || len(ten.Edges()) != 0 {
+		t.Error("IsFinal failed")
+	}
+	if _, ok := te.Advance('x'); ok {
+		t.Error("Advance should fail")
+	}
+
+	var zero Cursor
+	if _, ok := zero.Advance('t'); ok || zero.IsFinal() || len(zero.Edges()) != 0 {
+		t.Error("Zero cursor failed")
+	}
+}
@@ -3,178 +3,154 @@ package dawg
 
 import (
 	"bufio"
-	"bytes"
+	"context"
+	"encoding/binary"
 	"errors"
-	"math/rand"
 	"os"
 	"strconv"
 	"strings"
-	"time"
+	"sync"
 )
 
-// DAWG is used to store the representation of the Directly Acyclic Word Graph
+// DAWG is used to store the representation of the Directly Acyclic Word Graph.
+// The queries can be run by several goroutines at the same time, as long as the words are not changed
+// (to change them while searching, see ConcurrentDAWG).
 type DAWG struct {
-	initialState *state
-	nodesCount   uint64
+	initialState   *state
+	nodesCount     uint64
+	trieNodesCount uint64 // Number of nodes before the compression of the trie (0 if unknown)
+	maxWordSize    int    // Length of the longest word
+
+	phoneticIndexOnce sync.Once
+	phoneticIndex     *PhoneticIndex // Metaphone index of the words, built on first use
+
+	mutable *mutableIndex // Index of the states, built on the first change of the words
+
+	frequencies  []uint64 // Frequency of each word, at the index of the word (nil if the DAWG has no frequencies)
+	maxFrequency uint64
+	flags        []uint32 // Flags of each word, at the index of the word (nil if the DAWG has no flags)
+
+	frequencyMaximaOnce sync.Once
+	frequencyMaxima     []uint64 // Maximum frequencies of the ranges of words (see TopCompletions), built on first use
+
+	metrics Metrics // Observes the searches (nil if none, see SetMetrics)
 }
 
 type letter struct {
 	char  rune // Yay ! Unicode !
 	state *state
-
-	// Tree, allow for O(log(n)) search operations
-	left  *letter
-	right *letter
-
-	// Linked list, allow for a quick iteration on all the sub-letters of a state
-	next *letter
 }
 
 type state struct {
 	final bool
 
-	letters      *letter // Root of the letter tree and the letter linked list
-	lettersCount int     // Number of letters in the tree/linked list
+	letters    []*letter // Letters of the state, sorted by rune (for O(log(n)) search operations)
+	wordsCount uint64    // Number of words under this state (itself included if it is final)
 
 	next   *state  // Linked list of all the state on the same level (used to merge duplicate nodes)
 	letter *letter // The letter this state comes from (used to merge duplicate nodes)
-	number uint64  // The number of this state (used to save the DAWG to a file)
 }
 
-// Linked list of words
-type word struct {
-	content  string
-	nextWord *word
-}
-
-// Check if two states are equals.
-// Two states are equals :
-//   - if they are on the same level
-//   - if they are both final/non-final
-//   - if they have the same sub-letters going to the same states.
-func (state *state) equals(otherState *state) (equals bool) {
-	if state.final != otherState.final || state.lettersCount != otherState.lettersCount {
-		return false
-	}
-
-	for curLetter := state.letters; curLetter != nil; curLetter = curLetter.next {
-		if !otherState.containsLetter(curLetter) {
-			return false
-		}
+// Append to key the signature of the state: its finality, and its sub-letters with the ids of the states they go to.
+// Two states whose sub-states are numbered in ids are equals if they have the same signature.
+func (state *state) signature(key []byte, ids map[*state]uint64) []byte {
+	if state.final {
+		key = append(key, 1)
+	} else {
+		key = append(key, 0)
 	}
-
-	return true
-}
-
-// Check if this state contains this letter (in O(log(n)) time)
-func (state *state) containsLetter(letter *letter) (containsLetter bool) {
-	curLetter := state.letters
-	for curLetter != nil && curLetter.char != letter.char {
-		if curLetter.char < letter.char {
-			curLetter = curLetter.left
-		} else {
-			curLetter = curLetter.right
-		}
+	for _, curLetter := range state.letters {
+		key = binary.LittleEndian.AppendUint32(key, uint32(curLetter.char))
+		key = binary.LittleEndian.AppendUint64(key, ids[curLetter.state])
 	}
-	return curLetter != nil && curLetter.state == letter.state
+	return key
 }
 
-// Get a letter from the state (in O(log(n)) time)
+// Get a letter from the state (in O(log(n)) time, with a binary search)
 func (state *state) getletter(letter rune) *letter {
-	curLetter := state.letters
-	for curLetter != nil && curLetter.char != letter {
-		if curLetter.char < letter {
-			curLetter = curLetter.left
+	low, high := 0, len(state.letters)
+	for low < high {
+		middle := int(uint(low+high) >> 1)
+		if state.letters[middle].char < letter {
+			low = middle + 1
 		} else {
-			curLetter = curLetter.right
+			high = middle
 		}
 	}
-	return curLetter
+	if low < len(state.letters) && state.letters[low].char == letter {
+		return state.letters[low]
+	}
+	return nil
 }
 
 // Create a new DAWG by loading the words from a file.
 // The file must be UTF-8 encoded, one word per line.
 func CreateDAWGFromFile(fileName string) (dawg *DAWG, err error) {
-	file, err := os.Open(fileName)
-	if err != nil {
-		return
-	}
-	defer file.Close()
-
-	reader := bufio.NewReader(file)
-	scanner := bufio.NewScanner(reader)
-
-	initialState := &state{final: false}
-	var nbNodes uint64 = 1
-	maxWordSize := 0
-	for scanner.Scan() {
-		_, size, createdNodes := addWord(initialState, scanner.Text())
-		if size > maxWordSize {
-			maxWordSize = size
-		}
-		nbNodes += createdNodes
-	}
-	if err = scanner.Err(); err != nil {
-		return
-	}
-	nbNodes -= compressTrie(initialState, maxWordSize)
-	return &DAWG{initialState: initialState, nodesCount: nbNodes}, nil
+	return CreateDAWGFromFileWithOptions(fileName, BuildOptions{})
 }
 
 // Create a new DAWG by loading the words from an array.
 func CreateDAWG(words []string) *DAWG {
-	initialState := &state{final: false}
-	var nbNodes uint64 = 1
-	maxWordSize := 0
-	for _, word := range words {
-		_, size, createdNodes := addWord(initialState, word)
-		if size > maxWordSize {
-			maxWordSize = size
-		}
-		nbNodes += createdNodes
-	}
-	nbNodes -= compressTrie(initialState, maxWordSize)
-	return &DAWG{initialState: initialState, nodesCount: nbNodes}
+	dawg, _ := CreateDAWGWithOptions(words, BuildOptions{}) // Can't fail without options
+	return dawg
 }
 
-func compressTrie(initialState *state, maxWordSize int) (deletedNodes uint64) {
+// Merge the equal states of the trie, aborted with the error of the context as soon as it is done
+func compressTrie(ctx context.Context, initialState *state, maxWordSize int) (deletedNodes uint64, err error) {
 	// First, analyse the trie recursively to create a linked list of all the state on the same level
 	levels := make([]*state, maxWordSize)
-	if initialState.lettersCount != 0 {
+	if len(initialState.letters) != 0 {
 		channels := make([]chan int, maxWordSize) // To synchronize the access to levels
-		done := make(chan int, initialState.lettersCount)
 		for i := 0; i < maxWordSize; i++ {
 			channels[i] = make(chan int, 1)
-			i := i
-			go func() {
-				channels[i] <- 1
-			}()
+			channels[i] <- 1
 		}
-		for curLetter := initialState.letters; curLetter != nil; curLetter = curLetter.next {
-			// Parallelize the treatment
-			go func(curState *state) {
-				analyseSubTrie(curState, levels, channels)
-				done <- 1
-			}(curLetter.state)
-		}
-		// Wait for the end of all goroutines
-		for i := 0; i < initialState.lettersCount; i++ {
-			<-done
+		if parallelBuild {
+			done := make(chan int, len(initialState.letters))
+			for _, curLetter := range initialState.letters {
+				// Parallelize the treatment
+				go func(curState *state) {
+					analyseSubTrie(curState, levels, channels)
+					done <- 1
+				}(curLetter.state)
+			}
+			// Wait for the end of all goroutines
+			for i := 0; i < len(initialState.letters); i++ {
+				<-done
+			}
+		} else {
+			for _, curLetter := range initialState.letters {
+				analyseSubTrie(curLetter.state, levels, channels)
+			}
 		}
 	}
 
-	// For each level, merge the duplicates states
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	// For each level, merge the duplicates states.
+	// The register maps the signature of each state kept to this state: the states of the lower levels
+	// are already merged, so two states of a level are equal if they have the same signature.
+	register := make(map[string]*state)
+	ids := make(map[*state]uint64)
+	var key []byte
+	steps := 0
 	for i := 0; i < maxWordSize; i++ {
-		for curState := levels[i]; curState != nil && curState.next != nil; curState = curState.next {
-			for previousState, sameState := curState, curState.next; sameState != nil; sameState = sameState.next {
-				if curState.equals(sameState) {
-					previousState.next = sameState.next
-					sameState.letter.state = curState
-					deletedNodes++
-				} else {
-					previousState = sameState
+		for curState := levels[i]; curState != nil; curState = curState.next {
+			if steps++; steps%contextCheckInterval == 0 {
+				if err = ctx.Err(); err != nil {
+					return
 				}
 			}
+			key = curState.signature(key[:0], ids)
+			if sameState, ok := register[string(key)]; ok {
+				curState.letter.state = sameState
+				deletedNodes++
+			} else {
+				register[string(key)] = curState
+				ids[curState] = uint64(len(ids))
+			}
 		}
 	}
 	return
@@ -182,8 +158,8 @@ func compressTrie(initialState *state, maxWordSize int) (deletedNodes uint64) {
 
 func analyseSubTrie(curState *state, levels []*state, channels []chan int) (subLevels int) {
 	var curLevel int = 0
-	if curState.lettersCount != 0 {
-		for curLetter := curState.letters; curLetter != nil; curLetter = curLetter.next {
+	if len(curState.letters) != 0 {
+		for _, curLetter := range curState.letters {
 			curSubLevels := analyseSubTrie(curLetter.state, levels, channels)
 			if curSubLevels > curLevel {
 				curLevel = curSubLevels
@@ -199,40 +175,19 @@ func analyseSubTrie(curState *state, levels []*state, channels []chan int) (subL
 	return curLevel + 1
 }
 
-// Add a new word to the Trie
-func addWord(initialState *state, word string) (newEndState bool, wordSize int, createdNodes uint64) {
+// Add a new word to the Trie, allocating its states and letters from the arena
+func addWord(arena *arena, initialState *state, word string) (newEndState bool, wordSize int, createdNodes uint64) {
 	curState := initialState
 	for _, l := range word {
-		var curLetter *letter
-		if curState.letters == nil {
-			curLetter = &letter{char: l}
-			curState.letters = curLetter
-		} else {
-			for curLetter = curState.letters; curLetter.char != l; {
-				if curLetter.char < l {
-					if curLetter.left == nil {
-						curLetter.left = &letter{char: l}
-					}
-					curLetter = curLetter.left
-				} else {
-					if curLetter.right == nil {
-						curLetter.right = &letter{char: l}
-					}
-					curLetter = curLetter.right
-				}
-			}
-		}
-		if curLetter.state == nil {
-			curLetter.state = &state{final: false, letter: curLetter}
+		curLetter := curState.getletter(l)
+		if curLetter == nil {
+			curLetter = arena.newLetter(l, arena.newState())
+			curLetter.state.letter = curLetter
 			createdNodes++
-			curState.lettersCount++
-			if curState.final == false && curState.lettersCount == 1 || curState.lettersCount > 1 {
+			arena.addLetter(curState, curLetter)
+			if curState.final == false && len(curState.letters) == 1 || len(curState.letters) > 1 {
 				newEndState = true
 			}
-			if curLetter != curState.letters {
-				curLetter.next = curState.letters.next
-				curState.letters.next = curLetter
-			}
 		}
 		curState = curLetter.state
 		wordSize++ // We can't use len() on UTF-8 strings
@@ -241,38 +196,39 @@ func addWord(initialState *state, word string) (newEndState bool, wordSize int,
 	return
 }
 
+// Get the state reached by the prefix, or nil if no word starts with it
+func (dawg *DAWG) prefixState(prefix string) *state {
+	return dawg.initialState.follow(prefix)
+}
+
+// Get the state reached from this state by following the letters, or nil if there is no such path
+func (curState *state) follow(letters string) *state {
+	for _, l := range letters {
+		curLetter := curState.getletter(l)
+		if curLetter == nil {
+			return nil
+		}
+		curState = curLetter.state
+	}
+	return curState
+}
+
+// Check if the word is in the DAWG (without any allocation)
+func (dawg *DAWG) Contains(word string) bool {
+	curState := dawg.prefixState(word)
+	return curState != nil && curState.final
+}
+
 // Approximate string searching in the DAWG.
 // levenshteinDistance is the maximum Levenshtein distance allowed beetween word and the words found in the DAWG.
-// maxResults allow to limit the number of returned results (to reduce the time needed by the search)
+// maxResults allow to limit the number of returned results (to reduce the time needed by the search), all of them being returned if maxResults <= 0
 // allowAdd and allowDelete specify if the returned words can have insertions/deletions of letters
+// The words are sorted by distance, then lexicographically, and the maxResults first ones are returned.
+//
+// Deprecated: use SearchWithOptions.
 func (dawg *DAWG) Search(word string, levenshteinDistance int, maxResults int, allowAdd bool, allowDelete bool) (words []string, err error) {
-	wordsFound, _, wordsSize, err := searchSubString(dawg.initialState, *bytes.NewBufferString(""), *bytes.NewBufferString(word), levenshteinDistance, maxResults, allowAdd, allowDelete, 0)
-	if err != nil {
-		return
-	}
-	// Truncate if we have found more words than we need
-	for ; wordsSize > maxResults; wordsSize-- {
-		wordsFound = wordsFound.nextWord
-	}
-	// Transform to an array of strings
-	words = make([]string, wordsSize)
-	for ; wordsSize > 0; wordsSize-- {
-		words[wordsSize-1] = wordsFound.content
-		wordsFound = wordsFound.nextWord
-	}
-	return
-}
-
-func mergeWords(words1 *word, lastWord1 *word, wordsSize1 int, words2 *word, lastWord2 *word, wordsSize2 int) (words *word, lastWord *word, wordsSize int) {
-	if words1 == nil {
-		return words2, lastWord2, wordsSize2
-	}
-	lastWord1.nextWord = words2
-	if lastWord2 == nil {
-		return words1, lastWord1, wordsSize1 + wordsSize2
-	} else {
-		return words1, lastWord2, wordsSize1 + wordsSize2
-	}
+	matches, err := dawg.SearchWithOptions(word, SearchOptions{Distance: levenshteinDistance, MaxResults: maxResults, AllowAdd: allowAdd, AllowDelete: allowDelete})
+	return matchedWords(matches), err
 }
 
 // Load from a file a DAWG saved by SaveToFile
@@ -337,28 +293,8 @@ func LoadDAWGFromFile(fileName string) (dawg *DAWG, err error) {
 					return
 				}
 
-				states[nodeNumber].lettersCount = (i + 1) / 2
-
-				if states[nodeNumber].letters == nil {
-					states[nodeNumber].letters = &letter{char: char, state: states[linkedNodeNumber]}
-				} else {
-					for curLetter := states[nodeNumber].letters; curLetter.char != char; {
-						if curLetter.char < char {
-							if curLetter.left == nil {
-								curLetter.left = &letter{char: char, state: states[linkedNodeNumber]}
-								curLetter.left.next = states[nodeNumber].letters.next
-								states[nodeNumber].letters.next = curLetter.left
-							}
-							curLetter = curLetter.left
-						} else {
-							if curLetter.right == nil {
-								curLetter.right = &letter{char: char, state: states[linkedNodeNumber]}
-								curLetter.right.next = states[nodeNumber].letters.next
-								states[nodeNumber].letters.next = curLetter.right
-							}
-							curLetter = curLetter.right
-						}
-					}
+				if states[nodeNumber].getletter(char) == nil {
+					states[nodeNumber].addLetter(&letter{char: char, state: states[linkedNodeNumber]})
 				}
 			}
 		}
@@ -366,7 +302,8 @@ func LoadDAWGFromFile(fileName string) (dawg *DAWG, err error) {
 	if err = scanner.Err(); err != nil {
 		return
 	}
-	return &DAWG{initialState: initialState, nodesCount: nbNodes}, nil
+	countWords(initialState, make(map[*state]bool))
+	return &DAWG{initialState: initialState, nodesCount: nbNodes, maxWordSize: longestWord(initialState, make(map[*state]int))}, nil
 }
 
 // Save the DAWG to a file, usefull if you want to load it later without re-computing anything
@@ -383,25 +320,26 @@ func (dawg *DAWG) SaveToFile(fileName string) (err error) {
 		return
 	}
 
-	var nodeNumber uint64 = 0
-	// FIXME: if dawg.initialState.number != 0, the file was already saved, reinit all the numbers
-	err = saveSubTrieToFile(file, dawg.initialState, &nodeNumber)
+	// The states are numbered from the words, not from the order they were added in, so a set of words is always
+	// saved to the same bytes
+	numbers := make(map[*state]uint64)
+	err = saveSubTrieToFile(file, dawg.initialState, numbers)
 	return
 }
 
-func saveSubTrieToFile(file *os.File, curState *state, nodeNumber *uint64) (err error) {
-	for curLetter := curState.letters; curLetter != nil; curLetter = curLetter.next {
-		if curLetter.state.number == 0 {
-			err = saveSubTrieToFile(file, curLetter.state, nodeNumber)
+func saveSubTrieToFile(file *os.File, curState *state, numbers map[*state]uint64) (err error) {
+	letters := curState.letters
+	for _, curLetter := range letters {
+		if _, ok := numbers[curLetter.state]; !ok {
+			err = saveSubTrieToFile(file, curLetter.state, numbers)
 			if err != nil {
 				return
 			}
 		}
 	}
-	if curState.number == 0 {
-		(*nodeNumber)++
-		curState.number = (*nodeNumber)
-		if _, err = file.WriteString(strconv.FormatUint(curState.number-1, 10)); err != nil {
+	if _, ok := numbers[curState]; !ok {
+		numbers[curState] = uint64(len(numbers))
+		if _, err = file.WriteString(strconv.FormatUint(numbers[curState], 10)); err != nil {
 			return
 		}
 		if _, err = file.WriteString(" "); err != nil {
@@ -410,7 +348,7 @@ func saveSubTrieToFile(file *os.File, curState *state, nodeNumber *uint64) (err
 		if _, err = file.WriteString(strconv.FormatBool(curState.final)); err != nil {
 			return
 		}
-		for curLetter := curState.letters; curLetter != nil; curLetter = curLetter.next {
+		for _, curLetter := range letters {
 			if _, err = file.WriteString(" "); err != nil {
 				return
 			}
@@ -420,7 +358,7 @@ func saveSubTrieToFile(file *os.File, curState *state, nodeNumber *uint64) (err
 			if _, err = file.WriteString(" "); err != nil {
 				return
 			}
-			if _, err = file.WriteString(strconv.FormatUint(curLetter.state.number-1, 10)); err != nil {
+			if _, err = file.WriteString(strconv.FormatUint(numbers[curLetter.state], 10)); err != nil {
 				return
 			}
 		}
@@ -431,122 +369,7 @@ func saveSubTrieToFile(file *os.File, curState *state, nodeNumber *uint64) (err
 	return
 }
 
+// Get a random word of wordSize runes, all the words of this size being equally likely
 func (dawg *DAWG) FindRandomWord(wordSize int) (string, error) {
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-	// FIXME : infinite loop if no word of size wordSize
-	// FIXME : highly inefficient
-	INFINITE: for {
-		word := new(bytes.Buffer)
-		state := dawg.initialState
-		for i := 0; i < wordSize; i++ {
-			if state.lettersCount == 0 { // That's bad
-				continue INFINITE
-			}
-			var numLetter int
-			if state.lettersCount == 1 {
-				numLetter = 0
-			} else {
-				numLetter = r.Intn(state.lettersCount)
-			}
-			letter := state.letters
-			for j := 0; j < numLetter; j++ {
-				letter = letter.next
-			}
-			_, err := word.WriteRune(letter.char)
-			if err != nil {
-				return "", err
-			}
-			state = letter.state
-		}
-		if state.final {
-			return word.String(), nil
-		}
-	}
-}
-
-func searchSubString(state *state, start bytes.Buffer, end bytes.Buffer, levenshteinDistance int, maxResults int, allowAdd bool, allowDelete bool, ignoreChar rune) (words *word, lastWord *word, wordsSize int, er error) {
-	var char rune
-	if end.Len() > 0 {
-		char, _, er = end.ReadRune()
-		if er != nil {
-			return
-		}
-		if char != ignoreChar {
-			if letter := state.getletter(char); letter != nil {
-				runeLen, err := start.WriteRune(letter.char)
-				if err != nil {
-					return nil, nil, 0, err
-				}
-				foundWords, foundLastWord, foundWordsSize, err := searchSubString(letter.state, start, end, levenshteinDistance, maxResults, allowAdd, allowDelete, 0)
-				if err != nil {
-					return nil, nil, 0, err
-				}
-				words, lastWord, wordsSize = mergeWords(foundWords, foundLastWord, foundWordsSize, words, lastWord, wordsSize)
-				if maxResults > 0 && wordsSize > maxResults {
-					return
-				}
-				start.Truncate(start.Len() - runeLen) // Revert the WriteRune
-			}
-		}
-
-		if levenshteinDistance > 0 {
-			for letter := state.letters; letter != nil; letter = letter.next {
-				if letter.char != char && letter.char != ignoreChar { // Change one letter
-					runeLen, err := start.WriteRune(letter.char)
-					if err != nil {
-						return nil, nil, 0, err
-					}
-					foundWords, foundLastWord, foundWordsSize, err := searchSubString(letter.state, start, end, levenshteinDistance-1, maxResults, allowAdd, allowDelete, char)
-					if err != nil {
-						return nil, nil, 0, err
-					}
-					words, lastWord, wordsSize = mergeWords(foundWords, foundLastWord, foundWordsSize, words, lastWord, wordsSize)
-					if maxResults > 0 && wordsSize > maxResults {
-						return
-					}
-					start.Truncate(start.Len() - runeLen) // Revert the WriteRune
-				}
-			}
-			if allowDelete {
-				foundWords, foundLastWord, foundWordsSize, err := searchSubString(state, start, end, levenshteinDistance-1, maxResults, allowAdd, allowDelete, char) // Remove one letter
-				if err != nil {
-					return nil, nil, 0, err
-				}
-				words, lastWord, wordsSize = mergeWords(foundWords, foundLastWord, foundWordsSize, words, lastWord, wordsSize)
-				if maxResults > 0 && wordsSize > maxResults {
-					return
-				}
-			}
-		}
-
-		if err := end.UnreadRune(); err != nil { // Revert the ReadRune
-			return nil, nil, 0, err
-		}
-	} else if state.final {
-		words = &word{content: start.String(), nextWord: words}
-		lastWord = words
-		wordsSize = 1
-	}
-
-	if levenshteinDistance > 0 && allowAdd {
-		for letter := state.letters; letter != nil; letter = letter.next {
-			if letter.char != char && letter.char != ignoreChar { // Add one letter
-				runeLen, err := start.WriteRune(letter.char)
-				if err != nil {
-					return nil, nil, 0, err
-				}
-				foundWords, foundLastWord, foundWordsSize, err := searchSubString(letter.state, start, end, levenshteinDistance-1, maxResults, allowAdd, allowDelete, 0)
-				if err != nil {
-					return nil, nil, 0, err
-				}
-				words, lastWord, wordsSize = mergeWords(foundWords, foundLastWord, foundWordsSize, words, lastWord, wordsSize)
-				if maxResults > 0 && wordsSize > maxResults {
-					return
-				}
-				start.Truncate(start.Len() - runeLen) // Revert the WriteRune
-			}
-		}
-	}
-
-	return
+	return dawg.FindRandomWordWithPrefix("", wordSize)
 }
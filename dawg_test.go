@@ -1,6 +1,10 @@
 package dawg
 
-import "testing"
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
 
 func TestCreateDAWG(t *testing.T) {
 	dawg := CreateDAWG([]string{"test", "rest", "nest", "note"})
@@ -9,6 +13,20 @@ func TestCreateDAWG(t *testing.T) {
 	}
 }
 
+func TestContains(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "日本"})
+
+	if !dawg.Contains("test") || !dawg.Contains("tes") || !dawg.Contains("日本") {
+		t.Error("Contains failed")
+	}
+	if dawg.Contains("te") || dawg.Contains("tests") || dawg.Contains("") || dawg.Contains("日") {
+		t.Error("Contains failed")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { dawg.Contains("test2") }); allocs != 0 {
+		t.Error("Contains should not allocate")
+	}
+}
+
 func TestSearch(t *testing.T) {
 	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note"})
 
@@ -50,3 +68,131 @@ func TestUTF8(t *testing.T) {
 		t.Error("UTF-8 Failed")
 	}
 }
+
+func TestSearchWithTranspositions(t *testing.T) {
+	dawg := CreateDAWG([]string{"the", "then", "test", "tset", "bare", "bear"})
+
+	if words, err := dawg.Search("teh", 1, 10, false, false); err != nil || len(words) != 0 {
+		t.Error("Search failed")
+	}
+	words, err := dawg.SearchWithTranspositions("teh", 1, 10, false, false)
+	if err != nil || len(words) != 1 || words[0] != "the" {
+		t.Error("SearchWithTranspositions failed")
+	}
+	// A transposition and an insertion
+	words, err = dawg.SearchWithTranspositions("tehn", 2, 10, true, false)
+	if err != nil || !containsWord(words, "then") {
+		t.Error("SearchWithTranspositions failed")
+	}
+	// A transposition and a substitution
+	words, err = dawg.SearchWithTranspositions("baer", 1, 10, false, false)
+	if err != nil || !containsWord(words, "bare") || !containsWord(words, "bear") {
+		t.Error("SearchWithTranspositions failed")
+	}
+	// Two transpositions
+	words, err = dawg.SearchWithTranspositions("etts", 2, 10, false, false)
+	if err != nil || len(words) != 1 || words[0] != "test" {
+		t.Error("SearchWithTranspositions failed")
+	}
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSearchWithDistance(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note"})
+
+	matches, err := dawg.SearchWithDistance("test", 1, 10, true, true)
+	if err != nil || len(matches) != 5 {
+		t.Error("SearchWithDistance failed")
+	}
+	for _, match := range matches {
+		if match.Distance != levenshtein([]rune("test"), []rune(match.Word)) {
+			t.Error("SearchWithDistance failed for", match.Word, match.Distance)
+		}
+	}
+
+	matches, err = dawg.SearchWithDistance("nose", 2, 10, false, false)
+	if err != nil || len(matches) != 3 {
+		t.Error("SearchWithDistance failed")
+	}
+	for _, match := range matches {
+		if match.Word == "note" && match.Distance != 1 || match.Word != "note" && match.Distance != 2 {
+			t.Error("SearchWithDistance failed for", match.Word, match.Distance)
+		}
+	}
+}
+
+func TestSearchBestResults(t *testing.T) {
+	dawg := CreateDAWG([]string{"aaaa", "aaab", "aabb", "abbb", "bbbb"})
+
+	// The exact match must be kept, whatever the order of the traversal
+	for _, query := range []string{"aaaa", "abbb", "bbbb"} {
+		matches, err := dawg.SearchWithDistance(query, 4, 1, false, false)
+		if err != nil || len(matches) != 1 || matches[0].Word != query || matches[0].Distance != 0 {
+			t.Error("Search should keep the best results", matches)
+		}
+	}
+	matches, err := dawg.SearchWithDistance("aabb", 4, 3, false, false)
+	if err != nil || len(matches) != 3 || matches[0].Word != "aabb" || matches[1].Distance != 1 || matches[2].Distance != 1 {
+		t.Error("Search should keep the best results", matches)
+	}
+}
+
+func TestSearchSubstitutions(t *testing.T) {
+	dawg := CreateDAWG([]string{"yx", "yyx"})
+
+	if words, err := dawg.Search("xx", 1, 10, false, false); err != nil || len(words) != 1 || words[0] != "yx" {
+		t.Error("Search failed", words)
+	}
+	if words, err := dawg.Search("xzx", 2, 10, false, false); err != nil || len(words) != 1 || words[0] != "yyx" {
+		t.Error("Search failed", words)
+	}
+}
+
+func TestSearchOrder(t *testing.T) {
+	// The same words, added in different orders
+	words := []string{"test", "tese", "nest", "test2", "tes", "note", "best", "rest"}
+	reversed := make([]string, len(words))
+	for i, word := range words {
+		reversed[len(words)-1-i] = word
+	}
+	expected := "test best nest rest tes tese test2"
+	for _, dawg := range []*DAWG{CreateDAWG(words), CreateDAWG(reversed)} {
+		found, err := dawg.Search("test", 1, 10, true, true)
+		if err != nil || strings.Join(found, " ") != expected {
+			t.Error("Search order failed:", found)
+		}
+		found, err = dawg.Search("test", 1, 3, true, true)
+		if err != nil || strings.Join(found, " ") != "test best nest" {
+			t.Error("Search order failed:", found)
+		}
+	}
+}
+
+func TestCreateDAWGMinimal(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	words := make([]string, 5000)
+	for i := range words {
+		word := make([]rune, 1+r.Intn(8))
+		for j := range word {
+			word[j] = rune('a' + r.Intn(4))
+		}
+		words[i] = string(word)
+	}
+	dawg := CreateDAWG(words)
+	if err := dawg.VerifyMinimal(); err != nil {
+		t.Error("CreateDAWG should be minimal:", err)
+	}
+	for _, word := range words {
+		if !dawg.Contains(word) {
+			t.Error("CreateDAWG lost", word)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package dawg
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// Bits of the units of a dawgdic dictionary
+const (
+	dawgdicIsLeaf     = 1 << 31 // The unit holds the value of a word
+	dawgdicHasLeaf    = 1 << 8  // The unit has a leaf child (a word ends here)
+	dawgdicExtension  = 1 << 9  // The offset is shifted by 8 bits
+	dawgdicOffsetMax  = 1 << 21 // Maximum offset without extension
+	dawgdicLabelMask  = 0xFF
+	dawgdicOffsetBits = 10 // Position of the offset in the unit
+	dawgdicOffsetMask = 0xFF << 21
+
+	dawgdicBlockSize  = 256 // Number of units added at once
+	dawgdicOpenBlocks = 16  // Number of last blocks whose free units can still be used
+)
+
+// Read a dictionary in the binary format of the dawgdic C++ library, used by the DAWG Python package
+// (DAWG.save). The words are the keys of the dictionary, which must be UTF-8 encoded, their values are ignored.
+func ReadDawgdic(r io.Reader) (*DAWG, error) {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+	size := binary.LittleEndian.Uint32(header)
+	if size == 0 {
+		return nil, errors.New("Incorrect dawgdic format : no root.")
+	}
+	// The size is checked against the data while it is read, the buffer growing with the bytes read
+	data, err := readBytes(r, 4*uint64(size))
+	if err == io.ErrUnexpectedEOF {
+		return nil, errors.New("Incorrect dawgdic format : file too short.")
+	} else if err != nil {
+		return nil, err
+	}
+	units := make([]uint32, size)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint32(data[4*i:])
+	}
+
+	// The keys are found in the order of their bytes, which is the order of their runes
+	builder := newSortedBuilder(BuildOptions{})
+	var walk func(index uint32, key []byte) error
+	walk = func(index uint32, key []byte) error {
+		if len(key) >= len(units) { // Longer than any path of the dictionary
+			return errors.New("Incorrect dawgdic format : cycle.")
+		}
+		unit := units[index]
+		base := index ^ dawgdicOffset(unit)
+		if unit&dawgdicHasLeaf != 0 {
+			if !utf8.Valid(key) {
+				return errors.New("Incorrect dawgdic format : invalid UTF-8 key.")
+			}
+			builder.add(string(key), 0) // Sorted
+		}
+		for label := uint32(1); label <= dawgdicLabelMask; label++ {
+			child := base ^ label
+			if child < size && child != index && units[child]&(dawgdicIsLeaf|dawgdicLabelMask) == label {
+				if err := walk(child, append(key, byte(label))); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk(0, nil); err != nil {
+		return nil, err
+	}
+	return builder.dawg(), nil
+}
+
+// Get the offset of the children of a unit
+func dawgdicOffset(unit uint32) uint32 {
+	return (unit >> dawgdicOffsetBits) << ((unit & dawgdicExtension) >> 6)
+}
+
+// Write the DAWG to w in the binary format of the dawgdic C++ library, readable by the DAWG Python package
+// (dawg.DAWG().load). The words are the UTF-8 encoded keys of the dictionary, with the value 0, and can't contain
+// the NUL character, which ends the keys in this format.
+func (dawg *DAWG) WriteDawgdic(w io.Writer) error {
+	builder := &dawgdicBuilder{links: make(map[int32]uint32)}
+	root, err := builder.addNode(dawgdicNode{state: dawg.initialState}, make(map[dawgdicNode]int32), make(map[string]int32))
+	if err != nil {
+		return err
+	}
+	if err = builder.build(root); err != nil {
+		return err
+	}
+	buffer := make([]byte, 0, 4+4*len(builder.units))
+	buffer = binary.LittleEndian.AppendUint32(buffer, uint32(len(builder.units)))
+	for _, unit := range builder.units {
+		buffer = binary.LittleEndian.AppendUint32(buffer, unit)
+	}
+	_, err = w.Write(buffer)
+	return err
+}
+
+// A node of the DAWG of the bytes of the words: a state, and the first bytes of the runes of its letters
+type dawgdicNode struct {
+	state  *state
+	prefix string
+}
+
+// Get the labels of the children of the node, in increasing order, and the nodes they lead to
+func (node dawgdicNode) children() (labels []byte, children []dawgdicNode) {
+	var encoded [utf8.UTFMax]byte
+	for _, curLetter := range node.state.letters {
+		size := utf8.EncodeRune(encoded[:], curLetter.char)
+		bytes := string(encoded[:size])
+		if len(bytes) <= len(node.prefix) || bytes[:len(node.prefix)] != node.prefix {
+			continue
+		}
+		label := bytes[len(node.prefix)]
+		child := dawgdicNode{state: node.state, prefix: bytes[:len(node.prefix)+1]}
+		if len(bytes) == len(node.prefix)+1 {
+			child = dawgdicNode{state: curLetter.state}
+		}
+		// The runes sharing their next byte share the child
+		if len(labels) == 0 || labels[len(labels)-1] != label {
+			labels = append(labels, label)
+			children = append(children, child)
+		}
+	}
+	return
+}
+
+// Check if a word ends at the node
+func (node dawgdicNode) final() bool {
+	return node.prefix == "" && node.state.final
+}
+
+// A node of the minimal DAWG of the bytes, where the states and the prefixes of runes with the same bytes under
+// them are merged, as in the DawgBuilder of dawgdic
+type dawgdicByteNode struct {
+	final    bool
+	labels   []byte
+	children []int32
+	parents  int // Number of edges leading to the node
+}
+
+// The state of a unit of the dictionary while it is built
+type dawgdicExtra struct {
+	prev, next uint32 // The free units of the open blocks, in a circular list
+	used       bool   // The unit is the base of a node
+	fixed      bool   // The unit is not free
+}
+
+// A dawgdicBuilder places the nodes in the double array of a dawgdic dictionary, the children of the unit i
+// being at i ^ offset ^ label, as the DictionaryBuilder of dawgdic: the dictionaries are the same bytes.
+type dawgdicBuilder struct {
+	nodes   []dawgdicByteNode
+	units   []uint32
+	extras  []dawgdicExtra
+	unfixed uint32           // First free unit of the open blocks, len(units) if none
+	links   map[int32]uint32 // Base of the children of the nodes with several parents already placed
+	labels  []byte
+}
+
+// Add the node and the nodes under it to the minimal DAWG of the bytes, and get its number
+func (builder *dawgdicBuilder) addNode(node dawgdicNode, numbers map[dawgdicNode]int32, registry map[string]int32) (int32, error) {
+	if number, ok := numbers[node]; ok {
+		return number, nil
+	}
+	labels, children := node.children()
+	if len(labels) > 0 && labels[0] == 0 {
+		return 0, errors.New("Incorrect word for the dawgdic format : NUL character.")
+	}
+	byteNode := dawgdicByteNode{final: node.final(), labels: labels, children: make([]int32, len(children))}
+	key := make([]byte, 1, 1+5*len(labels))
+	if byteNode.final {
+		key[0] = 1
+	}
+	for i, child := range children {
+		number, err := builder.addNode(child, numbers, registry)
+		if err != nil {
+			return 0, err
+		}
+		byteNode.children[i] = number
+		key = binary.LittleEndian.AppendUint32(append(key, labels[i]), uint32(number))
+	}
+	number, ok := registry[string(key)]
+	if !ok {
+		number = int32(len(builder.nodes))
+		for _, child := range byteNode.children {
+			builder.nodes[child].parents++
+		}
+		builder.nodes = append(builder.nodes, byteNode)
+		registry[string(key)] = number
+	}
+	numbers[node] = number
+	return number, nil
+}
+
+// Build the dictionary from the root node
+func (builder *dawgdicBuilder) build(root int32) error {
+	builder.reserveUnit(0)
+	builder.extras[0].used = true
+	builder.units[0] = 1 << dawgdicOffsetBits
+	if node := builder.nodes[root]; node.final || len(node.labels) > 0 {
+		if !builder.buildNode(root, 0) {
+			return errors.New("Too many units for the dawgdic format.")
+		}
+	}
+	for block := max(len(builder.units)/dawgdicBlockSize-dawgdicOpenBlocks, 0); block < len(builder.units)/dawgdicBlockSize; block++ {
+		builder.fixBlock(uint32(block))
+	}
+	return nil
+}
+
+// Place the children of the node reached by the unit, depth-first. The nodes with several parents share their
+// children when the offset allows it.
+func (builder *dawgdicBuilder) buildNode(number int32, index uint32) bool {
+	node := builder.nodes[number]
+	if base, ok := builder.links[number]; ok {
+		offset := base ^ index
+		if offset&dawgdicOffsetMask == 0 || offset&dawgdicLabelMask == 0 {
+			if node.final {
+				builder.units[index] |= dawgdicHasLeaf
+			}
+			builder.setOffset(index, offset)
+			return true
+		}
+	}
+	base, ok := builder.arrangeChildren(node, index)
+	if !ok {
+		return false
+	}
+	if node.parents > 1 {
+		builder.links[number] = base
+	}
+	for i, label := range node.labels {
+		if !builder.buildNode(node.children[i], base^uint32(label)) {
+			return false
+		}
+	}
+	return true
+}
+
+// Find a base for the children of the node and reserve their units
+func (builder *dawgdicBuilder) arrangeChildren(node dawgdicByteNode, index uint32) (uint32, bool) {
+	builder.labels = builder.labels[:0]
+	if node.final {
+		builder.labels = append(builder.labels, 0)
+	}
+	builder.labels = append(builder.labels, node.labels...)
+	base := builder.findBase(index)
+	if !builder.setOffset(index, index^base) {
+		return 0, false
+	}
+	for _, label := range builder.labels {
+		child := base ^ uint32(label)
+		builder.reserveUnit(child)
+		if label == 0 {
+			builder.units[index] |= dawgdicHasLeaf
+			builder.units[child] = dawgdicIsLeaf // The value 0
+		} else {
+			builder.units[child] = builder.units[child]&^dawgdicLabelMask | uint32(label)
+		}
+	}
+	builder.extras[base].used = true
+	return base, true
+}
+
+// Set the offset of the unit, if it can be encoded
+func (builder *dawgdicBuilder) setOffset(index uint32, offset uint32) bool {
+	if offset >= dawgdicOffsetMax<<8 {
+		return false
+	}
+	unit := builder.units[index] & (dawgdicIsLeaf | dawgdicHasLeaf | dawgdicLabelMask)
+	if offset < dawgdicOffsetMax {
+		unit |= offset << dawgdicOffsetBits
+	} else {
+		unit |= offset<<2 | dawgdicExtension
+	}
+	builder.units[index] = unit
+	return true
+}
+
+// Find a base among the free units of the open blocks, or else in a new block
+func (builder *dawgdicBuilder) findBase(index uint32) uint32 {
+	size := uint32(len(builder.units))
+	if builder.unfixed >= size {
+		return size | index&dawgdicLabelMask
+	}
+	for free := builder.unfixed; ; {
+		if base := free ^ uint32(builder.labels[0]); builder.isGoodBase(index, base) {
+			return base
+		}
+		if free = builder.extras[free].next; free == builder.unfixed {
+			return size | index&dawgdicLabelMask
+		}
+	}
+}
+
+// Check if the base is not used by another node, its offset from the unit can be encoded and the units of the
+// children are free
+func (builder *dawgdicBuilder) isGoodBase(index uint32, base uint32) bool {
+	if builder.extras[base].used {
+		return false
+	}
+	if offset := index ^ base; offset&dawgdicLabelMask != 0 && offset&dawgdicOffsetMask != 0 {
+		return false
+	}
+	for _, label := range builder.labels[1:] {
+		if builder.extras[base^uint32(label)].fixed {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove the unit from the free units, adding a block if needed
+func (builder *dawgdicBuilder) reserveUnit(index uint32) {
+	if index >= uint32(len(builder.units)) {
+		builder.addBlock()
+	}
+	extras := builder.extras
+	if index == builder.unfixed {
+		if builder.unfixed = extras[index].next; builder.unfixed == index {
+			builder.unfixed = uint32(len(builder.units))
+		}
+	}
+	extras[extras[index].prev].next = extras[index].next
+	extras[extras[index].next].prev = extras[index].prev
+	extras[index].fixed = true
+}
+
+// Add a block of free units, closing the oldest open block
+func (builder *dawgdicBuilder) addBlock() {
+	start := uint32(len(builder.units))
+	if blocks := start / dawgdicBlockSize; blocks >= dawgdicOpenBlocks {
+		builder.fixBlock(blocks - dawgdicOpenBlocks)
+	}
+	end := start + dawgdicBlockSize
+	builder.units = append(builder.units, make([]uint32, dawgdicBlockSize)...)
+	builder.extras = append(builder.extras, make([]dawgdicExtra, dawgdicBlockSize)...)
+	extras := builder.extras
+	for i := start + 1; i < end; i++ {
+		extras[i-1].next = i
+		extras[i].prev = i - 1
+	}
+	extras[start].prev = end - 1
+	extras[end-1].next = start
+
+	// Merge the free units of the block with the others
+	extras[start].prev = extras[builder.unfixed].prev
+	extras[end-1].next = builder.unfixed
+	extras[extras[builder.unfixed].prev].next = start
+	extras[builder.unfixed].prev = end - 1
+}
+
+// Close the block: its free units get a label which can't be reached from a base
+func (builder *dawgdicBuilder) fixBlock(block uint32) {
+	start, end := block*dawgdicBlockSize, (block+1)*dawgdicBlockSize
+	unused := uint32(0)
+	for base := start; base < end; base++ {
+		if !builder.extras[base].used {
+			unused = base
+			break
+		}
+	}
+	for index := start; index < end; index++ {
+		if !builder.extras[index].fixed {
+			builder.reserveUnit(index)
+			builder.units[index] = builder.units[index]&^dawgdicLabelMask | (index^unused)&dawgdicLabelMask
+		}
+	}
+}
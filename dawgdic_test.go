@@ -0,0 +1,86 @@
+package dawg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Check if the key is in a dawgdic dictionary, as the Contains method of the dawgdic library
+func dawgdicContains(data []byte, key string) bool {
+	units := make([]uint32, binary.LittleEndian.Uint32(data))
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint32(data[4+4*i:])
+	}
+	index := uint32(0)
+	for i := 0; i < len(key); i++ {
+		label := uint32(key[i])
+		next := index ^ dawgdicOffset(units[index]) ^ label
+		if int(next) >= len(units) || units[next]&(dawgdicIsLeaf|dawgdicLabelMask) != label {
+			return false
+		}
+		index = next
+	}
+	return units[index]&dawgdicHasLeaf != 0 && units[index^dawgdicOffset(units[index])]&dawgdicIsLeaf != 0
+}
+
+func TestDawgdic(t *testing.T) {
+	words := []string{"", "b", "bar", "baz", "bé", "bè", "foo", "foobar", "zèbre", "日本"}
+	dawg := CreateDAWG(words)
+
+	var buffer bytes.Buffer
+	if err := dawg.WriteDawgdic(&buffer); err != nil {
+		t.Fatal("WriteDawgdic failed:", err)
+	}
+	for _, word := range words {
+		if !dawgdicContains(buffer.Bytes(), word) {
+			t.Error("WriteDawgdic failed:", word)
+		}
+	}
+	for _, word := range []string{"ba", "fo", "foob", "bë", "日", "x"} {
+		if dawgdicContains(buffer.Bytes(), word) {
+			t.Error("WriteDawgdic failed:", word)
+		}
+	}
+
+	read, err := ReadDawgdic(bytes.NewReader(buffer.Bytes()))
+	if err != nil || !slices.Equal(slices.Collect(read.Words()), slices.Collect(dawg.Words())) || read.NodesCount() != dawg.NodesCount() {
+		t.Error("ReadDawgdic failed:", err)
+	}
+	if _, err = ReadDawgdic(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0x7f, 0, 0, 0, 0})); err == nil || err.Error() != "Incorrect dawgdic format : file too short." {
+		t.Error("ReadDawgdic of a size larger than the data failed", err)
+	}
+	if _, err = ReadDawgdic(bytes.NewReader(buffer.Bytes()[:10])); err == nil {
+		t.Error("ReadDawgdic should fail")
+	}
+	if err = CreateDAWG([]string{"a", "a\x00b"}).WriteDawgdic(&buffer); err == nil || err.Error() != "Incorrect word for the dawgdic format : NUL character." {
+		t.Error("WriteDawgdic of a word with a NUL character failed", err)
+	}
+}
+
+// The dictionary of testdata/dawgdic/words.txt saved by the DAWG Python package, see testdata/dawgdic/generate.py
+func TestDawgdicFixture(t *testing.T) {
+	data, err := os.ReadFile("testdata/dawgdic/words.dawg")
+	if os.IsNotExist(err) {
+		t.Skip("testdata/dawgdic/words.dawg must be generated with testdata/dawgdic/generate.py")
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	text, err := os.ReadFile("testdata/dawgdic/words.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := strings.Split(strings.TrimSuffix(string(text), "\n"), "\n")
+
+	read, err := ReadDawgdic(bytes.NewReader(data))
+	if err != nil || !slices.Equal(slices.Collect(read.Words()), words) {
+		t.Error("ReadDawgdic of the fixture failed:", err)
+	}
+	var buffer bytes.Buffer
+	if err = CreateDAWG(words).WriteDawgdic(&buffer); err != nil || !bytes.Equal(buffer.Bytes(), data) {
+		t.Error("WriteDawgdic failed to give the bytes of the fixture:", err)
+	}
+}
@@ -0,0 +1,47 @@
+package dawg
+
+import "iter"
+
+// Compare two versions of a DAWG: removed iterates over the words only in oldDAWG, and added over the words
+// only in newDAWG, in lexicographic order.
+// Both DAWGs are walked at the same time, skipping the states with the same words under them: the words in both
+// DAWGs are not walked, but the states are compared with hashes of the words under them, which are computed on all
+// the states of both DAWGs once per iteration. So the cost is linear in the number of states of the DAWGs,
+// plus the size of the changes.
+func Diff(oldDAWG *DAWG, newDAWG *DAWG) (removed iter.Seq[string], added iter.Seq[string]) {
+	return wordsNotIn(oldDAWG, newDAWG), wordsNotIn(newDAWG, oldDAWG)
+}
+
+// Iterate over the words of a which are not in b, in lexicographic order
+func wordsNotIn(a *DAWG, b *DAWG) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		hashesA, hashesB := make(map[*state][32]byte), make(map[*state][32]byte)
+		walkDifference(a.initialState, b.initialState, nil, hashesA, hashesB, func(word []rune) bool {
+			return yield(string(word))
+		})
+	}
+}
+
+// Call fn for each word under a which is not under b (nil if the prefix is not in b), in lexicographic order.
+// The walk stops as soon as fn returns false.
+func walkDifference(a *state, b *state, prefix []rune, hashesA map[*state][32]byte, hashesB map[*state][32]byte, fn func(word []rune) bool) bool {
+	if b == nil {
+		return walkSorted(a, prefix, fn)
+	}
+	if hashState(a, hashesA) == hashState(b, hashesB) {
+		return true // Same words
+	}
+	if a.final && !b.final && !fn(prefix) {
+		return false
+	}
+	for _, letterA := range a.letters {
+		var subB *state
+		if letterB := b.getletter(letterA.char); letterB != nil {
+			subB = letterB.state
+		}
+		if !walkDifference(letterA.state, subB, append(prefix, letterA.char), hashesA, hashesB, fn) {
+			return false
+		}
+	}
+	return true
+}
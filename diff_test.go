@@ -0,0 +1,31 @@
+package dawg
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	oldDAWG := CreateDAWG([]string{"test", "tes", "nest", "", "notes", "zebra"})
+	newDAWG := CreateDAWG([]string{"test", "rest", "note", "nest", "zebra", "zebras"})
+
+	removed, added := Diff(oldDAWG, newDAWG)
+	if words := strings.Join(slices.Collect(removed), " "); words != " notes tes" {
+		t.Error("Diff failed:", words)
+	}
+	if words := strings.Join(slices.Collect(added), " "); words != "note rest zebras" {
+		t.Error("Diff failed:", words)
+	}
+	for word := range added {
+		if word != "note" {
+			t.Error("Diff should stop")
+		}
+		break
+	}
+
+	removed, added = Diff(oldDAWG, oldDAWG)
+	if len(slices.Collect(removed)) != 0 || len(slices.Collect(added)) != 0 {
+		t.Error("Diff of the same DAWG failed")
+	}
+}
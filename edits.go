@@ -0,0 +1,134 @@
+package dawg
+
+import (
+	"iter"
+	"slices"
+	"strings"
+)
+
+// Get the strings at most at the given distance of the word which are prefixes of the words of the DAWG (the words
+// themselves included), in lexicographic order: the candidates of a spelling corrector, as generated by the edits of
+// Peter Norvig's corrector (deleting, inserting, replacing or swapping adjacent letters), but only following the
+// letters of the DAWG. Accepting the candidates is left to the caller, to score them with a language model for example.
+func (dawg *DAWG) GenerateEdits(word string, distance int) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		if distance < 0 {
+			return
+		}
+		rows := &editRows{options: SearchOptions{Distance: distance, AllowAdd: true, AllowDelete: true, Transpose: true}, query: []rune(word)}
+		rows.firstRow()
+		rows.generate(dawg.initialState, yield)
+	}
+}
+
+// Call yield for the prefix leading to the state, and the prefixes starting with it, within the distance of the query.
+// Return false if yield did.
+func (rows *editRows) generate(curState *state, yield func(string) bool) bool {
+	depth := len(rows.word)
+	row := rows.rows[depth]
+	if row[len(rows.query)] <= rows.options.Distance && !yield(string(rows.word)) {
+		return false
+	}
+	if slices.Min(row) > rows.options.Distance {
+		return true
+	}
+	for _, curLetter := range curState.letters {
+		rows.word = append(rows.word[:depth], curLetter.char)
+		rows.nextRow()
+		if !rows.generate(curLetter.state, yield) {
+			return false
+		}
+	}
+	rows.word = rows.word[:depth]
+	return true
+}
+
+// The edit distances between the prefixes of a query and a word built letter by letter: rows[i][j] is the distance
+// between the first i letters of the word and the first j letters of the query, with the edits of the options
+// (Distance + 1 for the higher distances)
+type editRows struct {
+	options SearchOptions
+	query   []rune
+	word    []rune
+	rows    [][]int // rows[len(word)] is the row of the current word
+}
+
+// Compute the row of the empty word
+func (rows *editRows) firstRow() []int {
+	firstRow := rows.row(0)
+	firstRow[0] = 0
+	for i := 1; i < len(firstRow); i++ {
+		switch {
+		case i > rows.options.ExactPrefix && rows.freeSeparator(rows.query[i-1]): // Deleting a separator for free
+			firstRow[i] = firstRow[i-1]
+		case !rows.options.AllowDelete || rows.options.ExactPrefix > 0:
+			firstRow[i] = rows.options.Distance + 1
+		default: // Deleting the first letters of the query
+			firstRow[i] = min(firstRow[i-1]+1, rows.options.Distance+1)
+		}
+	}
+	return firstRow
+}
+
+// Get the reusable row of the given depth
+func (rows *editRows) row(depth int) []int {
+	for len(rows.rows) <= depth {
+		rows.rows = append(rows.rows, make([]int, len(rows.query)+1))
+	}
+	return rows.rows[depth]
+}
+
+// Compute the row of the word after its last letter, from the rows of its prefixes (the same edits as the matcher)
+func (rows *editRows) nextRow() []int {
+	options, query, word := rows.options, rows.query, rows.word
+	depth := len(word)
+	char, previousRow, row := word[depth-1], rows.rows[depth-1], rows.row(depth)
+	row[0] = options.Distance + 1 // Inserting the letter into the word
+	if options.AllowAdd && options.ExactPrefix == 0 {
+		row[0] = previousRow[0] + 1
+	}
+	if options.ExactPrefix == 0 && rows.freeSeparator(char) {
+		row[0] = previousRow[0]
+	}
+	for i := 1; i < len(row); i++ {
+		row[i] = options.Distance + 1
+		if i > options.ExactPrefix { // Substitution
+			row[i] = previousRow[i-1] + 1
+		}
+		if rows.sameLetter(char, query[i-1]) {
+			row[i] = previousRow[i-1]
+		}
+		if options.AllowAdd && i >= options.ExactPrefix {
+			row[i] = min(row[i], previousRow[i]+1)
+		}
+		if options.AllowDelete && i > options.ExactPrefix {
+			row[i] = min(row[i], row[i-1]+1)
+		}
+		if options.Transpose && i > options.ExactPrefix+1 && depth > 1 &&
+			rows.sameLetter(char, query[i-2]) && rows.sameLetter(word[depth-2], query[i-1]) {
+			row[i] = min(row[i], rows.rows[depth-2][i-2]+1)
+		}
+		// The separators edited for free: inserted, replaced by another separator, or deleted
+		if rows.freeSeparator(char) && i >= options.ExactPrefix {
+			row[i] = min(row[i], previousRow[i])
+			if i > options.ExactPrefix && rows.freeSeparator(query[i-1]) {
+				row[i] = min(row[i], previousRow[i-1])
+			}
+		}
+		if i > options.ExactPrefix && rows.freeSeparator(query[i-1]) {
+			row[i] = min(row[i], row[i-1])
+		}
+		row[i] = min(row[i], options.Distance+1) // Any distance above the maximum is as bad
+	}
+	return row
+}
+
+// Check if the letter is a separator which can be edited for free
+func (rows *editRows) freeSeparator(char rune) bool {
+	return rows.options.FreeSeparators && strings.ContainsRune(rows.options.Separators, char)
+}
+
+// Check if a letter of the word matches a letter of the query
+func (rows *editRows) sameLetter(a rune, b rune) bool {
+	return a == b || rows.options.IgnoreCase && sameFold(a, b)
+}
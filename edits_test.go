@@ -0,0 +1,64 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGenerateEdits(t *testing.T) {
+	dawg := CreateDAWG([]string{"the", "then", "they", "tea", "hte", "ten", "a"})
+
+	edits := slices.Collect(dawg.GenerateEdits("teh", 1))
+	// "te" and "th" by deleting a letter, "the" by swapping "eh", "tea" and "ten" by replacing "h", "hte" isn't a prefix
+	if !slices.Equal(edits, []string{"te", "tea", "ten", "th", "the"}) {
+		t.Error("GenerateEdits failed", edits)
+	}
+
+	// All the prefixes within the distance, and only them
+	for _, word := range []string{"teh", "tn", "x", ""} {
+		for distance := range 3 {
+			var expected []string
+			dawg.Walk(func(prefix string, isFinal bool) bool {
+				if optimalStringAlignment([]rune(prefix), []rune(word)) <= distance {
+					expected = append(expected, prefix)
+				}
+				return true
+			})
+			if edits := slices.Collect(dawg.GenerateEdits(word, distance)); !slices.Equal(edits, expected) {
+				t.Error("GenerateEdits failed", word, distance, edits, expected)
+			}
+		}
+	}
+
+	for range dawg.GenerateEdits("teh", 2) {
+		break // Stops the generation
+	}
+	if slices.Collect(dawg.GenerateEdits("teh", -1)) != nil {
+		t.Error("GenerateEdits of a negative distance failed")
+	}
+}
+
+// Compute the Damerau-Levenshtein distance between two words, each substring being edited once
+func optimalStringAlignment(a []rune, b []rune) int {
+	distances := make([][]int, len(a)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(b)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			distances[i][j] = min(distances[i-1][j]+1, distances[i][j-1]+1, distances[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				distances[i][j] = min(distances[i][j], distances[i-2][j-2]+1)
+			}
+		}
+	}
+	return distances[len(a)][len(b)]
+}
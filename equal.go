@@ -0,0 +1,93 @@
+package dawg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// Check if the two DAWGs contain the same words.
+// The DAWGs don't need to be minimal, only their words are compared.
+func (dawg *DAWG) Equal(other *DAWG) bool {
+	return equivalentStates(dawg.initialState, other.initialState, make(map[[2]*state]bool))
+}
+
+// Check if the same words are under the two states.
+// visited holds the pairs of states already compared (or being compared).
+func equivalentStates(a *state, b *state, visited map[[2]*state]bool) bool {
+	if a == b || visited[[2]*state{a, b}] {
+		return true
+	}
+	visited[[2]*state{a, b}] = true
+	if a.final != b.final || len(a.letters) != len(b.letters) {
+		return false
+	}
+	for _, letterA := range a.letters {
+		letterB := b.getletter(letterA.char)
+		if letterB == nil || !equivalentStates(letterA.state, letterB.state, visited) {
+			return false
+		}
+	}
+	return true
+}
+
+// Compute a fingerprint of the words of the DAWG (a SHA-256 hash over its minimized structure).
+// Two DAWGs have the same fingerprint if they contain the same words, whatever the way they were built,
+// stored or loaded: it can be used to check a deserialized DAWG, or as a cache key.
+func (dawg *DAWG) Fingerprint() [32]byte {
+	return hashState(dawg.initialState, make(map[*state][32]byte))
+}
+
+// Compute the hash of the words under the state, from its finality and the sorted hashes of
+// its letters (memoized in hashes)
+func hashState(curState *state, hashes map[*state][32]byte) [32]byte {
+	if hash, ok := hashes[curState]; ok {
+		return hash
+	}
+	hasher := sha256.New()
+	if curState.final {
+		hasher.Write([]byte{1})
+	} else {
+		hasher.Write([]byte{0})
+	}
+	for _, curLetter := range curState.letters {
+		var char [4]byte
+		binary.BigEndian.PutUint32(char[:], uint32(curLetter.char))
+		hasher.Write(char[:])
+		childHash := hashState(curLetter.state, hashes)
+		hasher.Write(childHash[:])
+	}
+	var hash [32]byte
+	hasher.Sum(hash[:0])
+	hashes[curState] = hash
+	return hash
+}
+
+// Check that the DAWG is minimal: no two of its states have the same words under them
+func (dawg *DAWG) VerifyMinimal() error {
+	hashes := make(map[*state][32]byte)
+	hashState(dawg.initialState, hashes)
+
+	// Remember a prefix leading to each state, to report the duplicated states
+	prefixes := make(map[*state]string)
+	var walk func(curState *state, prefix string)
+	walk = func(curState *state, prefix string) {
+		if _, ok := prefixes[curState]; ok {
+			return
+		}
+		prefixes[curState] = prefix
+		for _, curLetter := range curState.letters {
+			walk(curLetter.state, prefix+string(curLetter.char))
+		}
+	}
+	walk(dawg.initialState, "")
+
+	states := make(map[[32]byte]*state)
+	for curState, hash := range hashes {
+		if other, ok := states[hash]; ok && equivalentStates(curState, other, make(map[[2]*state]bool)) {
+			return fmt.Errorf("The DAWG is not minimal: the states reached by %q and %q are equivalent.", prefixes[other], prefixes[curState])
+		}
+		states[hash] = curState
+	}
+	return nil
+}
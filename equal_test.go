@@ -0,0 +1,58 @@
+package dawg
+
+import "testing"
+
+func TestEqual(t *testing.T) {
+	a := CreateDAWG([]string{"test", "rest", "nest", "note"})
+	b := CreateDAWG([]string{"note", "nest", "rest", "test"})
+	c := CreateDAWG([]string{"note", "nest", "rest", "tests"})
+
+	if !a.Equal(b) || a.Fingerprint() != b.Fingerprint() {
+		t.Error("Equal failed")
+	}
+	if a.Equal(c) || c.Equal(a) || a.Fingerprint() == c.Fingerprint() {
+		t.Error("Equal failed")
+	}
+}
+
+func TestVerifyMinimal(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "rest", "nest", "note"})
+	if err := dawg.VerifyMinimal(); err != nil {
+		t.Error("VerifyMinimal failed:", err)
+	}
+
+	// Duplicate the state reached by "t"
+	letter := dawg.initialState.getletter('t')
+	duplicate := *letter.state
+	letter.state = &duplicate
+	if err := dawg.VerifyMinimal(); err == nil {
+		t.Error("VerifyMinimal should fail on a non minimal DAWG")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "rest", "nest", "note", "日本"})
+
+	// Through a binary encoding, or built differently
+	data, err := dawg.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &DAWG{}
+	if err = decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	sorted, _ := CreateDAWGFromSorted([]string{"nest", "note", "rest", "test", "日本"})
+	if !dawg.Equal(decoded) || dawg.Fingerprint() != decoded.Fingerprint() || dawg.Fingerprint() != sorted.Fingerprint() {
+		t.Error("Fingerprint failed")
+	}
+
+	dawg.Add("tests")
+	if dawg.Equal(decoded) || dawg.Fingerprint() == decoded.Fingerprint() {
+		t.Error("Fingerprint should change")
+	}
+	dawg.Remove("tests")
+	if dawg.Fingerprint() != decoded.Fingerprint() {
+		t.Error("Fingerprint failed")
+	}
+}
@@ -0,0 +1,120 @@
+package dawg
+
+import "slices"
+
+// EditOp is the kind of an edit turning a searched word into a word found
+type EditOp int
+
+const (
+	Substitution  EditOp = iota // A letter is replaced by another one
+	Insertion                   // A letter is inserted
+	Deletion                    // A letter is deleted
+	Transposition               // Two adjacent letters are swapped
+)
+
+func (op EditOp) String() string {
+	switch op {
+	case Substitution:
+		return "substitution"
+	case Insertion:
+		return "insertion"
+	case Deletion:
+		return "deletion"
+	case Transposition:
+		return "transposition"
+	}
+	return "unknown"
+}
+
+// Edit is an edit turning a searched word into a word found
+type Edit struct {
+	Op           EditOp
+	Position     int  // Position of the edit in the searched word, in runes (the letter before which a letter is inserted)
+	WordPosition int  // Position of the edit in the word found, in runes (the letter before which a letter was deleted)
+	From         rune // Letter of the searched word (the first of the two letters of a transposition, 0 for an insertion)
+	To           rune // Letter of the word found (the first of the two letters of a transposition, 0 for a deletion)
+}
+
+// Explanation is a word found by an approximate search, with the edits leading to it
+type Explanation struct {
+	Match
+	Edits []Edit // Edits turning the searched word into Word, by position (as many as the Distance)
+}
+
+// Same as SearchWithOptions, with the edits turning the searched word into each word found.
+// The edits only use the edits allowed by the options, so they are the ones counted by the Distance.
+func (dawg *DAWG) SearchExplained(word string, options SearchOptions) ([]Explanation, error) {
+	matches, err := dawg.SearchWithOptions(word, options)
+	if err != nil {
+		return nil, err
+	}
+	query := []rune(options.normalize(word))
+	explanations := make([]Explanation, len(matches))
+	for i, match := range matches {
+		explanations[i] = Explanation{Match: match, Edits: editScript(query, []rune(match.Word), options)}
+	}
+	return explanations, nil
+}
+
+// Get the fewest edits allowed by the options turning a into b, by position.
+// The substitutions are preferred to the other edits of the same cost.
+func editScript(a []rune, b []rune, options SearchOptions) []Edit {
+	// distances[i][j] is the distance between a[:i] and b[:j], disallowed edits being too expensive
+	impossible := len(a) + len(b) + 1
+	distances := make([][]int, len(a)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(b)+1)
+		for j := range distances[i] {
+			distance := impossible
+			switch {
+			case i == 0 && j == 0:
+				distance = 0
+			case i == 0 || j == 0:
+				if j == 0 && options.AllowDelete || i == 0 && options.AllowAdd {
+					distance = i + j
+				}
+			default:
+				cost := 1
+				if a[i-1] == b[j-1] {
+					cost = 0
+				}
+				distance = distances[i-1][j-1] + cost
+				if options.AllowDelete {
+					distance = min(distance, distances[i-1][j]+1)
+				}
+				if options.AllowAdd {
+					distance = min(distance, distances[i][j-1]+1)
+				}
+				if options.Transpose && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+					distance = min(distance, distances[i-2][j-2]+1)
+				}
+			}
+			distances[i][j] = distance
+		}
+	}
+
+	// Follow the edits back from the end of the words
+	var edits []Edit
+	i, j := len(a), len(b)
+	for i > 0 || j > 0 {
+		distance := distances[i][j]
+		switch {
+		case i > 0 && j > 0 && a[i-1] == b[j-1] && distances[i-1][j-1] == distance:
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && distances[i-1][j-1]+1 == distance:
+			i, j = i-1, j-1
+			edits = append(edits, Edit{Op: Substitution, Position: i, WordPosition: j, From: a[i], To: b[j]})
+		case options.Transpose && i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && distances[i-2][j-2]+1 == distance:
+			i, j = i-2, j-2
+			edits = append(edits, Edit{Op: Transposition, Position: i, WordPosition: j, From: a[i], To: b[j]})
+		case options.AllowDelete && i > 0 && distances[i-1][j]+1 == distance:
+			i--
+			edits = append(edits, Edit{Op: Deletion, Position: i, WordPosition: j, From: a[i]})
+		default:
+			j--
+			edits = append(edits, Edit{Op: Insertion, Position: i, WordPosition: j, To: b[j]})
+		}
+	}
+	slices.Reverse(edits)
+	return edits
+}
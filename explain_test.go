@@ -0,0 +1,41 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSearchExplained(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tests", "tet", "tets", "best"})
+
+	explanations, err := dawg.SearchExplained("tset", SearchOptions{Distance: 2, AllowAdd: true, AllowDelete: true, Transpose: true})
+	if err != nil || len(explanations) != 5 {
+		t.Fatal("SearchExplained failed:", explanations)
+	}
+	for _, explanation := range explanations {
+		if len(explanation.Edits) != explanation.Distance {
+			t.Error("SearchExplained distance failed:", explanation)
+		}
+	}
+	if explanations[0].Word != "test" || !slices.Equal(explanations[0].Edits, []Edit{{Op: Transposition, Position: 1, WordPosition: 1, From: 's', To: 'e'}}) {
+		t.Error("SearchExplained transposition failed:", explanations[0])
+	}
+	if explanations[1].Word != "tet" || !slices.Equal(explanations[1].Edits, []Edit{{Op: Deletion, Position: 1, WordPosition: 1, From: 's'}}) {
+		t.Error("SearchExplained deletion failed:", explanations[1])
+	}
+
+	explanations, err = dawg.SearchExplained("tet", SearchOptions{Distance: 1, AllowAdd: true})
+	if err != nil || len(explanations) != 3 || explanations[1].Word != "test" ||
+		!slices.Equal(explanations[1].Edits, []Edit{{Op: Insertion, Position: 2, WordPosition: 2, To: 's'}}) {
+		t.Error("SearchExplained insertion failed:", explanations)
+	}
+	// Without transpositions, "tets" is two substitutions away from "test"
+	explanations, err = dawg.SearchExplained("tets", SearchOptions{Distance: 2})
+	if err != nil || len(explanations) != 2 || explanations[1].Word != "test" ||
+		!slices.Equal(explanations[1].Edits, []Edit{{Op: Substitution, Position: 2, WordPosition: 2, From: 't', To: 's'}, {Op: Substitution, Position: 3, WordPosition: 3, From: 's', To: 't'}}) {
+		t.Error("SearchExplained substitutions failed:", explanations)
+	}
+	if Transposition.String() != "transposition" {
+		t.Error("EditOp String failed")
+	}
+}
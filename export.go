@@ -0,0 +1,98 @@
+package dawg
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Write the DAWG in the Graphviz DOT format.
+// The states are numbered in breadth-first order from the initial state (0),
+// the final states are double circled, and the edges are labeled with their rune.
+func (dawg *DAWG) WriteDOT(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	states, numbers := numberStates(dawg.initialState)
+	fmt.Fprintln(writer, "digraph dawg {")
+	fmt.Fprintln(writer, "\trankdir=LR;")
+	fmt.Fprintln(writer, "\tnode [shape=circle];")
+	for i, curState := range states {
+		if curState.final {
+			fmt.Fprintf(writer, "\t%d [shape=doublecircle];\n", i)
+		}
+	}
+	for i, curState := range states {
+		for _, curLetter := range curState.letters {
+			fmt.Fprintf(writer, "\t%d -> %d [label=%s];\n", i, numbers[curLetter.state], strconv.Quote(string(curLetter.char)))
+		}
+	}
+	fmt.Fprintln(writer, "}")
+	return writer.Flush()
+}
+
+// Write the DAWG as a Mermaid flowchart, to be rendered in Markdown documentation or in a browser.
+// The states are numbered as in WriteDOT (node n0 being the initial state), the final states are
+// double circled, and the edges are labeled with their rune.
+func (dawg *DAWG) WriteMermaid(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	states, numbers := numberStates(dawg.initialState)
+	fmt.Fprintln(writer, "flowchart LR")
+	for i, curState := range states {
+		if curState.final {
+			fmt.Fprintf(writer, "\tn%d(((%d)))\n", i, i)
+		} else {
+			fmt.Fprintf(writer, "\tn%d((%d))\n", i, i)
+		}
+	}
+	for i, curState := range states {
+		for _, curLetter := range curState.letters {
+			fmt.Fprintf(writer, "\tn%d -->|\"%s\"| n%d\n", i, mermaidLabel(curLetter.char), numbers[curLetter.state])
+		}
+	}
+	return writer.Flush()
+}
+
+// Get the text of a Mermaid label for the rune, the characters with a meaning for Mermaid or HTML,
+// and the characters which can't be printed, being written as entity codes
+func mermaidLabel(char rune) string {
+	if strings.ContainsRune("\"#<>&", char) || !unicode.IsPrint(char) {
+		return fmt.Sprintf("#%d;", char)
+	}
+	return string(char)
+}
+
+// Graph of a DAWG for WriteGraphJSON
+type jsonGraph struct {
+	Nodes []jsonGraphNode `json:"nodes"`
+	Edges []jsonGraphEdge `json:"edges"`
+}
+
+type jsonGraphNode struct {
+	ID    uint32 `json:"id"`
+	Final bool   `json:"final"`
+}
+
+type jsonGraphEdge struct {
+	From  uint32 `json:"from"`
+	To    uint32 `json:"to"`
+	Label string `json:"label"`
+}
+
+// Write the graph of the DAWG in JSON, as lists of nodes and edges for the graph libraries of the web:
+// {"nodes": [{"id": 0, "final": false}, ...], "edges": [{"from": 0, "to": 1, "label": "a"}, ...]}.
+// The states are numbered as in WriteDOT, node 0 being the initial state. Unlike MarshalJSON, the document
+// is not meant to be read back into a DAWG.
+func (dawg *DAWG) WriteGraphJSON(w io.Writer) error {
+	states, numbers := numberStates(dawg.initialState)
+	graph := jsonGraph{Nodes: make([]jsonGraphNode, len(states)), Edges: []jsonGraphEdge{}}
+	for i, curState := range states {
+		graph.Nodes[i] = jsonGraphNode{ID: uint32(i), Final: curState.final}
+		for _, curLetter := range curState.letters {
+			graph.Edges = append(graph.Edges, jsonGraphEdge{From: uint32(i), To: numbers[curLetter.state], Label: string(curLetter.char)})
+		}
+	}
+	return json.NewEncoder(w).Encode(graph)
+}
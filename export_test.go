@@ -0,0 +1,71 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	dawg := CreateDAWG([]string{"ab", "bb", "a\"", "b\""})
+
+	var builder strings.Builder
+	if err := dawg.WriteDOT(&builder); err != nil {
+		t.Fatal(err)
+	}
+	expected := `digraph dawg {
+	rankdir=LR;
+	node [shape=circle];
+	2 [shape=doublecircle];
+	0 -> 1 [label="a"];
+	0 -> 1 [label="b"];
+	1 -> 2 [label="\""];
+	1 -> 2 [label="b"];
+}
+`
+	if builder.String() != expected {
+		t.Error("WriteDOT failed")
+	}
+}
+
+func TestWriteMermaid(t *testing.T) {
+	dawg := CreateDAWG([]string{"ab", "bb", "a\"", "b#"})
+
+	var builder strings.Builder
+	if err := dawg.WriteMermaid(&builder); err != nil {
+		t.Fatal(err)
+	}
+	expected := `flowchart LR
+	n0((0))
+	n1((1))
+	n2((2))
+	n3(((3)))
+	n0 -->|"a"| n1
+	n0 -->|"b"| n2
+	n1 -->|"#34;"| n3
+	n1 -->|"b"| n3
+	n2 -->|"#35;"| n3
+	n2 -->|"b"| n3
+`
+	if builder.String() != expected {
+		t.Error("WriteMermaid failed", builder.String())
+	}
+}
+
+func TestWriteGraphJSON(t *testing.T) {
+	dawg := CreateDAWG([]string{"ab", "bb"})
+
+	var builder strings.Builder
+	if err := dawg.WriteGraphJSON(&builder); err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"nodes":[{"id":0,"final":false},{"id":1,"final":false},{"id":2,"final":true}],` +
+		`"edges":[{"from":0,"to":1,"label":"a"},{"from":0,"to":1,"label":"b"},{"from":1,"to":2,"label":"b"}]}` + "\n"
+	if builder.String() != expected {
+		t.Error("WriteGraphJSON failed", builder.String())
+	}
+
+	builder.Reset()
+	if err := CreateDAWG(nil).WriteGraphJSON(&builder); err != nil || builder.String() != `{"nodes":[{"id":0,"final":false}],"edges":[]}`+"\n" {
+		t.Error("WriteGraphJSON of an empty DAWG failed", builder.String())
+	}
+}
@@ -0,0 +1,43 @@
+//go:build !tinygo
+
+package dawg
+
+import (
+	"expvar"
+	"strconv"
+	"time"
+)
+
+// ExpvarMetrics is a Metrics publishing counters with expvar (and so on /debug/vars with net/http):
+// the number of searches, their total duration in nanoseconds and number of results, the number of searches
+// by distance, and the same for the builds with their total number of words and of states.
+type ExpvarMetrics struct {
+	vars *expvar.Map
+}
+
+// Create an ExpvarMetrics publishing its counters in an expvar.Map with the given name.
+// Like expvar.NewMap, it panics if the name is already used.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	vars := expvar.NewMap(name)
+	vars.Set("searches_by_distance", new(expvar.Map))
+	return &ExpvarMetrics{vars: vars}
+}
+
+// Get the expvar.Map of the counters
+func (metrics *ExpvarMetrics) Vars() *expvar.Map {
+	return metrics.vars
+}
+
+func (metrics *ExpvarMetrics) ObserveSearch(duration time.Duration, distance int, results int) {
+	metrics.vars.Add("searches", 1)
+	metrics.vars.Add("search_nanoseconds", int64(duration))
+	metrics.vars.Add("search_results", int64(results))
+	metrics.vars.Get("searches_by_distance").(*expvar.Map).Add(strconv.Itoa(distance), 1)
+}
+
+func (metrics *ExpvarMetrics) ObserveBuild(duration time.Duration, words uint64, nodes uint64) {
+	metrics.vars.Add("builds", 1)
+	metrics.vars.Add("build_nanoseconds", int64(duration))
+	metrics.vars.Add("build_words", int64(words))
+	metrics.vars.Add("build_nodes", int64(nodes))
+}
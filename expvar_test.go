@@ -0,0 +1,18 @@
+//go:build !tinygo
+
+package dawg
+
+import "testing"
+
+func TestExpvarMetrics(t *testing.T) {
+	metrics := NewExpvarMetrics("dawg_test")
+	dawg, _ := CreateDAWGWithOptions([]string{"test", "tests", "text"}, BuildOptions{Metrics: metrics})
+	dawg.Search("tast", 1, 10, false, false)
+	dawg.Search("tast", 2, 10, false, false)
+	vars := metrics.Vars()
+	if vars.Get("builds").String() != "1" || vars.Get("build_words").String() != "3" ||
+		vars.Get("searches").String() != "2" || vars.Get("search_results").String() != "3" ||
+		vars.Get("searches_by_distance").String() != `{"1": 1, "2": 1}` {
+		t.Error("ExpvarMetrics failed:", vars.String())
+	}
+}
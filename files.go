@@ -0,0 +1,77 @@
+package dawg
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Create a new DAWG by loading the words of several files, with options (see CreateDAWGFromFileWithOptions).
+// The files are read and compressed into DAWGs concurrently, then the DAWGs are merged two by two, also concurrently
+// (one after the other when compiled by TinyGo).
+// The options apply to each file: Progress may be called by several goroutines at the same time.
+// The error returned for a file which can't be loaded is a FileError.
+func CreateDAWGFromFiles(fileNames []string, options BuildOptions) (*DAWG, error) {
+	dawgs := make([]*DAWG, len(fileNames))
+	errs := make([]error, len(fileNames))
+	var wait sync.WaitGroup
+	workers := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i, fileName := range fileNames {
+		if !parallelBuild {
+			dawgs[i], errs[i] = CreateDAWGFromFileWithOptions(fileName, options)
+			continue
+		}
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			dawgs[i], errs[i] = CreateDAWGFromFileWithOptions(fileName, options)
+		}()
+	}
+	wait.Wait()
+	for i, err := range errs {
+		if err != nil {
+			return nil, &FileError{FileName: fileNames[i], Err: err}
+		}
+	}
+	if len(dawgs) == 0 {
+		return CreateDAWG(nil), nil
+	}
+
+	for len(dawgs) > 1 {
+		merged := make([]*DAWG, (len(dawgs)+1)/2)
+		for i := range merged {
+			if 2*i+1 == len(dawgs) {
+				merged[i] = dawgs[2*i]
+				continue
+			}
+			if !parallelBuild {
+				merged[i] = Union(dawgs[2*i], dawgs[2*i+1])
+				continue
+			}
+			wait.Add(1)
+			go func() {
+				defer wait.Done()
+				merged[i] = Union(dawgs[2*i], dawgs[2*i+1])
+			}()
+		}
+		wait.Wait()
+		dawgs = merged
+	}
+	dawgs[0].metrics = options.Metrics // Each file is observed, not the merge
+	return dawgs[0], nil
+}
+
+// FileError is returned when one of the files given to CreateDAWGFromFiles can't be loaded
+type FileError struct {
+	FileName string
+	Err      error
+}
+
+func (err *FileError) Error() string {
+	return err.FileName + ": " + err.Err.Error()
+}
+
+func (err *FileError) Unwrap() error {
+	return err.Err
+}
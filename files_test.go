@@ -0,0 +1,39 @@
+package dawg
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCreateDAWGFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	contents := []string{"apple\napricot\n", "banana\nberry\napple\n", "cherry\n", "", "date\n"}
+	var fileNames []string
+	for i, content := range contents {
+		fileName := filepath.Join(dir, string(rune('a'+i))+".txt")
+		if err := os.WriteFile(fileName, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		fileNames = append(fileNames, fileName)
+	}
+
+	dawg, err := CreateDAWGFromFiles(fileNames, BuildOptions{SkipBlankLines: true})
+	expected := CreateDAWG([]string{"apple", "apricot", "banana", "berry", "cherry", "date"})
+	if err != nil || !dawg.Equal(expected) || dawg.nodesCount != expected.nodesCount || dawg.CountWithPrefix("") != 6 {
+		t.Error("CreateDAWGFromFiles failed")
+	}
+
+	dawg, err = CreateDAWGFromFiles(nil, BuildOptions{})
+	if err != nil || dawg.CountWithPrefix("") != 0 {
+		t.Error("CreateDAWGFromFiles without files failed")
+	}
+
+	missing := filepath.Join(dir, "missing.txt")
+	_, err = CreateDAWGFromFiles(append(fileNames, missing), BuildOptions{})
+	var fileErr *FileError
+	if !errors.As(err, &fileErr) || fileErr.FileName != missing || !errors.Is(err, os.ErrNotExist) {
+		t.Error("CreateDAWGFromFiles with a missing file failed")
+	}
+}
@@ -0,0 +1,58 @@
+package dawg
+
+import "sort"
+
+// Create a new DAWG from words and their flags: a bitmask of categories defined by the application
+// (1 for a noun, 2 for a proper noun, 4 for an offensive word...), to filter the words found by the searches
+// with SearchOptions.RequireFlags and SearchOptions.ExcludeFlags.
+// As the frequencies, the flags are kept when words are added (with no flags) or removed, but they are not serialized.
+func CreateDAWGWithFlags(flags map[string]uint32) *DAWG {
+	words := make([]string, 0, len(flags))
+	for word := range flags {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	dawg, _ := CreateDAWGFromSorted(words) // Can't fail on sorted words
+	// The index of a word is its position in the sorted words
+	dawg.flags = make([]uint32, len(words))
+	for i, word := range words {
+		dawg.flags[i] = flags[word]
+	}
+	return dawg
+}
+
+// Get the flags of the word, 0 if the word isn't in the DAWG or if it has no flags
+func (dawg *DAWG) Flags(word string) uint32 {
+	if dawg.flags == nil {
+		return 0
+	}
+	index, ok := dawg.Index(word)
+	if !ok {
+		return 0
+	}
+	return dawg.flags[index]
+}
+
+// Set the flags of a word of the DAWG (see CreateDAWGWithFlags). Return false if the word isn't in the DAWG.
+// The DAWG must not be used by other goroutines during the call.
+func (dawg *DAWG) SetFlags(word string, flags uint32) bool {
+	index, ok := dawg.Index(word)
+	if !ok {
+		return false
+	}
+	if dawg.flags == nil {
+		dawg.flags = make([]uint32, dawg.WordsCount())
+	}
+	dawg.flags[index] = flags
+	return true
+}
+
+// Check if the options filter the words found by their flags
+func (options SearchOptions) filtersFlags() bool {
+	return options.RequireFlags != 0 || options.ExcludeFlags != 0
+}
+
+// Check if a word with the flags can be found by a search requiring and excluding flags
+func allowedFlags(flags uint32, require uint32, exclude uint32) bool {
+	return flags&require == require && flags&exclude == 0
+}
@@ -0,0 +1,82 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+const (
+	testNoun      = 1
+	testProper    = 2
+	testOffensive = 4
+)
+
+func TestFlags(t *testing.T) {
+	dawg := CreateDAWGWithFlags(map[string]uint32{"test": testNoun, "tests": testNoun, "Tess": testNoun | testProper, "teat": testOffensive, "tease": 0})
+
+	if dawg.Flags("Tess") != testNoun|testProper || dawg.Flags("tease") != 0 || dawg.Flags("x") != 0 {
+		t.Error("Flags failed")
+	}
+	if !dawg.SetFlags("tease", testNoun) || dawg.Flags("tease") != testNoun || dawg.SetFlags("x", testNoun) {
+		t.Error("SetFlags failed")
+	}
+
+	// The flags follow their words
+	dawg.Add("teas")
+	dawg.Remove("test")
+	if dawg.Flags("teas") != 0 || dawg.Flags("tests") != testNoun || dawg.Flags("teat") != testOffensive || dawg.Verify() != nil {
+		t.Error("Flags after changes failed")
+	}
+	if sub := dawg.Sub("tea"); sub.Flags("t") != testOffensive || sub.Flags("se") != testNoun {
+		t.Error("Flags of Sub failed")
+	}
+
+	withoutFlags := CreateDAWG([]string{"test"})
+	if withoutFlags.Flags("test") != 0 || !withoutFlags.SetFlags("test", testNoun) || withoutFlags.Flags("test") != testNoun {
+		t.Error("SetFlags without flags failed")
+	}
+}
+
+func TestSearchFlags(t *testing.T) {
+	dawg := CreateDAWGWithFlags(map[string]uint32{"test": testNoun, "tests": testNoun, "Tess": testNoun | testProper, "teat": testOffensive, "tent": 0})
+
+	words := func(options SearchOptions) []string {
+		matches, err := dawg.SearchWithOptions("tesst", options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var words []string
+		for _, match := range matches {
+			words = append(words, match.Word)
+		}
+		if count := dawg.CountMatches("tesst", options); count != len(words) {
+			t.Error("CountMatches with flags failed", count, words)
+		}
+		return words
+	}
+	options := SearchOptions{Distance: 2, AllowAdd: true, AllowDelete: true, IgnoreCase: true}
+	if found := words(options); !slices.Equal(found, []string{"Tess", "test", "teat", "tent", "tests"}) {
+		t.Fatal("Search failed", found)
+	}
+	options.ExcludeFlags = testOffensive
+	if found := words(options); !slices.Equal(found, []string{"Tess", "test", "tent", "tests"}) {
+		t.Error("ExcludeFlags failed", found)
+	}
+	options.RequireFlags, options.ExcludeFlags = testNoun, testProper
+	if found := words(options); !slices.Equal(found, []string{"test", "tests"}) {
+		t.Error("RequireFlags failed", found)
+	}
+	// The words excluded don't take the place of other words
+	options.MaxResults = 1
+	if found := words(options); !slices.Equal(found, []string{"test"}) {
+		t.Error("Flags with MaxResults failed", found)
+	}
+	options.MaxResults, options.Graphemes = 0, true
+	if found := words(options); !slices.Equal(found, []string{"test", "tests"}) {
+		t.Error("Flags on graphemes failed", found)
+	}
+	options.Graphemes, options.Prefix, options.Distance = false, true, 1
+	if found := words(options); !slices.Equal(found, []string{"test", "tests"}) {
+		t.Error("Flags in prefix mode failed", found)
+	}
+}
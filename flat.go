@@ -0,0 +1,214 @@
+package dawg
+
+import (
+	"iter"
+	"slices"
+	"sort"
+)
+
+// A flatGraph is a read-only DAWG whose states and letters are numbered: the nodes and the edges.
+// The node 0 is the initial state, and the edges of a node are consecutive and sorted by rune.
+// The queries are implemented once for all the flat representations (CompactDAWG, SuccinctDAWG).
+type flatGraph interface {
+	flatEdges
+	wordsCount(node uint32) uint64
+}
+
+// The nodes and edges of a flatGraph, enough to walk its words (MappedDAWG doesn't count the words under its nodes)
+type flatEdges interface {
+	final(node uint32) bool
+	edges(node uint32) (first uint32, last uint32) // The edges of the node are first to last (excluded)
+	char(edge uint32) rune
+	target(edge uint32) uint32
+}
+
+// Find the edge of the node with the rune, or the position where it would be, with a binary search
+func flatSearch(graph flatGraph, node uint32, char rune) (edge uint32, found bool) {
+	first, last := graph.edges(node)
+	edge = first + uint32(sort.Search(int(last-first), func(i int) bool {
+		return graph.char(first+uint32(i)) >= char
+	}))
+	return edge, edge < last && graph.char(edge) == char
+}
+
+// Get the node reached from the initial node by the prefix, false if no word starts with the prefix
+func flatPrefixNode(graph flatGraph, prefix string) (node uint32, ok bool) {
+	for _, char := range prefix {
+		edge, found := flatSearch(graph, node, char)
+		if !found {
+			return 0, false
+		}
+		node = graph.target(edge)
+	}
+	return node, true
+}
+
+// Check if the word is in the graph
+func flatContains(graph flatGraph, word string) bool {
+	node, ok := flatPrefixNode(graph, word)
+	return ok && graph.final(node)
+}
+
+// Count the words of the graph starting with the prefix
+func flatCountWithPrefix(graph flatGraph, prefix string) int {
+	node, ok := flatPrefixNode(graph, prefix)
+	if !ok {
+		return 0
+	}
+	return int(graph.wordsCount(node))
+}
+
+// Get the words of the graph starting with the prefix, in lexicographic order (at most max words if max > 0)
+func flatCompletions(graph flatGraph, prefix string, max int) []string {
+	completions := []string{}
+	node, ok := flatPrefixNode(graph, prefix)
+	if !ok {
+		return completions
+	}
+	flatWalkSorted(graph, node, []rune(prefix), func(word []rune) bool {
+		completions = append(completions, string(word))
+		return max <= 0 || len(completions) < max
+	})
+	return completions
+}
+
+// Iterate over all the words of the graph, in lexicographic order
+func flatWords(graph flatGraph) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		flatWalkSorted(graph, 0, nil, func(word []rune) bool {
+			return yield(string(word))
+		})
+	}
+}
+
+// Call fn for each word under the node, in lexicographic order, until it returns false.
+// The word slice is only valid during the call.
+func flatWalkSorted(graph flatGraph, node uint32, word []rune, fn func(word []rune) bool) bool {
+	if graph.final(node) && !fn(word) {
+		return false
+	}
+	first, last := graph.edges(node)
+	for edge := first; edge < last; edge++ {
+		if !flatWalkSorted(graph, graph.target(edge), append(word, graph.char(edge)), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the index of the word among the words of the graph sorted in lexicographic order
+func flatIndex(graph flatGraph, word string) (index uint, ok bool) {
+	var node uint32
+	for _, char := range word {
+		// The words lower than the prefix: the prefix itself, and the words after lower letters
+		if graph.final(node) {
+			index++
+		}
+		first, _ := graph.edges(node)
+		edge, found := flatSearch(graph, node, char)
+		for lower := first; lower < edge; lower++ {
+			index += uint(graph.wordsCount(graph.target(lower)))
+		}
+		if !found {
+			return 0, false
+		}
+		node = graph.target(edge)
+	}
+	if !graph.final(node) {
+		return 0, false
+	}
+	return index, true
+}
+
+// Get the word at the index among the words of the graph sorted in lexicographic order
+func flatWordAt(graph flatGraph, index uint) (word string, ok bool) {
+	if uint64(index) >= graph.wordsCount(0) {
+		return "", false
+	}
+	var runes []rune
+	var node uint32
+	for {
+		if graph.final(node) {
+			if index == 0 {
+				return string(runes), true
+			}
+			index--
+		}
+		first, last := graph.edges(node)
+		for edge := first; edge < last; edge++ {
+			target := graph.target(edge)
+			if uint64(index) < graph.wordsCount(target) {
+				runes = append(runes, graph.char(edge))
+				node = target
+				break
+			}
+			index -= uint(graph.wordsCount(target))
+		}
+	}
+}
+
+// Approximate string searching in the graph, with the edits of the options (Distance, AllowAdd, AllowDelete, Transpose,
+// ExactPrefix, IgnoreCase) and the lengths of the words found (MinLength, MaxLength). The other options are ignored,
+// but Compare which sorts the words of a same distance.
+// As for the DAWG, the words are searched by increasing distance, the words of a distance being all found before
+// searching the next one: the MaxResults closest words are found without walking the farther words.
+// The words are sorted by distance, then lexicographically, and the MaxResults first ones are returned.
+func flatSearchWithOptions(graph flatEdges, word string, options SearchOptions) []Match {
+	matches := []Match{}
+	searcher := &flatSearcher{editRows: editRows{options: options, query: []rune(word)}, graph: graph}
+	for distance := 0; distance <= options.Distance; distance++ {
+		// The words not found with a lower distance are exactly at this distance
+		searcher.options.Distance = distance
+		searcher.matches, searcher.limit = searcher.matches[:0], 0
+		if options.MaxResults > 0 && options.Compare == nil {
+			// The words are found in lexicographic order, so the first ones are kept
+			searcher.limit = options.MaxResults - len(matches)
+		}
+		searcher.firstRow()
+		searcher.visit(0)
+		slices.SortFunc(searcher.matches, func(a Match, b Match) int {
+			return options.compareWords(a.Word, b.Word)
+		})
+		matches = append(matches, searcher.matches...)
+		if options.MaxResults > 0 && len(matches) >= options.MaxResults {
+			return matches[:options.MaxResults]
+		}
+	}
+	return matches
+}
+
+// A flatSearcher walks a flat graph depth-first with the edit distances of the prefixes of the words,
+// keeping the words at exactly the distance of its options
+type flatSearcher struct {
+	editRows
+	graph   flatEdges
+	matches []Match
+	limit   int // Maximum number of words found, 0 for no limit
+}
+
+// Find the words under the node, the row of the letters leading to it being computed.
+// Return false once the limit is reached.
+func (searcher *flatSearcher) visit(node uint32) bool {
+	options, graph := searcher.options, searcher.graph
+	depth := len(searcher.word)
+	row := searcher.rows[depth]
+	if graph.final(node) && row[len(searcher.query)] == options.Distance && depth >= options.MinLength {
+		searcher.matches = append(searcher.matches, newMatch(string(searcher.word), options.Distance, runesSize(searcher.query)))
+		if searcher.limit > 0 && len(searcher.matches) >= searcher.limit {
+			return false
+		}
+	}
+	if slices.Min(row) > options.Distance || options.MaxLength > 0 && depth >= options.MaxLength {
+		return true
+	}
+	first, last := graph.edges(node)
+	for edge := first; edge < last; edge++ {
+		searcher.word = append(searcher.word[:depth], graph.char(edge))
+		searcher.nextRow()
+		if !searcher.visit(graph.target(edge)) {
+			return false
+		}
+	}
+	searcher.word = searcher.word[:depth]
+	return true
+}
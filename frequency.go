@@ -0,0 +1,150 @@
+package dawg
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Maximum cost added to the score of a suggestion because its word is rare:
+// a frequent word can come before a rarer word needing the same number of edits, never before a closer word
+const frequencyWeight = 0.5
+
+// Create a new DAWG from words and their frequencies (number of occurrences in a corpus, ...).
+// Suggest ranks the suggestions of such a DAWG by frequency as well as by edit cost.
+// The frequencies are kept when words are added (with a frequency of 0) or removed, but they are not serialized.
+func CreateDAWGWithFrequencies(frequencies map[string]uint64) *DAWG {
+	words := make([]string, 0, len(frequencies))
+	for word := range frequencies {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+	dawg, _ := CreateDAWGFromSorted(words) // Can't fail on sorted words
+	// The index of a word is its position in the sorted words
+	dawg.frequencies = make([]uint64, len(words))
+	for i, word := range words {
+		dawg.frequencies[i] = frequencies[word]
+		dawg.maxFrequency = max(dawg.maxFrequency, frequencies[word])
+	}
+	return dawg
+}
+
+// Get the frequency of the word, 0 if the word isn't in the DAWG or if the DAWG was built without frequencies
+func (dawg *DAWG) Frequency(word string) uint64 {
+	if dawg.frequencies == nil {
+		return 0
+	}
+	index, ok := dawg.Index(word)
+	if !ok {
+		return 0
+	}
+	return dawg.frequencies[index]
+}
+
+// Get the cost added to the score of a suggestion of the word, from 0 for the most frequent word
+// to frequencyWeight for a word with a frequency of 0 (on a log scale), 0 for a DAWG without frequencies
+func (dawg *DAWG) frequencyCost(word string) float64 {
+	if dawg.frequencies == nil || dawg.maxFrequency == 0 {
+		return 0
+	}
+	frequency := min(dawg.Frequency(word), dawg.maxFrequency)
+	return frequencyWeight * (1 - math.Log1p(float64(frequency))/math.Log1p(float64(dawg.maxFrequency)))
+}
+
+// Get the k words of the DAWG starting with the prefix with the highest frequencies (all of them if k <= 0), by
+// decreasing frequency, then in lexicographic order (see CreateDAWGWithFrequencies). The words starting with the
+// prefix are the words of a range of indexes: the subtrees are walked by decreasing maximum frequency of their
+// words, computed on the ranges of their indexes, so only the subtrees which can hold one of the k words are walked.
+// Without frequencies, the words are the first ones in lexicographic order.
+func (dawg *DAWG) TopCompletions(prefix string, k int) []string {
+	completions := []string{}
+	index, curState := dawg.rank(prefix)
+	if curState == nil {
+		return completions
+	}
+	if dawg.frequencies == nil {
+		return dawg.Completions(prefix, k)
+	}
+	first := uint64(index)
+	queue := &completionQueue{{word: prefix, state: curState, index: first, frequency: dawg.maxFrequencyBetween(first, first+curState.wordsCount)}}
+	for queue.Len() > 0 && (k <= 0 || len(completions) < k) {
+		item := heap.Pop(queue).(completionItem)
+		if item.state == nil {
+			completions = append(completions, item.word)
+			continue
+		}
+		// The words under the state: the word of the state itself, then the words of its letters
+		index := item.index
+		if item.state.final {
+			heap.Push(queue, completionItem{word: item.word, index: index, frequency: dawg.frequencies[index]})
+			index++
+		}
+		for _, curLetter := range item.state.letters {
+			last := index + curLetter.state.wordsCount
+			word := item.word + string(curLetter.char)
+			heap.Push(queue, completionItem{word: word, state: curLetter.state, index: index, frequency: dawg.maxFrequencyBetween(index, last)})
+			index = last
+		}
+	}
+	return completions
+}
+
+// A word, or the words under a state, waiting to be given by TopCompletions
+type completionItem struct {
+	word      string // The word, or the prefix leading to the state
+	state     *state // nil for a word
+	index     uint64 // Index of the word, or of the first word under the state
+	frequency uint64 // Frequency of the word, or maximum frequency of the words under the state
+}
+
+// Heap of the completions, the highest frequency on top. At the same frequency, the lowest word (or prefix)
+// is on top, a word before the state of the same prefix: so the words are given in the order of TopCompletions.
+type completionQueue []completionItem
+
+func (queue completionQueue) Len() int { return len(queue) }
+func (queue completionQueue) Less(i, j int) bool {
+	a, b := queue[i], queue[j]
+	if a.frequency != b.frequency {
+		return a.frequency > b.frequency
+	}
+	if order := strings.Compare(a.word, b.word); order != 0 {
+		return order < 0
+	}
+	return a.state == nil && b.state != nil
+}
+func (queue completionQueue) Swap(i, j int)       { queue[i], queue[j] = queue[j], queue[i] }
+func (queue *completionQueue) Push(x interface{}) { *queue = append(*queue, x.(completionItem)) }
+func (queue *completionQueue) Pop() interface{} {
+	old := *queue
+	x := old[len(old)-1]
+	*queue = old[:len(old)-1]
+	return x
+}
+
+// Get the maximum frequency of the words of indexes first to last (excluded), 0 if the range is empty.
+// The maxima are a segment tree of the frequencies: the leaves are the frequencies from the index n,
+// and the node i is the maximum of the nodes 2*i and 2*i+1.
+func (dawg *DAWG) maxFrequencyBetween(first uint64, last uint64) (maximum uint64) {
+	dawg.frequencyMaximaOnce.Do(func() {
+		n := len(dawg.frequencies)
+		dawg.frequencyMaxima = make([]uint64, 2*n)
+		copy(dawg.frequencyMaxima[n:], dawg.frequencies)
+		for i := n - 1; i > 0; i-- {
+			dawg.frequencyMaxima[i] = max(dawg.frequencyMaxima[2*i], dawg.frequencyMaxima[2*i+1])
+		}
+	})
+	maxima := dawg.frequencyMaxima
+	n := uint64(len(maxima) / 2)
+	for first, last = first+n, last+n; first < last; first, last = first/2, last/2 {
+		if first%2 == 1 {
+			maximum = max(maximum, maxima[first])
+			first++
+		}
+		if last%2 == 1 {
+			last--
+			maximum = max(maximum, maxima[last])
+		}
+	}
+	return maximum
+}
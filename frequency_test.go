@@ -0,0 +1,100 @@
+package dawg
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSuggestWithFrequencies(t *testing.T) {
+	frequencies := map[string]uint64{"the": 50000, "hate": 40, "he": 3000, "hue": 2, "ate": 10}
+	dawg := CreateDAWGWithFrequencies(frequencies)
+
+	if dawg.Frequency("the") != 50000 || dawg.Frequency("hue") != 2 || dawg.Frequency("th") != 0 {
+		t.Error("Frequency failed")
+	}
+
+	suggestions := dawg.Suggest("hte", 3)
+	if len(suggestions) != 3 || suggestions[0].Word != "he" || suggestions[1].Word != "hate" || suggestions[2].Word != "ate" {
+		t.Error("Suggest with frequencies failed")
+	}
+
+	// Without frequencies, the words needing as many edits are sorted lexicographically
+	suggestions = CreateDAWG([]string{"the", "hate", "he", "hue", "ate"}).Suggest("hte", 3)
+	if len(suggestions) != 3 || suggestions[0].Word != "ate" || suggestions[1].Word != "hate" {
+		t.Error("Suggest without frequencies failed")
+	}
+
+	// A closer word comes first, however rare
+	suggestions = dawg.Suggest("hue", 2)
+	if len(suggestions) != 2 || suggestions[0].Word != "hue" || suggestions[1].Word != "he" {
+		t.Error("Suggest of a rare word failed")
+	}
+}
+
+func TestFrequenciesAddRemove(t *testing.T) {
+	dawg := CreateDAWGWithFrequencies(map[string]uint64{"a": 1, "c": 3, "e": 5})
+	dawg.Add("b")
+	dawg.Add("f")
+	if !dawg.Remove("c") {
+		t.Error("Remove failed")
+	}
+	if dawg.Frequency("a") != 1 || dawg.Frequency("b") != 0 || dawg.Frequency("c") != 0 || dawg.Frequency("e") != 5 || dawg.Frequency("f") != 0 {
+		t.Error("Frequencies after Add and Remove failed")
+	}
+	if CreateDAWG([]string{"a"}).Frequency("a") != 0 {
+		t.Error("Frequency without frequencies failed")
+	}
+}
+
+func TestTopCompletions(t *testing.T) {
+	dawg := CreateDAWGWithFrequencies(map[string]uint64{"the": 50000, "then": 800, "there": 1200, "these": 1200, "theory": 30, "tea": 900})
+
+	if top := dawg.TopCompletions("the", 3); !slices.Equal(top, []string{"the", "there", "these"}) {
+		t.Error("TopCompletions failed", top)
+	}
+	if top := dawg.TopCompletions("then", 0); !slices.Equal(top, []string{"then"}) {
+		t.Error("TopCompletions of a word failed", top)
+	}
+	if top := dawg.TopCompletions("x", 3); len(top) != 0 {
+		t.Error("TopCompletions of a missing prefix failed", top)
+	}
+	// The frequencies of the new words are 0
+	dawg.Add("theme")
+	dawg.Remove("the")
+	if top := dawg.TopCompletions("the", 0); !slices.Equal(top, []string{"there", "these", "then", "theory", "theme"}) {
+		t.Error("TopCompletions after changes failed", top)
+	}
+	if top := CreateDAWG([]string{"the", "then", "tea"}).TopCompletions("th", 1); !slices.Equal(top, []string{"the"}) {
+		t.Error("TopCompletions without frequencies failed", top)
+	}
+
+	// Compare with all the completions sorted by frequency
+	r := rand.New(rand.NewSource(5))
+	frequencies := make(map[string]uint64)
+	for i := 0; i < 500; i++ {
+		word := make([]rune, 1+r.Intn(6))
+		for j := range word {
+			word[j] = rune('a' + r.Intn(4))
+		}
+		frequencies[string(word)] = uint64(r.Intn(20))
+	}
+	dawg = CreateDAWGWithFrequencies(frequencies)
+	for _, prefix := range []string{"", "a", "bc", "dda"} {
+		expected := dawg.Completions(prefix, 0)
+		slices.SortStableFunc(expected, func(a string, b string) int {
+			return cmp.Compare(frequencies[b], frequencies[a])
+		})
+		for _, k := range []int{1, 5, 50, 0} {
+			want := expected
+			if k > 0 && k < len(expected) {
+				want = expected[:k]
+			}
+			if top := dawg.TopCompletions(prefix, k); !slices.Equal(top, want) {
+				t.Error("TopCompletions failed for", prefix, k, strings.Join(top, " "))
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package dawg
+
+// Separator between the reversed prefix and the suffix in the paths of a GADDAG
+const GADDAGSeparator = '◇'
+
+// GADDAG is the structure described by Steven Gordon to generate Scrabble moves.
+// For each word w and each split w = u.v (u not empty), the GADDAG contains the path
+// reverse(u) ◇ v, so a word can be built from any of its letters, to the left then to the right.
+// The path reverse(w) has no separator, as nothing can follow it.
+type GADDAG struct {
+	dawg *DAWG
+}
+
+// Create a new GADDAG from an array of words.
+// The paths are added to a trie, compressed like the one of a DAWG.
+func CreateGADDAG(words []string) *GADDAG {
+	builder := newBuilder(BuildOptions{})
+	for i, word := range words {
+		for _, path := range gaddagPaths([]rune(word)) {
+			builder.add(path, i+1) // Can't fail without options
+		}
+	}
+	return &GADDAG{dawg: builder.dawg()}
+}
+
+// Get the paths of the word in a GADDAG
+func gaddagPaths(word []rune) []string {
+	paths := make([]string, 0, len(word))
+	path := make([]rune, 0, len(word)+1)
+	for i := 1; i <= len(word); i++ {
+		path = path[:0]
+		for j := i - 1; j >= 0; j-- {
+			path = append(path, word[j])
+		}
+		if i < len(word) {
+			path = append(path, GADDAGSeparator)
+			path = append(path, word[i:]...)
+		}
+		paths = append(paths, string(path))
+	}
+	return paths
+}
+
+// Get a cursor on the initial state of the GADDAG, to walk its paths.
+// The letters before the anchor are followed in reverse order, then GADDAGSeparator,
+// then the letters after the anchor.
+func (gaddag *GADDAG) Root() Cursor {
+	return gaddag.dawg.Root()
+}
+
+// Check if the word is in the GADDAG
+func (gaddag *GADDAG) Contains(word string) bool {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return gaddag.dawg.initialState.final
+	}
+	curState := gaddag.dawg.prefixState(reverse(runes))
+	return curState != nil && curState.final
+}
+
+// Get the letters that can be added before the word (front hooks) and after the word (back hooks)
+// to form another word. The word itself doesn't have to be in the GADDAG.
+func (gaddag *GADDAG) Hooks(word string) (front []rune, back []rune) {
+	runes := []rune(word)
+	if len(runes) == 0 {
+		return
+	}
+	curState := gaddag.dawg.prefixState(reverse(runes))
+	if curState == nil {
+		return
+	}
+	for _, curLetter := range curState.letters {
+		if curLetter.char != GADDAGSeparator && curLetter.state.final {
+			// reverse(c.word) is the path of c.word without separator
+			front = append(front, curLetter.char)
+		}
+	}
+	if separator := curState.getletter(GADDAGSeparator); separator != nil {
+		for _, curLetter := range separator.state.letters {
+			if curLetter.state.final {
+				back = append(back, curLetter.char)
+			}
+		}
+	}
+	return
+}
+
+// Get the letters c for which before.c.after is a word, sorted.
+// This is the cross-check of a square of the board, between the tiles before and after it.
+func (gaddag *GADDAG) CrossCheck(before string, after string) []rune {
+	reversedBefore := reverse([]rune(before))
+	var letters []rune
+	for _, curLetter := range gaddag.dawg.initialState.letters {
+		if curLetter.char == GADDAGSeparator {
+			continue
+		}
+		// The path of before.c.after split after c is c.reverse(before) ◇ after
+		curState := curLetter.state.follow(reversedBefore)
+		if curState != nil && after != "" {
+			if separator := curState.getletter(GADDAGSeparator); separator != nil {
+				curState = separator.state.follow(after)
+			} else {
+				curState = nil
+			}
+		}
+		if curState != nil && curState.final {
+			letters = append(letters, curLetter.char)
+		}
+	}
+	return letters
+}
+
+// Get the string of the runes in reverse order
+func reverse(runes []rune) string {
+	reversed := make([]rune, len(runes))
+	for i, char := range runes {
+		reversed[len(runes)-1-i] = char
+	}
+	return string(reversed)
+}
@@ -0,0 +1,46 @@
+package dawg
+
+import "testing"
+
+func TestGADDAG(t *testing.T) {
+	gaddag := CreateGADDAG([]string{"care", "cares", "scare", "car", "bar", "bare"})
+
+	for _, word := range []string{"care", "cares", "scare", "car", "bar", "bare"} {
+		if !gaddag.Contains(word) {
+			t.Error("Contains failed for", word)
+		}
+	}
+	if gaddag.Contains("ca") || gaddag.Contains("are") {
+		t.Error("Contains failed")
+	}
+
+	// Anchor on 'r' of "care": reversed prefix "rac", then the separator and the suffix "e"
+	cursor, ok := gaddag.Root(), true
+	for _, char := range "rac" + string(GADDAGSeparator) + "e" {
+		if cursor, ok = cursor.Advance(char); !ok {
+			t.Fatal("Root failed")
+		}
+	}
+	if !cursor.IsFinal() {
+		t.Error("Root failed")
+	}
+
+	front, back := gaddag.Hooks("care")
+	if string(front) != "s" || string(back) != "s" {
+		t.Error("Hooks failed:", string(front), string(back))
+	}
+	front, back = gaddag.Hooks("ar")
+	if string(front) != "bc" || len(back) != 0 {
+		t.Error("Hooks failed:", string(front), string(back))
+	}
+
+	if letters := gaddag.CrossCheck("ca", "e"); string(letters) != "r" {
+		t.Error("CrossCheck failed:", string(letters))
+	}
+	if letters := gaddag.CrossCheck("", "ar"); string(letters) != "bc" {
+		t.Error("CrossCheck failed:", string(letters))
+	}
+	if letters := gaddag.CrossCheck("car", ""); string(letters) != "e" {
+		t.Error("CrossCheck failed:", string(letters))
+	}
+}
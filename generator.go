@@ -0,0 +1,102 @@
+package dawg
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// Maximum number of attempts to generate a pseudo-word before giving up
+const maxGenerateAttempts = 1000
+
+// Rune marking the end of a word in the continuations of a context
+const endOfWord rune = -1
+
+// Generator produces pronounceable pseudo-words: words which look like the words of a DAWG,
+// but are not in it.
+// Each letter is chosen according to the letters following the same context (the order
+// previous letters) in the words of the DAWG.
+type Generator struct {
+	dawg          *DAWG
+	order         int
+	continuations map[string]*continuations
+}
+
+// Letters following a context, with the number of times they do
+type continuations struct {
+	chars  []rune
+	counts []int
+	total  int
+}
+
+// Create a new pseudo-word generator, choosing each letter from the order previous ones.
+// All the words of the DAWG are read to count the continuations of each context.
+func (dawg *DAWG) NewGenerator(order int) *Generator {
+	generator := &Generator{dawg: dawg, order: order, continuations: make(map[string]*continuations)}
+	walkSorted(dawg.initialState, nil, func(word []rune) bool {
+		for i := 0; i <= len(word); i++ {
+			char := endOfWord
+			if i < len(word) {
+				char = word[i]
+			}
+			generator.observe(word[max(0, i-order):i], char)
+		}
+		return true
+	})
+	return generator
+}
+
+// Count one more occurrence of char after context
+func (generator *Generator) observe(context []rune, char rune) {
+	c, ok := generator.continuations[string(context)]
+	if !ok {
+		c = &continuations{}
+		generator.continuations[string(context)] = c
+	}
+	c.total++
+	for i := range c.chars {
+		if c.chars[i] == char {
+			c.counts[i]++
+			return
+		}
+	}
+	c.chars = append(c.chars, char)
+	c.counts = append(c.counts, 1)
+}
+
+// Generate a pseudo-word of minLength to maxLength letters, which is not in the DAWG
+func (generator *Generator) Generate(r *rand.Rand, minLength int, maxLength int) (string, error) {
+ATTEMPTS:
+	for attempt := 0; attempt < maxGenerateAttempts; attempt++ {
+		var word []rune
+		for {
+			c, ok := generator.continuations[string(word[max(0, len(word)-generator.order):])]
+			if !ok {
+				continue ATTEMPTS
+			}
+			char := c.pick(r)
+			if char == endOfWord {
+				break
+			}
+			if len(word) == maxLength {
+				continue ATTEMPTS
+			}
+			word = append(word, char)
+		}
+		if len(word) >= minLength && !generator.dawg.Check(string(word)) {
+			return string(word), nil
+		}
+	}
+	return "", errors.New("Unable to generate a word which is not in the DAWG.")
+}
+
+// Pick a letter with a probability proportional to its count
+func (c *continuations) pick(r *rand.Rand) rune {
+	n := r.Intn(c.total)
+	for i, count := range c.counts {
+		if n < count {
+			return c.chars[i]
+		}
+		n -= count
+	}
+	return endOfWord
+}
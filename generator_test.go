@@ -0,0 +1,23 @@
+package dawg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestGenerator(t *testing.T) {
+	dawg := CreateDAWG([]string{"banana", "bandana", "cabana", "canal", "panama", "mana"})
+	generator := dawg.NewGenerator(2)
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 10; i++ {
+		word, err := generator.Generate(r, 4, 8)
+		if err != nil || len(word) < 4 || len(word) > 8 || dawg.Check(word) {
+			t.Error("Generate failed")
+		}
+	}
+
+	if _, err := CreateDAWG([]string{"a"}).NewGenerator(1).Generate(r, 1, 5); err == nil {
+		t.Error("Generate should fail when all the possible words are in the DAWG")
+	}
+}
@@ -0,0 +1,239 @@
+package dawg
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Returned by graphemeMatcher.visit when the search is stopped by SearchOptions.Stop
+var errSearchStopped = errors.New("Search stopped.")
+
+// Zero width joiner, joining emojis into a single grapheme ("👩‍💻")
+const zeroWidthJoiner = '\u200d'
+
+// Split the word into grapheme clusters, the letters as seen by a reader ("é" written with a combining accent,
+// "👩‍💻" or "🇫🇷" are a single grapheme each). The segmentation follows the main rules of the extended grapheme
+// clusters of Unicode (UAX #29): the combining marks, zero width joiner sequences, emoji modifiers, tags and
+// pairs of regional indicators are kept with the letter before them.
+func Graphemes(word string) []string {
+	graphemes := []string{}
+	var segmenter graphemeSegmenter
+	start := 0
+	for i, char := range word {
+		if !segmenter.next(char) && i > 0 {
+			graphemes = append(graphemes, word[start:i])
+			start = i
+		}
+	}
+	if start < len(word) {
+		graphemes = append(graphemes, word[start:])
+	}
+	return graphemes
+}
+
+// A graphemeSegmenter finds the boundaries of the graphemes of a word, letter after letter
+type graphemeSegmenter struct {
+	previous           rune
+	regionalIndicators int // Number of regional indicators at the end of the current grapheme
+}
+
+// Check if the letter following the previous letters continues their last grapheme
+func (segmenter *graphemeSegmenter) next(char rune) bool {
+	previous := segmenter.previous
+	segmenter.previous = char
+	isRegionalIndicator := char >= 0x1f1e6 && char <= 0x1f1ff
+	switch {
+	case previous == 0:
+	case unicode.Is(unicode.Mark, char) || char == zeroWidthJoiner,
+		char >= 0x1f3fb && char <= 0x1f3ff, // Emoji modifiers (skin tones)
+		char >= 0xe0020 && char <= 0xe007f: // Tags (flags of regions)
+		return true
+	case previous == zeroWidthJoiner:
+		segmenter.regionalIndicators = 0
+		return true
+	case isRegionalIndicator && segmenter.regionalIndicators == 1: // The second letter of a flag
+		segmenter.regionalIndicators++
+		return true
+	}
+	segmenter.regionalIndicators = 0
+	if isRegionalIndicator {
+		segmenter.regionalIndicators = 1
+	}
+	return false
+}
+
+// A graphemeMatcher searches the words of a DAWG close to a query, the edits being counted on graphemes
+// instead of letters. The DAWG is walked depth-first, with a row of the edit distances between the graphemes
+// of the query and each grapheme of the word found: the last grapheme is only known when the next letter
+// starts a new grapheme, or at the end of the word.
+type graphemeMatcher struct {
+	dawg      *DAWG
+	options   SearchOptions
+	query     []string
+	querySize int // Number of bytes of the query
+	word      []rune
+	rows      [][]int  // Edit distances after each grapheme of the word, rows[0] being for no grapheme
+	previous  []string // The graphemes of the word, for the transpositions
+	matches   []Match
+	steps     int
+}
+
+// Search the words of the DAWG at most at options.Distance of the word, counting the edits on graphemes,
+// and call fn for each of them, sorted by distance then lexicographically
+func (dawg *DAWG) searchGraphemes(ctx context.Context, word string, options SearchOptions, fn func(match Match) bool) error {
+	matcher := &graphemeMatcher{dawg: dawg, options: options, query: Graphemes(word), querySize: len(word)}
+	firstRow := make([]int, len(matcher.query)+1)
+	for i := range firstRow {
+		firstRow[i] = matcher.deletions(i)
+	}
+	matcher.rows = [][]int{firstRow}
+	best := firstRow[len(matcher.query)]
+	err := matcher.visit(ctx, dawg.initialState, 0, graphemeSegmenter{}, best)
+	if err == errSearchStopped {
+		err = nil
+	} else if err != nil && context.Cause(ctx) != errTruncated {
+		return err
+	} else if err != nil {
+		err = errTruncated // The words found before the end of the budget are given
+	}
+	slices.SortFunc(matcher.matches, func(a Match, b Match) int {
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		return options.compareWords(a.Word, b.Word)
+	})
+	for i, match := range matcher.matches {
+		if !fn(match) || options.MaxResults > 0 && i+1 >= options.MaxResults {
+			break
+		}
+	}
+	return err
+}
+
+// Get the number of bytes of the shortest prefix of the word at the distance of the query, in prefix mode
+func (matcher *graphemeMatcher) matchedPrefix(distance int) int {
+	size := 0
+	for i, row := range matcher.rows {
+		if row[len(matcher.query)] == distance {
+			break
+		}
+		if i < len(matcher.previous) {
+			size += len(matcher.previous[i])
+		}
+	}
+	return size
+}
+
+// Cost of deleting the first graphemes of the query
+func (matcher *graphemeMatcher) deletions(count int) int {
+	if count > 0 && (!matcher.options.AllowDelete || matcher.options.ExactPrefix > 0) {
+		return matcher.options.Distance + 1
+	}
+	return count
+}
+
+// Walk the words under the state, whose first letters are in the word buffer up to depth, the last grapheme
+// starting at start. In prefix mode, best is the lowest distance between the query and the prefixes of the word.
+func (matcher *graphemeMatcher) visit(ctx context.Context, curState *state, start int, segmenter graphemeSegmenter, best int) error {
+	if matcher.steps++; matcher.steps%contextCheckInterval == 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	depth, inheritedBest := len(matcher.word), best
+	if matcher.options.MaxLength > 0 && depth > matcher.options.MaxLength {
+		return nil
+	}
+	if depth > start { // The last grapheme is complete if the word ends or the next letter starts a new grapheme
+		row := matcher.nextRow(string(matcher.word[start:]))
+		matcher.rows = append(matcher.rows, row)
+		matcher.previous = append(matcher.previous, string(matcher.word[start:]))
+		defer func() {
+			matcher.rows = matcher.rows[:len(matcher.rows)-1]
+			matcher.previous = matcher.previous[:len(matcher.previous)-1]
+		}()
+		best = min(best, row[len(matcher.query)])
+	}
+	row := matcher.rows[len(matcher.rows)-1]
+	distance := row[len(matcher.query)]
+	if matcher.options.Prefix {
+		distance = best
+	}
+	if curState.final && distance <= matcher.options.Distance && depth >= matcher.options.MinLength {
+		match := newMatch(string(matcher.word), distance, matcher.querySize)
+		if matcher.options.Prefix {
+			match.Matched = matcher.matchedPrefix(distance)
+		}
+		if options := matcher.options; !options.filtersFlags() || allowedFlags(matcher.dawg.Flags(match.Word), options.RequireFlags, options.ExcludeFlags) {
+			matcher.matches = append(matcher.matches, match)
+			if options.Stop != nil && options.Stop(match) {
+				return errSearchStopped
+			}
+		}
+	}
+
+	for _, curLetter := range curState.letters {
+		matcher.word = append(matcher.word, curLetter.char)
+		if filter := matcher.options.Filter; filter != nil && !filter(string(matcher.word)) {
+			matcher.word = matcher.word[:depth]
+			continue
+		}
+		letterSegmenter := segmenter
+		var err error
+		if letterSegmenter.next(curLetter.char) {
+			// The letter continues the last grapheme, which wasn't complete
+			matcher.rows = matcher.rows[:len(matcher.rows)-1]
+			matcher.previous = matcher.previous[:len(matcher.previous)-1]
+			err = matcher.visit(ctx, curLetter.state, start, letterSegmenter, inheritedBest)
+			matcher.rows = append(matcher.rows, row)
+			matcher.previous = append(matcher.previous, string(matcher.word[start:depth]))
+		} else if slices.Min(row) <= matcher.options.Distance || matcher.options.Prefix && best <= matcher.options.Distance {
+			err = matcher.visit(ctx, curLetter.state, depth, letterSegmenter, best)
+		}
+		matcher.word = matcher.word[:depth]
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compute the edit distances between the prefixes of the query and the word, after adding a grapheme to the word
+func (matcher *graphemeMatcher) nextRow(grapheme string) []int {
+	options := matcher.options
+	previousRow := matcher.rows[len(matcher.rows)-1]
+	row := make([]int, len(previousRow))
+	row[0] = options.Distance + 1 // Inserting the grapheme into the word
+	if options.AllowAdd && options.ExactPrefix == 0 {
+		row[0] = previousRow[0] + 1
+	}
+	for i := 1; i < len(row); i++ {
+		row[i] = options.Distance + 1
+		if i > options.ExactPrefix { // Substitution
+			row[i] = previousRow[i-1] + 1
+		}
+		if matcher.sameGrapheme(grapheme, matcher.query[i-1]) {
+			row[i] = previousRow[i-1]
+		}
+		if options.AllowAdd && i >= options.ExactPrefix {
+			row[i] = min(row[i], previousRow[i]+1)
+		}
+		if options.AllowDelete && i > options.ExactPrefix {
+			row[i] = min(row[i], row[i-1]+1)
+		}
+		if options.Transpose && i > options.ExactPrefix+1 && len(matcher.rows) > 1 && len(matcher.previous) > 0 &&
+			matcher.sameGrapheme(grapheme, matcher.query[i-2]) && matcher.sameGrapheme(matcher.previous[len(matcher.previous)-1], matcher.query[i-1]) {
+			row[i] = min(row[i], matcher.rows[len(matcher.rows)-2][i-2]+1)
+		}
+		row[i] = min(row[i], options.Distance+1) // Any distance above the maximum is as bad
+	}
+	return row
+}
+
+// Check if a grapheme of the word matches a grapheme of the query
+func (matcher *graphemeMatcher) sameGrapheme(a string, b string) bool {
+	return a == b || matcher.options.IgnoreCase && strings.EqualFold(a, b)
+}
@@ -0,0 +1,73 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGraphemes(t *testing.T) {
+	for word, expected := range map[string][]string{
+		"":           {},
+		"abc":        {"a", "b", "c"},
+		"cafe\u0301": {"c", "a", "f", "e\u0301"},
+		"👩\u200d💻!":  {"👩\u200d💻", "!"},
+		"👍🏽👍":        {"👍🏽", "👍"},
+		"🇫🇷🇩🇪🇮":      {"🇫🇷", "🇩🇪", "🇮"},
+		"\u0301a":    {"\u0301", "a"},
+		"🏴\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f": {"🏴\U000e0067\U000e0062\U000e0065\U000e006e\U000e0067\U000e007f"},
+	} {
+		if graphemes := Graphemes(word); !slices.Equal(graphemes, expected) {
+			t.Errorf("Graphemes failed for %q: %q", word, graphemes)
+		}
+	}
+}
+
+func TestSearchGraphemes(t *testing.T) {
+	dawg := CreateDAWG([]string{"cafe\u0301", "cafes", "caf\u00e9", "👩\u200d💻", "👩\u200d🔬x", "ab", "ba"})
+
+	// One substitution of "e" by "\u00e9" with a combining accent, instead of an insertion
+	matches, _ := dawg.SearchWithOptions("cafe", SearchOptions{Distance: 1, Graphemes: true})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"cafe\u0301", 1}, {"caf\u00e9", 1}}) {
+		t.Error("SearchWithOptions with Graphemes failed:", matches)
+	}
+	if matches, _ = dawg.SearchWithOptions("cafe", SearchOptions{Distance: 1}); !slices.Equal(wordDistances(matches), []wordDistance{{"caf\u00e9", 1}}) {
+		t.Error("SearchWithOptions without Graphemes failed:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("👩\u200d🔬", SearchOptions{Distance: 1, AllowAdd: true, Graphemes: true})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"👩\u200d💻", 1}, {"👩\u200d🔬x", 1}}) {
+		t.Error("SearchWithOptions with Graphemes failed on emojis:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("ba", SearchOptions{Distance: 1, Transpose: true, Graphemes: true, MaxResults: 1})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"ba", 0}}) {
+		t.Error("SearchWithOptions with Graphemes failed with MaxResults:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("ba", SearchOptions{Distance: 1, Transpose: true, Graphemes: true, MinLength: 2, MaxLength: 2})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"ba", 0}, {"ab", 1}}) {
+		t.Error("SearchWithOptions with Graphemes failed with a transposition:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("caf", SearchOptions{Distance: 0, Prefix: true, Graphemes: true})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"cafes", 0}, {"cafe\u0301", 0}, {"caf\u00e9", 0}}) {
+		t.Error("SearchWithOptions with Graphemes failed with a prefix:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("CAF\u00c9", SearchOptions{Distance: 0, IgnoreCase: true, Graphemes: true, AllowDelete: true})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"caf\u00e9", 0}}) {
+		t.Error("SearchWithOptions with Graphemes failed with IgnoreCase:", matches)
+	}
+
+	// Same results as a search on letters without combining letters
+	words := []string{"test", "tests", "rest", "nest", "note", "tset", "te", "ttest"}
+	dawg = CreateDAWG(words)
+	for _, options := range []SearchOptions{
+		{Distance: 2},
+		{Distance: 1, AllowAdd: true},
+		{Distance: 2, AllowDelete: true, Transpose: true},
+		{Distance: 2, AllowAdd: true, AllowDelete: true, Transpose: true},
+		{Distance: 1, AllowAdd: true, AllowDelete: true, Prefix: true},
+	} {
+		expected, _ := dawg.SearchWithOptions("tset", options)
+		options.Graphemes = true
+		if matches, _ = dawg.SearchWithOptions("tset", options); !slices.Equal(matches, expected) {
+			t.Error("SearchWithOptions with Graphemes failed:", options, matches, expected)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package grpcserver serves the queries of a DAWG over gRPC, with the Dictionary service of proto/dawg.proto
+// (generated in the dawgpb package):
+//
+//	server := grpc.NewServer()
+//	dawgpb.RegisterDictionaryServer(server, grpcserver.New(d))
+//
+// The incorrect requests (a distance or a number of results above the maximum of the server) fail with the code
+// InvalidArgument, and Random fails with the code NotFound when no random word can be drawn.
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/ftbe/dawg"
+	"github.com/ftbe/dawg/proto/dawgpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Default maximum distance of the searches, and maximum number of words of the responses
+const (
+	DefaultMaxDistance = 3
+	DefaultMaxResults  = 1000
+)
+
+// Server serves the queries of a DAWG
+type Server struct {
+	dawgpb.UnimplementedDictionaryServer
+	dawg func() *dawg.DAWG // Get the DAWG queried
+
+	// Limits of the requests, to bound the work of a single request (set them before serving)
+	MaxDistance int // Maximum distance of the searches
+	MaxResults  int // Maximum number of words of the responses, also given when the request doesn't limit them
+}
+
+// Create a server of the queries of the DAWG
+func New(d *dawg.DAWG) *Server {
+	return newServer(func() *dawg.DAWG { return d })
+}
+
+// Create a server of the queries of the DAWG of the handle, each request using the DAWG loaded at its start
+func NewWithHandle(handle *dawg.Handle) *Server {
+	return newServer(handle.Load)
+}
+
+func newServer(load func() *dawg.DAWG) *Server {
+	return &Server{dawg: load, MaxDistance: DefaultMaxDistance, MaxResults: DefaultMaxResults}
+}
+
+// Check if a word is in the dictionary
+func (server *Server) Contains(ctx context.Context, request *dawgpb.ContainsRequest) (*dawgpb.ContainsResponse, error) {
+	return &dawgpb.ContainsResponse{Contains: server.dawg().Contains(request.GetWord())}, nil
+}
+
+// Get the words close to a word, by distance then lexicographically
+func (server *Server) Search(ctx context.Context, request *dawgpb.SearchRequest) (*dawgpb.SearchResponse, error) {
+	if request.GetDistance() > uint32(server.MaxDistance) {
+		return nil, status.Error(codes.InvalidArgument, "Incorrect parameter: distance.")
+	}
+	maxResults, err := server.maxResults(request.GetMaxResults())
+	if err != nil {
+		return nil, err
+	}
+	options := dawg.SearchOptions{
+		Distance:    int(request.GetDistance()),
+		MaxResults:  maxResults,
+		AllowAdd:    request.GetAllowAdd(),
+		AllowDelete: request.GetAllowDelete(),
+		Transpose:   request.GetTranspose(),
+		Prefix:      request.GetPrefix(),
+		MinLength:   int(request.GetMinLength()),
+		MaxLength:   int(request.GetMaxLength()),
+	}
+	found, err := server.dawg().SearchContext(ctx, request.GetWord(), options)
+	if err != nil {
+		return nil, status.FromContextError(err).Err()
+	}
+	response := &dawgpb.SearchResponse{Matches: make([]*dawgpb.Match, len(found))}
+	for i, match := range found {
+		response.Matches[i] = &dawgpb.Match{Word: match.Word, Distance: uint32(match.Distance)}
+	}
+	return response, nil
+}
+
+// Get the words starting with a prefix, lexicographically
+func (server *Server) Complete(ctx context.Context, request *dawgpb.CompleteRequest) (*dawgpb.CompleteResponse, error) {
+	maxResults, err := server.maxResults(request.GetMaxResults())
+	if err != nil {
+		return nil, err
+	}
+	return &dawgpb.CompleteResponse{Words: server.dawg().Completions(request.GetPrefix(), maxResults)}, nil
+}
+
+// Draw a random word
+func (server *Server) Random(ctx context.Context, request *dawgpb.RandomRequest) (*dawgpb.RandomResponse, error) {
+	var word string
+	var err error
+	if request.GetLength() > 0 {
+		word, err = server.dawg().FindRandomWordWithPrefix(request.GetPrefix(), int(request.GetLength()))
+	} else {
+		word, err = server.dawg().RandomPath(request.GetPrefix())
+	}
+	if err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &dawgpb.RandomResponse{Word: word}, nil
+}
+
+// Get the maximum number of words of a response, from the maximum of the request (0 for the maximum of the server)
+func (server *Server) maxResults(requested uint32) (int, error) {
+	if requested > uint32(server.MaxResults) {
+		return 0, status.Error(codes.InvalidArgument, "Incorrect parameter: max_results.")
+	}
+	if requested == 0 {
+		return server.MaxResults, nil
+	}
+	return int(requested), nil
+}
@@ -0,0 +1,86 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	"github.com/ftbe/dawg"
+	"github.com/ftbe/dawg/proto/dawgpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// Serve the server in memory, and get a client connected to it
+func connect(t *testing.T, server *Server) dawgpb.DictionaryClient {
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	dawgpb.RegisterDictionaryServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	dial := func(ctx context.Context, address string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}
+	conn, err := grpc.NewClient("passthrough:///bufnet", grpc.WithContextDialer(dial), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return dawgpb.NewDictionaryClient(conn)
+}
+
+func TestServer(t *testing.T) {
+	client := connect(t, New(dawg.CreateDAWG([]string{"test", "tests", "nest", "note"})))
+	ctx := context.Background()
+
+	if contains, err := client.Contains(ctx, &dawgpb.ContainsRequest{Word: "test"}); err != nil || !contains.GetContains() {
+		t.Error("Contains failed", err)
+	}
+	if contains, err := client.Contains(ctx, &dawgpb.ContainsRequest{Word: "tes"}); err != nil || contains.GetContains() {
+		t.Error("Contains of a missing word failed", err)
+	}
+
+	search, err := client.Search(ctx, &dawgpb.SearchRequest{Word: "tesst", Distance: 1, AllowDelete: true, Transpose: true})
+	if err != nil || len(search.GetMatches()) != 2 || search.GetMatches()[0].GetWord() != "test" || search.GetMatches()[1].GetWord() != "tests" || search.GetMatches()[1].GetDistance() != 1 {
+		t.Error("Search failed", search, err)
+	}
+	if search, err = client.Search(ctx, &dawgpb.SearchRequest{Word: "te", Prefix: true, MaxResults: 1}); err != nil || len(search.GetMatches()) != 1 || search.GetMatches()[0].GetWord() != "test" {
+		t.Error("Search of a prefix failed", search, err)
+	}
+
+	if complete, err := client.Complete(ctx, &dawgpb.CompleteRequest{Prefix: "n"}); err != nil || !slices.Equal(complete.GetWords(), []string{"nest", "note"}) {
+		t.Error("Complete failed", complete, err)
+	}
+	if complete, err := client.Complete(ctx, &dawgpb.CompleteRequest{Prefix: "t", MaxResults: 1}); err != nil || !slices.Equal(complete.GetWords(), []string{"test"}) {
+		t.Error("Complete with a maximum failed", complete, err)
+	}
+
+	if random, err := client.Random(ctx, &dawgpb.RandomRequest{Prefix: "no", Length: 4}); err != nil || random.GetWord() != "note" {
+		t.Error("Random failed", random, err)
+	}
+	if _, err := client.Random(ctx, &dawgpb.RandomRequest{Prefix: "x"}); status.Code(err) != codes.NotFound {
+		t.Error("Random of a missing prefix failed", err)
+	}
+}
+
+func TestServerLimits(t *testing.T) {
+	server := New(dawg.CreateDAWG([]string{"test", "tests", "nest", "note"}))
+	server.MaxDistance, server.MaxResults = 1, 3
+	client := connect(t, server)
+	ctx := context.Background()
+
+	if _, err := client.Search(ctx, &dawgpb.SearchRequest{Word: "test", Distance: 2}); status.Code(err) != codes.InvalidArgument {
+		t.Error("Search above the maximum distance failed", err)
+	}
+	if _, err := client.Search(ctx, &dawgpb.SearchRequest{Word: "test", MaxResults: 4}); status.Code(err) != codes.InvalidArgument {
+		t.Error("Search above the maximum number of results failed", err)
+	}
+	if complete, err := client.Complete(ctx, &dawgpb.CompleteRequest{}); err != nil || len(complete.GetWords()) != 3 {
+		t.Error("Complete without a maximum failed", complete, err)
+	}
+}
@@ -0,0 +1,88 @@
+package dawg
+
+import (
+	"context"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// Handle holds the current version of a DAWG which is rebuilt in the background, such as a dictionary
+// reloaded when its file changes. The searches Load the current DAWG without any lock, and a new DAWG is
+// swapped in atomically with Store, the searches in progress keeping the previous one.
+type Handle struct {
+	current atomic.Pointer[DAWG]
+}
+
+// Create a handle on the DAWG
+func NewHandle(dawg *DAWG) *Handle {
+	handle := &Handle{}
+	handle.current.Store(dawg)
+	return handle
+}
+
+// Get the current DAWG. It must not be changed (with Add or Remove).
+func (handle *Handle) Load() *DAWG {
+	return handle.current.Load()
+}
+
+// Replace the current DAWG
+func (handle *Handle) Store(dawg *DAWG) {
+	handle.current.Store(dawg)
+}
+
+// Load the DAWG of the file with load, then reload it in a new goroutine each time the file changes (its
+// modification time or its size), until the context is done. The error of the first load is returned, without
+// starting the goroutine. The file is checked every interval: if it can't be read or loaded, onError is called
+// (if not nil, and the context isn't done), the current DAWG is kept, and the load is retried at the next check.
+//
+//	if err := handle.ReloadFromFile(ctx, "words.dawg", time.Minute, dawg.LoadDAWG, nil); err != nil {
+//		// Do something
+//	}
+func (handle *Handle) ReloadFromFile(ctx context.Context, fileName string, interval time.Duration, load func(fileName string) (*DAWG, error), onError func(err error)) error {
+	last, err := os.Stat(fileName) // Of the file loaded
+	if err != nil {
+		return err
+	}
+	dawg, err := load(fileName)
+	if err != nil {
+		return err
+	}
+	handle.Store(dawg)
+	go handle.reload(ctx, fileName, last, interval, load, onError)
+	return nil
+}
+
+// Reload the DAWG each time the file changes from the last file loaded, until the context is done
+func (handle *Handle) reload(ctx context.Context, fileName string, last os.FileInfo, interval time.Duration, load func(fileName string) (*DAWG, error), onError func(err error)) {
+	report := func(err error) {
+		// The errors once the context is done are expected (the file being removed at shutdown for example)
+		if onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		info, err := os.Stat(fileName)
+		if err != nil {
+			report(err)
+			continue
+		}
+		if info.ModTime().Equal(last.ModTime()) && info.Size() == last.Size() {
+			continue
+		}
+		dawg, err := load(fileName)
+		if err != nil {
+			report(err)
+			continue // The file is still different from the last file loaded: retried at the next check
+		}
+		last = info
+		handle.Store(dawg)
+	}
+}
@@ -0,0 +1,60 @@
+package dawg
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHandle(t *testing.T) {
+	first, second := CreateDAWG([]string{"first"}), CreateDAWG([]string{"second"})
+	handle := NewHandle(first)
+	if handle.Load() != first {
+		t.Error("Load failed")
+	}
+	handle.Store(second)
+	if handle.Load() != second {
+		t.Error("Store failed")
+	}
+}
+
+func TestHandleReloadFromFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "words.txt")
+	if err := os.WriteFile(fileName, []byte("old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	handle := NewHandle(CreateDAWG(nil))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err := handle.ReloadFromFile(ctx, fileName, time.Millisecond, CreateDAWGFromFile, func(err error) {
+		t.Error("ReloadFromFile failed:", err)
+	})
+	// The first load is done before returning
+	if err != nil || !handle.Load().Contains("old") {
+		t.Fatal("ReloadFromFile failed:", err)
+	}
+
+	waitFor := func(word string) {
+		for deadline := time.Now().Add(5 * time.Second); !handle.Load().Contains(word); {
+			if time.Now().After(deadline) {
+				t.Fatal("ReloadFromFile didn't load the file")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+	// A different size, whatever the precision of the modification times
+	if err := os.WriteFile(fileName, []byte("new\nnewer\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	waitFor("newer")
+	if handle.Load().Contains("old") || !handle.Load().Contains("new") {
+		t.Error("ReloadFromFile failed")
+	}
+
+	// The error of the first load
+	if err := handle.ReloadFromFile(ctx, filepath.Join(t.TempDir(), "missing.txt"), time.Millisecond, CreateDAWGFromFile, nil); err == nil {
+		t.Error("ReloadFromFile of a missing file failed")
+	}
+}
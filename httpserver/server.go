@@ -0,0 +1,206 @@
+// Package httpserver serves the queries of a DAWG over HTTP, with JSON responses:
+//
+//	GET /contains?word=w                                       {"word": "w", "contains": true}
+//	GET /search?word=w&distance=1&max=20&add=1&delete=1&transpose=1  {"matches": [{"word": "w", "distance": 0}]}
+//	GET /complete?prefix=p&max=20&page=token                   {"words": ["p", "pa"], "next": "token"}
+//	GET /random?prefix=p&length=5                              {"word": "pasta"}
+//
+// /complete gives the token of the next page of words in "next" when there are more words (see DAWG.CompletionsPage).
+// The errors are returned as {"error": "message"}, with the status 400 for an incorrect query (a distance or a max
+// above the maximum of the server included) and 404 when no random word can be drawn.
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ftbe/dawg"
+)
+
+// Default maximum number of words of the responses
+const defaultMax = 20
+
+// Default limits of the queries of a Server
+const (
+	DefaultMaxDistance = 3
+	DefaultMaxResults  = 1000
+)
+
+// Server serves the queries of a DAWG
+type Server struct {
+	dawg func() *dawg.DAWG // Get the DAWG queried
+	mux  *http.ServeMux
+
+	// Limits of the queries, to bound the work of a single request (set them before serving)
+	MaxDistance int // Maximum distance of /search
+	MaxResults  int // Maximum max of /search and /complete, also used when max is 0 or less (no limit)
+}
+
+// Create a server of the queries of the DAWG
+func New(d *dawg.DAWG) *Server {
+	return newServer(func() *dawg.DAWG { return d })
+}
+
+// Create a server of the queries of the DAWG of the handle, each request using the DAWG loaded at its start
+func NewWithHandle(handle *dawg.Handle) *Server {
+	return newServer(handle.Load)
+}
+
+func newServer(load func() *dawg.DAWG) *Server {
+	server := &Server{dawg: load, mux: http.NewServeMux(), MaxDistance: DefaultMaxDistance, MaxResults: DefaultMaxResults}
+	server.mux.HandleFunc("/contains", server.contains)
+	server.mux.HandleFunc("/search", server.search)
+	server.mux.HandleFunc("/complete", server.complete)
+	server.mux.HandleFunc("/random", server.random)
+	return server
+}
+
+func (server *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mux.ServeHTTP(w, r)
+}
+
+// Match is a word found by /search
+type Match struct {
+	Word     string `json:"word"`
+	Distance int    `json:"distance"`
+}
+
+func (server *Server) contains(w http.ResponseWriter, r *http.Request) {
+	word := r.URL.Query().Get("word")
+	writeJSON(w, http.StatusOK, map[string]any{"word": word, "contains": server.dawg().Contains(word)})
+}
+
+func (server *Server) search(w http.ResponseWriter, r *http.Request) {
+	query := parser{values: r.URL.Query()}
+	options := dawg.SearchOptions{
+		Distance:    query.intAtMost("distance", 1, server.MaxDistance),
+		MaxResults:  server.max(&query),
+		AllowAdd:    query.bool("add"),
+		AllowDelete: query.bool("delete"),
+		Transpose:   query.bool("transpose"),
+	}
+	if query.err != nil {
+		writeError(w, http.StatusBadRequest, query.err)
+		return
+	}
+	found, err := server.dawg().SearchContext(r.Context(), query.values.Get("word"), options)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	matches := make([]Match, len(found))
+	for i, match := range found {
+		matches[i] = Match{Word: match.Word, Distance: match.Distance}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"matches": matches})
+}
+
+func (server *Server) complete(w http.ResponseWriter, r *http.Request) {
+	query := parser{values: r.URL.Query()}
+	max := server.max(&query)
+	if query.err != nil {
+		writeError(w, http.StatusBadRequest, query.err)
+		return
+	}
+	words, next, err := server.dawg().CompletionsPage(query.values.Get("prefix"), query.values.Get("page"), max)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, &paramError{name: "page"})
+		return
+	}
+	response := map[string]any{"words": words}
+	if next != "" {
+		response["next"] = next
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (server *Server) random(w http.ResponseWriter, r *http.Request) {
+	query := parser{values: r.URL.Query()}
+	length := query.int("length", 0)
+	if query.err != nil {
+		writeError(w, http.StatusBadRequest, query.err)
+		return
+	}
+	var word string
+	var err error
+	if length > 0 {
+		word, err = server.dawg().FindRandomWordWithPrefix(query.values.Get("prefix"), length)
+	} else {
+		word, err = server.dawg().RandomPath(query.values.Get("prefix"))
+	}
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"word": word})
+}
+
+// A parser of the parameters of a query, keeping the first error
+type parser struct {
+	values url.Values
+	err    error
+}
+
+// Get the integer parameter, or the default value if it is missing
+func (query *parser) int(name string, defaultValue int) int {
+	values := query.values[name]
+	if len(values) == 0 || values[0] == "" {
+		return defaultValue
+	}
+	value, err := strconv.Atoi(values[0])
+	if err != nil && query.err == nil {
+		query.err = &paramError{name: name}
+	}
+	return value
+}
+
+// Get the integer parameter, or the default value if it is missing. A value above the limit is an error.
+func (query *parser) intAtMost(name string, defaultValue int, limit int) int {
+	value := query.int(name, defaultValue)
+	if value > limit && query.err == nil {
+		query.err = &paramError{name: name}
+	}
+	return value
+}
+
+// Get the max parameter: the maximum number of words of the response, at most MaxResults
+func (server *Server) max(query *parser) int {
+	if max := query.intAtMost("max", defaultMax, server.MaxResults); max > 0 {
+		return max
+	}
+	return server.MaxResults
+}
+
+// Get the boolean parameter, false if it is missing
+func (query *parser) bool(name string) bool {
+	values := query.values[name]
+	if len(values) == 0 || values[0] == "" {
+		return false
+	}
+	value, err := strconv.ParseBool(values[0])
+	if err != nil && query.err == nil {
+		query.err = &paramError{name: name}
+	}
+	return value
+}
+
+// paramError is returned for a parameter with an incorrect value
+type paramError struct {
+	name string
+}
+
+func (err *paramError) Error() string {
+	return "Incorrect parameter: " + err.name + "."
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,93 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/ftbe/dawg"
+)
+
+// Get the status and the decoded JSON response of the request
+func get(t *testing.T, server http.Handler, url string, response any) int {
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest("GET", url, nil))
+	if err := json.Unmarshal(recorder.Body.Bytes(), response); err != nil {
+		t.Fatal("Incorrect JSON response:", recorder.Body.String())
+	}
+	return recorder.Code
+}
+
+func TestServer(t *testing.T) {
+	server := New(dawg.CreateDAWG([]string{"test", "tests", "nest", "note"}))
+
+	var contains struct {
+		Word     string
+		Contains bool
+	}
+	if status := get(t, server, "/contains?word=test", &contains); status != http.StatusOK || !contains.Contains || contains.Word != "test" {
+		t.Error("/contains failed")
+	}
+
+	var search struct{ Matches []Match }
+	if status := get(t, server, "/search?word=tesst&distance=1&delete=true&transpose=1", &search); status != http.StatusOK ||
+		!slices.Equal(search.Matches, []Match{{"test", 1}, {"tests", 1}}) {
+		t.Error("/search failed:", search.Matches)
+	}
+
+	var complete struct {
+		Words []string
+		Next  string
+	}
+	if status := get(t, server, "/complete?prefix=te&max=1", &complete); status != http.StatusOK || !slices.Equal(complete.Words, []string{"test"}) {
+		t.Error("/complete failed:", complete.Words)
+	}
+	next := complete.Next
+	complete.Next = ""
+	if status := get(t, server, "/complete?prefix=te&max=1&page="+next, &complete); status != http.StatusOK || !slices.Equal(complete.Words, []string{"tests"}) || complete.Next != "" {
+		t.Error("/complete next page failed:", complete.Words)
+	}
+
+	var random struct{ Word string }
+	if status := get(t, server, "/random?prefix=n&length=4", &random); status != http.StatusOK || (random.Word != "nest" && random.Word != "note") {
+		t.Error("/random failed:", random.Word)
+	}
+
+	var failure struct{ Error string }
+	if status := get(t, server, "/random?prefix=x", &failure); status != http.StatusNotFound || failure.Error == "" {
+		t.Error("/random should fail without word")
+	}
+	if status := get(t, server, "/search?word=test&distance=x", &failure); status != http.StatusBadRequest || failure.Error != "Incorrect parameter: distance." {
+		t.Error("/search should fail with an incorrect parameter")
+	}
+}
+
+func TestServerLimits(t *testing.T) {
+	server := New(dawg.CreateDAWG([]string{"test", "tests", "nest", "note"}))
+	server.MaxDistance, server.MaxResults = 1, 3
+
+	var failure struct{ Error string }
+	if status := get(t, server, "/search?word=test&distance=50", &failure); status != http.StatusBadRequest || failure.Error != "Incorrect parameter: distance." {
+		t.Error("/search above the maximum distance failed", failure.Error)
+	}
+	if status := get(t, server, "/search?word=test&max=4", &failure); status != http.StatusBadRequest || failure.Error != "Incorrect parameter: max." {
+		t.Error("/search above the maximum number of results failed", failure.Error)
+	}
+	var complete struct{ Words []string }
+	if status := get(t, server, "/complete?prefix=&max=0", &complete); status != http.StatusOK || len(complete.Words) != 3 {
+		t.Error("/complete without a maximum failed", complete.Words)
+	}
+}
+
+func TestServerWithHandle(t *testing.T) {
+	handle := dawg.NewHandle(dawg.CreateDAWG([]string{"old"}))
+	server := NewWithHandle(handle)
+	handle.Store(dawg.CreateDAWG([]string{"new"}))
+
+	var contains struct{ Contains bool }
+	if get(t, server, "/contains?word=new", &contains); !contains.Contains {
+		t.Error("Server with a handle failed")
+	}
+}
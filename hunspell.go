@@ -0,0 +1,73 @@
+package dawg
+
+import (
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ErrNoWordCount is returned (wrapped in a LineError) when a Hunspell dictionary doesn't start with its number of words
+var ErrNoWordCount = errors.New("Missing word count.")
+
+// AffixExpander gets the forms of a stem of a Hunspell dictionary, from the affix flags of the stem
+// (the part of the line after the '/', empty if the stem has no flags), using the rules of the .aff file.
+// The forms must include the stem itself, unless it is not a word without affixes.
+type AffixExpander func(stem string, flags string) []string
+
+// Create a new DAWG by reading the words of a Hunspell dictionary (.dic file) from r.
+// The first line is the number of words, then each line holds a stem, optionally followed by '/' and
+// its affix flags, then by morphological fields (separated by a space or a tab, they are ignored).
+// Lines starting with a tab are comments. A '/' in a stem is escaped as "\/".
+// If expand is nil the stems are added, otherwise the forms returned by expand for each stem are added.
+func CreateDAWGFromHunspell(r io.Reader, expand AffixExpander, options BuildOptions) (dawg *DAWG, err error) {
+	builder := newAdaptiveBuilder(options)
+	err = scanLines(r, options, func(text string, line int) error {
+		if line == 1 {
+			if _, err := strconv.Atoi(strings.TrimSpace(text)); err != nil {
+				return &LineError{Line: line, Err: ErrNoWordCount}
+			}
+			return nil
+		}
+		if strings.HasPrefix(text, "\t") {
+			return nil
+		}
+		stem, flags := parseHunspellLine(text)
+		if stem == "" {
+			return nil
+		}
+		if expand == nil {
+			return builder.add(stem, line)
+		}
+		for _, form := range expand(stem, flags) {
+			if err := builder.add(form, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	return builder.dawg(), nil
+}
+
+// Get the stem and the affix flags of a line of a Hunspell dictionary
+func parseHunspellLine(text string) (stem string, flags string) {
+	if end := strings.IndexAny(text, " \t"); end >= 0 {
+		text = text[:end]
+	}
+	var builder strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && i+1 < len(text) && text[i+1] == '/':
+			builder.WriteByte('/')
+			i++
+		case text[i] == '/':
+			return builder.String(), text[i+1:]
+		default:
+			builder.WriteByte(text[i])
+		}
+	}
+	return builder.String(), ""
+}
@@ -0,0 +1,45 @@
+package dawg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const hunspellDictionary = `5
+walk/SDG
+talk/SG
+and/1/2 po:conj
+	a comment
+km\/h
+cat`
+
+func TestCreateDAWGFromHunspell(t *testing.T) {
+	dawg, err := CreateDAWGFromHunspell(strings.NewReader(hunspellDictionary), nil, BuildOptions{})
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"walk", "talk", "and", "km/h", "cat"})) {
+		t.Error("Hunspell stems failed")
+	}
+
+	// Suffix flags: S -> s, D -> ed, G -> ing
+	suffixes := map[rune]string{'S': "s", 'D': "ed", 'G': "ing"}
+	expand := func(stem string, flags string) []string {
+		forms := []string{stem}
+		for _, flag := range flags {
+			if suffix, ok := suffixes[flag]; ok {
+				forms = append(forms, stem+suffix)
+			}
+		}
+		return forms
+	}
+	dawg, err = CreateDAWGFromHunspell(strings.NewReader(hunspellDictionary), expand, BuildOptions{})
+	expected := []string{"walk", "walks", "walked", "walking", "talk", "talks", "talking", "and", "km/h", "cat"}
+	if err != nil || !dawg.Equal(CreateDAWG(expected)) {
+		t.Error("Hunspell affix expansion failed")
+	}
+
+	_, err = CreateDAWGFromHunspell(strings.NewReader("walk/S\ntalk\n"), nil, BuildOptions{})
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 1 || !errors.Is(err, ErrNoWordCount) {
+		t.Error("Hunspell word count failed")
+	}
+}
@@ -0,0 +1,81 @@
+package dawg
+
+// Get the index of the word among the words of the DAWG sorted in lexicographic order.
+// The indexes go from 0 to the number of words minus one, so the DAWG is a minimal perfect hash of its words:
+// values can be stored in a slice at the index of their word. ok is false if the word isn't in the DAWG.
+func (dawg *DAWG) Index(word string) (index uint, ok bool) {
+	index, curState := dawg.rank(word)
+	if curState == nil || !curState.final {
+		return 0, false
+	}
+	return index, true
+}
+
+// Get the number of words of the DAWG lower than the prefix in lexicographic order (so the index of the first
+// word starting with the prefix), and the state reached by the prefix (nil if no word starts with it)
+func (dawg *DAWG) rank(prefix string) (index uint, curState *state) {
+	curState = dawg.initialState
+	for _, char := range prefix {
+		// The words lower than the prefix: the prefix itself, and the words after lower letters
+		if curState.final {
+			index++
+		}
+		var next *state
+		for _, curLetter := range curState.letters {
+			if curLetter.char < char {
+				index += uint(curLetter.state.wordsCount)
+			} else if curLetter.char == char {
+				next = curLetter.state
+			}
+		}
+		if next == nil {
+			return 0, nil
+		}
+		curState = next
+	}
+	return index, curState
+}
+
+// Same as Index for a word of the DAWG of the initial state, given as runes
+func runesIndex(initialState *state, word []rune) (index uint) {
+	curState := initialState
+	for _, char := range word {
+		if curState.final {
+			index++
+		}
+		for _, curLetter := range curState.letters {
+			if curLetter.char >= char {
+				curState = curLetter.state
+				break
+			}
+			index += uint(curLetter.state.wordsCount)
+		}
+	}
+	return index
+}
+
+// Get the word at the index among the words of the DAWG sorted in lexicographic order (the reverse of Index).
+// ok is false if the index is not lower than the number of words.
+func (dawg *DAWG) WordAt(index uint) (word string, ok bool) {
+	curState := dawg.initialState
+	if uint64(index) >= curState.wordsCount {
+		return "", false
+	}
+	var runes []rune
+	for {
+		if curState.final {
+			if index == 0 {
+				return string(runes), true
+			}
+			index--
+		}
+		for _, curLetter := range curState.letters {
+			if uint64(index) < curLetter.state.wordsCount {
+				runes = append(runes, curLetter.char)
+				curState = curLetter.state
+				break
+			}
+			index -= uint(curLetter.state.wordsCount)
+		}
+	}
+}
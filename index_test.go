@@ -0,0 +1,42 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestIndex(t *testing.T) {
+	words := []string{"", "nest", "note", "notes", "tes", "tese", "test", "test2", "日本"}
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "日本", "notes", ""})
+
+	for i, word := range words {
+		if index, ok := dawg.Index(word); !ok || index != uint(i) {
+			t.Error("Index failed for", word, index)
+		}
+		if word2, ok := dawg.WordAt(uint(i)); !ok || word2 != word {
+			t.Error("WordAt failed for", i, word2)
+		}
+	}
+	if _, ok := dawg.Index("te"); ok {
+		t.Error("Index should fail for a prefix")
+	}
+	if _, ok := dawg.Index("tests"); ok {
+		t.Error("Index should fail for a missing word")
+	}
+	if _, ok := dawg.WordAt(uint(len(words))); ok {
+		t.Error("WordAt should fail out of range")
+	}
+
+	// The indexes follow the changes of the words
+	dawg.Add("nesting")
+	dawg.Remove("tes")
+	words = slices.Collect(dawg.Words())
+	for i, word := range words {
+		if index, ok := dawg.Index(word); !ok || index != uint(i) {
+			t.Error("Index failed after Add/Remove for", word, index)
+		}
+		if word2, ok := dawg.WordAt(uint(i)); !ok || word2 != word {
+			t.Error("WordAt failed after Add/Remove for", i, word2)
+		}
+	}
+}
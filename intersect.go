@@ -0,0 +1,96 @@
+package dawg
+
+import "sort"
+
+// WordPair is a pair of close words, one from each DAWG
+type WordPair struct {
+	A        string
+	B        string
+	Distance int // Levenshtein distance between A and B
+}
+
+// Find the pairs of words (one from a, one from b) within levenshteinDistance of each other,
+// sorted by A then B.
+// Both DAWGs are traversed simultaneously, each step of the traversal consuming a letter
+// of a, a letter of b, or both, so the words are never enumerated.
+func FuzzyIntersect(a *DAWG, b *DAWG, levenshteinDistance int) []WordPair {
+	intersection := &fuzzyIntersection{
+		maxDistance: levenshteinDistance,
+		visited:     make(map[visitedPrefixes]int),
+		pairs:       make(map[[2]string]int),
+	}
+	intersection.walk(a.initialState, b.initialState, nil, nil, 0, noEdit)
+
+	pairs := make([]WordPair, 0, len(intersection.pairs))
+	for words, distance := range intersection.pairs {
+		pairs = append(pairs, WordPair{A: words[0], B: words[1], Distance: distance})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].A < pairs[j].A || pairs[i].A == pairs[j].A && pairs[i].B < pairs[j].B
+	})
+	return pairs
+}
+
+// Last edit done while walking the product of the DAWGs
+type edit int
+
+const (
+	noEdit edit = iota
+	insertEdit
+	deleteEdit
+)
+
+// State of the simultaneous traversal of two DAWGs
+type fuzzyIntersection struct {
+	maxDistance int
+	visited     map[visitedPrefixes]int // Lowest distance found for each pair of prefixes
+	pairs       map[[2]string]int       // Pairs of words found, with their distance
+}
+
+// Pair of prefixes reached during the traversal, with the edit used to reach them
+type visitedPrefixes struct {
+	prefixes [2]string
+	lastEdit edit
+}
+
+// Walk from the states stateA and stateB, reached by prefixA and prefixB with the given distance
+func (intersection *fuzzyIntersection) walk(stateA *state, stateB *state, prefixA []rune, prefixB []rune, distance int, lastEdit edit) {
+	key := [2]string{string(prefixA), string(prefixB)}
+	visited := visitedPrefixes{prefixes: key, lastEdit: lastEdit}
+	if previous, ok := intersection.visited[visited]; ok && previous <= distance {
+		return
+	}
+	intersection.visited[visited] = distance
+	if stateA.final && stateB.final {
+		if previous, ok := intersection.pairs[key]; !ok || distance < previous {
+			intersection.pairs[key] = distance
+		}
+	}
+
+	for _, letterA := range stateA.letters {
+		if letterB := stateB.getletter(letterA.char); letterB != nil {
+			intersection.walk(letterA.state, letterB.state, append(prefixA, letterA.char), append(prefixB, letterB.char), distance, noEdit)
+		}
+	}
+	if distance == intersection.maxDistance {
+		return
+	}
+	for _, letterA := range stateA.letters {
+		for _, letterB := range stateB.letters {
+			if letterA.char != letterB.char { // Substitution
+				intersection.walk(letterA.state, letterB.state, append(prefixA, letterA.char), append(prefixB, letterB.char), distance+1, noEdit)
+			}
+		}
+	}
+	// An insertion directly followed by a deletion (or the opposite) is never better than a substitution
+	if lastEdit != insertEdit {
+		for _, letterA := range stateA.letters {
+			intersection.walk(letterA.state, stateB, append(prefixA, letterA.char), prefixB, distance+1, deleteEdit)
+		}
+	}
+	if lastEdit != deleteEdit {
+		for _, letterB := range stateB.letters {
+			intersection.walk(stateA, letterB.state, prefixA, append(prefixB, letterB.char), distance+1, insertEdit)
+		}
+	}
+}
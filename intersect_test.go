@@ -0,0 +1,19 @@
+package dawg
+
+import "testing"
+
+func TestFuzzyIntersect(t *testing.T) {
+	ocr := CreateDAWG([]string{"tbe", "cat", "hous", "zzzz"})
+	lexicon := CreateDAWG([]string{"the", "cat", "cart", "house"})
+
+	pairs := FuzzyIntersect(ocr, lexicon, 1)
+	expected := []WordPair{{"cat", "cart", 1}, {"cat", "cat", 0}, {"hous", "house", 1}, {"tbe", "the", 1}}
+	if len(pairs) != len(expected) {
+		t.Fatal("FuzzyIntersect failed")
+	}
+	for i := range pairs {
+		if pairs[i] != expected[i] {
+			t.Error("FuzzyIntersect failed")
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package dawg
+
+import (
+	"encoding/json"
+	"errors"
+	"unicode/utf8"
+)
+
+// JSON representation of a DAWG: its nodes, numbered as in the binary format (breadth-first from
+// the initial state, following the letters in rune order), so the same words always give the same JSON
+type jsonDAWG struct {
+	Nodes []jsonNode `json:"nodes"`
+}
+
+type jsonNode struct {
+	ID    uint32     `json:"id"`
+	Final bool       `json:"final"`
+	Edges []jsonEdge `json:"edges"` // Sorted by rune
+}
+
+type jsonEdge struct {
+	Rune string `json:"rune"`
+	To   uint32 `json:"to"`
+}
+
+// Encode the DAWG in JSON: {"nodes": [{"id": 0, "final": false, "edges": [{"rune": "a", "to": 1}]}, ...]},
+// the node 0 being the initial state (implements json.Marshaler)
+func (dawg *DAWG) MarshalJSON() ([]byte, error) {
+	states, numbers := numberStates(dawg.initialState)
+	encoded := jsonDAWG{Nodes: make([]jsonNode, len(states))}
+	for i, curState := range states {
+		node := jsonNode{ID: uint32(i), Final: curState.final, Edges: make([]jsonEdge, 0, len(curState.letters))}
+		for _, curLetter := range curState.letters {
+			node.Edges = append(node.Edges, jsonEdge{Rune: string(curLetter.char), To: numbers[curLetter.state]})
+		}
+		encoded.Nodes[i] = node
+	}
+	return json.Marshal(encoded)
+}
+
+// Decode a DAWG encoded by MarshalJSON, replacing the content of this DAWG (implements json.Unmarshaler)
+func (dawg *DAWG) UnmarshalJSON(data []byte) error {
+	var decoded jsonDAWG
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if len(decoded.Nodes) == 0 {
+		return errors.New("Incorrect JSON format : no initial state.")
+	}
+
+	states := make([]state, len(decoded.Nodes))
+	for i, node := range decoded.Nodes {
+		if node.ID != uint32(i) {
+			return errors.New("Incorrect JSON format : nodes not numbered in order.")
+		}
+		states[i].final = node.Final
+		stateLetters := make([]*letter, 0, len(node.Edges))
+		for j, edge := range node.Edges {
+			char, size := utf8.DecodeRuneInString(edge.Rune)
+			if edge.Rune == "" || size != len(edge.Rune) {
+				return errors.New("Incorrect JSON format : an edge must have a single rune.")
+			}
+			if edge.To >= uint32(len(states)) {
+				return errors.New("Incorrect JSON format : node out of range.")
+			}
+			if j > 0 && char <= stateLetters[j-1].char {
+				return errors.New("Incorrect JSON format : edges not sorted.")
+			}
+			stateLetters = append(stateLetters, &letter{char: char, state: &states[edge.To]})
+		}
+		states[i].letters = stateLetters
+	}
+	initialState := &states[0]
+	if _, found := findCycle(initialState, nil, make(map[*state]bool)); found {
+		return errors.New("Incorrect JSON format : cycle.")
+	}
+
+	countWords(initialState, make(map[*state]bool))
+	dawg.initialState = initialState
+	dawg.nodesCount = uint64(len(states))
+	dawg.trieNodesCount = 0
+	dawg.maxWordSize = longestWord(initialState, make(map[*state]int))
+	dawg.mutable = nil
+	dawg.frequencies, dawg.maxFrequency, dawg.flags = nil, 0, nil
+	dawg.resetCaches()
+	return nil
+}
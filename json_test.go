@@ -0,0 +1,39 @@
+package dawg
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	dawg := CreateDAWG([]string{"ab", "b", "été"})
+
+	data, err := json.Marshal(dawg)
+	expected := `{"nodes":[{"id":0,"final":false,"edges":[{"rune":"a","to":1},{"rune":"b","to":2},{"rune":"é","to":3}]},` +
+		`{"id":1,"final":false,"edges":[{"rune":"b","to":2}]},{"id":2,"final":true,"edges":[]},` +
+		`{"id":3,"final":false,"edges":[{"rune":"t","to":4}]},{"id":4,"final":false,"edges":[{"rune":"é","to":2}]}]}`
+	if err != nil || string(data) != expected {
+		t.Fatal("MarshalJSON failed:", string(data))
+	}
+
+	decoded := &DAWG{}
+	if err = json.Unmarshal(data, decoded); err != nil || !slices.Equal(slices.Collect(decoded.Words()), []string{"ab", "b", "été"}) ||
+		decoded.NodesCount() != dawg.NodesCount() || decoded.LongestWordLen() != 3 {
+		t.Error("UnmarshalJSON failed:", err)
+	}
+
+	for _, incorrect := range []string{
+		`{"nodes":[]}`,
+		`{"nodes":[{"id":1,"final":true}]}`,
+		`{"nodes":[{"id":0,"edges":[{"rune":"ab","to":0}]}]}`,
+		`{"nodes":[{"id":0,"edges":[{"rune":"a","to":1}]}]}`,
+		`{"nodes":[{"id":0,"edges":[{"rune":"b","to":1},{"rune":"a","to":1}]},{"id":1,"final":true}]}`,
+		`{"nodes":[{"id":0,"edges":[{"rune":"a","to":1}]},{"id":1,"edges":[{"rune":"b","to":0}]}]}`,
+	} {
+		if err := json.Unmarshal([]byte(incorrect), &DAWG{}); err == nil || !strings.HasPrefix(err.Error(), "Incorrect JSON format") {
+			t.Error("UnmarshalJSON should fail:", incorrect, err)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package dawg
+
+import "unicode"
+
+// KeyboardLayout describes which keys are next to each other on a keyboard, to model typos
+type KeyboardLayout struct {
+	Name     string
+	adjacent map[rune][]rune
+}
+
+// Common keyboard layouts
+var (
+	QWERTY = NewKeyboardLayout("QWERTY", []string{"1234567890", "qwertyuiop", "asdfghjkl", "zxcvbnm"})
+	AZERTY = NewKeyboardLayout("AZERTY", []string{"azertyuiop", "qsdfghjklm", "wxcvbn"})
+	QWERTZ = NewKeyboardLayout("QWERTZ", []string{"1234567890", "qwertzuiop", "asdfghjkl", "yxcvbnm"})
+)
+
+// Create a new keyboard layout from its rows of keys, top row first.
+// Each row is assumed to be shifted by half a key to the right compared to the row above it,
+// so a key is adjacent to its left and right neighbours, to the two keys above it and to the two keys below it.
+func NewKeyboardLayout(name string, rows []string) *KeyboardLayout {
+	layout := &KeyboardLayout{Name: name, adjacent: make(map[rune][]rune)}
+	keys := make([][]rune, len(rows))
+	for i, row := range rows {
+		keys[i] = []rune(row)
+	}
+	for i, row := range keys {
+		for j, key := range row {
+			neighbours := []struct{ row, col int }{
+				{i, j - 1}, {i, j + 1}, // Same row
+				{i - 1, j}, {i - 1, j + 1}, // Row above
+				{i + 1, j - 1}, {i + 1, j}, // Row below
+			}
+			for _, n := range neighbours {
+				if n.row >= 0 && n.row < len(keys) && n.col >= 0 && n.col < len(keys[n.row]) {
+					layout.adjacent[key] = append(layout.adjacent[key], keys[n.row][n.col])
+				}
+			}
+		}
+	}
+	return layout
+}
+
+// Check if the keys of the two letters are next to each other (case insensitive)
+func (layout *KeyboardLayout) Adjacent(a rune, b rune) bool {
+	a, b = unicode.ToLower(a), unicode.ToLower(b)
+	for _, key := range layout.adjacent[a] {
+		if key == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,311 @@
+package dawg
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// LazyOptions configures the nodes of a LazyDAWG read when it is opened and kept in memory
+type LazyOptions struct {
+	EagerDepth int // Number of levels of nodes read when the DAWG is opened, under the initial state (always read)
+	CacheSize  int // Maximum number of the other nodes kept in memory once read (0 for no limit)
+}
+
+// LazyDAWG is a read-only DAWG reading a file saved by Save on demand: only the initial state and the first
+// levels of nodes under it (LazyOptions.EagerDepth) are read when it is opened, the other nodes being read from
+// the file on their first access and kept in a cache. Opening it is quick whatever the size of the DAWG, for
+// the programs which only follow a few words. When the cache is full, it is emptied (the first levels are kept).
+// A LazyDAWG is safe for concurrent use, until it is closed.
+type LazyDAWG struct {
+	file       io.ReaderAt
+	closer     io.Closer // Closes the file (nil if not opened by OpenLazyDAWG)
+	size       int64     // Size of the DAWG in the file
+	nodesCount uint32
+	edgesCount uint32
+	eager      []lazyNode // The nodes of the first levels, numbered in breadth-first order
+
+	lock      sync.Mutex
+	cache     map[uint32]*lazyNode
+	cacheSize int
+	reads     uint64 // Number of nodes read on demand
+}
+
+// A node of a LazyDAWG, with its edges sorted by rune
+type lazyNode struct {
+	final   bool
+	chars   []rune
+	targets []uint32
+}
+
+// Open a file saved by Save, reading its first levels of nodes (see LazyDAWG).
+// The header of the file is checked (see ReadDAWG for the errors), but not its checksum, which would read
+// the whole file: call VerifyChecksum for that.
+func OpenLazyDAWG(fileName string, options LazyOptions) (*LazyDAWG, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	dawg, err := NewLazyDAWG(file, info.Size(), options)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	dawg.closer = file
+	return dawg, nil
+}
+
+// Get a read-only DAWG reading on demand the size bytes of a DAWG written by WriteTo (or Save) from r
+// (see LazyDAWG). As for OpenLazyDAWG, the checksum is not checked.
+func NewLazyDAWG(r io.ReaderAt, size int64, options LazyOptions) (*LazyDAWG, error) {
+	if size < binaryHeaderSize {
+		return nil, errors.New("Incorrect binary format : file too short.")
+	}
+	header := make([]byte, binaryHeaderSize)
+	if err := readAt(r, header, 0); err != nil {
+		return nil, err
+	}
+	nodesCount, edgesCount, err := parseBinaryHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	dawgSize := binaryHeaderSize + binaryNodeSize*int64(nodesCount) + binaryEdgeSize*int64(edgesCount) + binaryFooterSize
+	if dawgSize > size {
+		return nil, errors.New("Incorrect binary format : file too short.")
+	}
+	dawg := &LazyDAWG{file: r, size: dawgSize, nodesCount: nodesCount, edgesCount: edgesCount, cache: make(map[uint32]*lazyNode), cacheSize: options.CacheSize}
+
+	// The nodes being numbered in breadth-first order, the nodes of a level follow the nodes of the previous levels
+	end := uint32(1)
+	for depth := 0; depth <= max(options.EagerDepth, 0) && len(dawg.eager) < int(end); depth++ {
+		nodes, err := dawg.readNodes(uint32(len(dawg.eager)), end)
+		if err != nil {
+			return nil, err
+		}
+		for _, node := range nodes {
+			for _, target := range node.targets {
+				end = max(end, target+1)
+			}
+		}
+		dawg.eager = append(dawg.eager, nodes...)
+	}
+	return dawg, nil
+}
+
+// Read the nodes first to last (excluded) and their edges from the file
+func (dawg *LazyDAWG) readNodes(first uint32, last uint32) ([]lazyNode, error) {
+	headers := make([]byte, binaryNodeSize*int(last-first))
+	if err := readAt(dawg.file, headers, binaryHeaderSize+binaryNodeSize*int64(first)); err != nil {
+		return nil, err
+	}
+	// The edges of the nodes are read at once, from the first edge of the nodes to their last edge
+	firstEdge, lastEdge := uint64(dawg.edgesCount), uint64(0)
+	for i := 0; i < len(headers); i += binaryNodeSize {
+		edge := uint64(binary.LittleEndian.Uint32(headers[i:]))
+		edgesCount := uint64(binary.LittleEndian.Uint32(headers[i+4:]) >> 1)
+		if edge+edgesCount > uint64(dawg.edgesCount) {
+			return nil, errors.New("Incorrect binary format : edge out of range.")
+		}
+		if edgesCount > 0 {
+			firstEdge, lastEdge = min(firstEdge, edge), max(lastEdge, edge+edgesCount)
+		}
+	}
+	var edges []byte
+	if lastEdge > firstEdge {
+		edges = make([]byte, binaryEdgeSize*(lastEdge-firstEdge))
+		edgesStart := binaryHeaderSize + binaryNodeSize*int64(dawg.nodesCount) + binaryEdgeSize*int64(firstEdge)
+		if err := readAt(dawg.file, edges, edgesStart); err != nil {
+			return nil, err
+		}
+	}
+
+	nodes := make([]lazyNode, last-first)
+	for i := range nodes {
+		header := headers[i*binaryNodeSize:]
+		edge := uint64(binary.LittleEndian.Uint32(header[0:]))
+		flags := binary.LittleEndian.Uint32(header[4:])
+		node := &nodes[i]
+		node.final = flags&1 != 0
+		node.chars = make([]rune, flags>>1)
+		node.targets = make([]uint32, flags>>1)
+		for j := range node.chars {
+			data := edges[(edge-firstEdge+uint64(j))*binaryEdgeSize:]
+			node.chars[j] = rune(binary.LittleEndian.Uint32(data[0:]))
+			node.targets[j] = binary.LittleEndian.Uint32(data[4:])
+			if node.targets[j] >= dawg.nodesCount {
+				return nil, errors.New("Incorrect binary format : node out of range.")
+			}
+			if j > 0 && node.chars[j] <= node.chars[j-1] {
+				return nil, errors.New("Incorrect binary format : edges not sorted.")
+			}
+		}
+	}
+	return nodes, nil
+}
+
+// Read len(buffer) bytes of r at the offset (ReaderAt can return io.EOF with all the bytes at the end of the data)
+func readAt(r io.ReaderAt, buffer []byte, offset int64) error {
+	if n, err := r.ReadAt(buffer, offset); n < len(buffer) {
+		return unexpectedEOF(err)
+	}
+	return nil
+}
+
+// Get the node number i, reading it from the file if it isn't in memory
+func (dawg *LazyDAWG) node(i uint32) (*lazyNode, error) {
+	if i < uint32(len(dawg.eager)) {
+		return &dawg.eager[i], nil
+	}
+	dawg.lock.Lock()
+	defer dawg.lock.Unlock()
+	if dawg.file == nil {
+		return nil, errors.New("The DAWG is closed.")
+	}
+	if node, ok := dawg.cache[i]; ok {
+		return node, nil
+	}
+	nodes, err := dawg.readNodes(i, i+1)
+	if err != nil {
+		return nil, err
+	}
+	if dawg.cacheSize > 0 && len(dawg.cache) >= dawg.cacheSize {
+		clear(dawg.cache)
+	}
+	dawg.cache[i] = &nodes[0]
+	dawg.reads++
+	return &nodes[0], nil
+}
+
+// Follow the edge of the given rune from the node (in O(log(n)) time, the edges being sorted)
+func (node *lazyNode) child(char rune) (target uint32, ok bool) {
+	i := sort.Search(len(node.chars), func(i int) bool {
+		return node.chars[i] >= char
+	})
+	if i == len(node.chars) || node.chars[i] != char {
+		return 0, false
+	}
+	return node.targets[i], true
+}
+
+// Get the node reached from the initial state by the prefix, and its number (nil if no word starts with it)
+func (dawg *LazyDAWG) follow(prefix string) (node *lazyNode, number uint32, err error) {
+	node, err = dawg.node(0)
+	for _, char := range prefix {
+		if err != nil {
+			return nil, 0, err
+		}
+		var ok bool
+		if number, ok = node.child(char); !ok {
+			return nil, 0, nil
+		}
+		node, err = dawg.node(number)
+	}
+	return node, number, err
+}
+
+// Check if the word is in the DAWG. The error is the error reading the nodes of the word, if any.
+func (dawg *LazyDAWG) Contains(word string) (bool, error) {
+	node, _, err := dawg.follow(word)
+	return node != nil && node.final, err
+}
+
+// Check if at least one word of the DAWG starts with the prefix
+func (dawg *LazyDAWG) HasPrefix(prefix string) (bool, error) {
+	node, _, err := dawg.follow(prefix)
+	return node != nil, err
+}
+
+// Get the words of the DAWG starting with the prefix, in lexicographic order (at most max words if max > 0).
+// Only the nodes of the words returned are read: as the whole file isn't checked when opened, the cycles of
+// a corrupted file are found while walking them (see ReadDAWG for the errors).
+func (dawg *LazyDAWG) Completions(prefix string, max int) ([]string, error) {
+	completions := []string{}
+	node, number, err := dawg.follow(prefix)
+	if node == nil || err != nil {
+		return completions, err
+	}
+	_, err = dawg.walk(number, node, []rune(prefix), make(map[uint32]bool), func(word []rune) bool {
+		completions = append(completions, string(word))
+		return max <= 0 || len(completions) < max
+	})
+	return completions, err
+}
+
+// Call fn for each word under the node of the given number, in lexicographic order, until it returns false
+// (then return false). The word slice is only valid during the call.
+// path holds the numbers of the nodes leading to the node: an edge back to one of them is a cycle.
+func (dawg *LazyDAWG) walk(number uint32, node *lazyNode, word []rune, path map[uint32]bool, fn func(word []rune) bool) (bool, error) {
+	if node.final && !fn(word) {
+		return false, nil
+	}
+	path[number] = true
+	defer delete(path, number)
+	for i, char := range node.chars {
+		if path[node.targets[i]] {
+			return false, errors.New("Incorrect binary format : cycle.")
+		}
+		next, err := dawg.node(node.targets[i])
+		if err != nil {
+			return false, err
+		}
+		if ok, err := dawg.walk(node.targets[i], next, append(word, char), path, fn); !ok || err != nil {
+			return ok, err
+		}
+	}
+	return true, nil
+}
+
+// Get the number of nodes of the DAWG, read or not
+func (dawg *LazyDAWG) NodesCount() uint64 {
+	return uint64(dawg.nodesCount)
+}
+
+// Get the number of nodes read when the DAWG was opened, and the number of nodes read on demand since then
+// (the nodes read again after the cache was emptied included)
+func (dawg *LazyDAWG) LoadedNodes() (eager uint64, reads uint64) {
+	dawg.lock.Lock()
+	defer dawg.lock.Unlock()
+	return uint64(len(dawg.eager)), dawg.reads
+}
+
+// Check the checksum of the DAWG, reading all its bytes. Return ErrChecksum if they are corrupted.
+func (dawg *LazyDAWG) VerifyChecksum() error {
+	dawg.lock.Lock()
+	defer dawg.lock.Unlock()
+	if dawg.file == nil {
+		return errors.New("The DAWG is closed.")
+	}
+	checksum := crc32.New(checksumTable)
+	if _, err := io.Copy(checksum, io.NewSectionReader(dawg.file, 0, dawg.size-binaryFooterSize)); err != nil {
+		return err
+	}
+	footer := make([]byte, binaryFooterSize)
+	if err := readAt(dawg.file, footer, dawg.size-binaryFooterSize); err != nil {
+		return err
+	}
+	if checksum.Sum32() != binary.LittleEndian.Uint32(footer) {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// Close the file opened by OpenLazyDAWG. The DAWG can't be used anymore afterwards.
+func (dawg *LazyDAWG) Close() error {
+	dawg.lock.Lock()
+	defer dawg.lock.Unlock()
+	dawg.file, dawg.eager = nil, nil
+	clear(dawg.cache)
+	if dawg.closer == nil {
+		return nil
+	}
+	return dawg.closer.Close()
+}
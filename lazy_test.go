@@ -0,0 +1,129 @@
+package dawg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestOpenLazyDAWG(t *testing.T) {
+	words := []string{"test", "tese", "nest", "test2", "tes", "note", "日本"}
+	dawg := CreateDAWG(words)
+	fileName := filepath.Join(t.TempDir(), "words.dawg")
+	if err := dawg.Save(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	lazy, err := OpenLazyDAWG(fileName, LazyOptions{EagerDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lazy.Close()
+	// The initial state and the states of "t", "n" and "日"
+	if eager, reads := lazy.LoadedNodes(); eager != 4 || reads != 0 || lazy.NodesCount() != dawg.NodesCount() {
+		t.Error("OpenLazyDAWG failed", eager, reads)
+	}
+	for _, word := range words {
+		if ok, err := lazy.Contains(word); !ok || err != nil {
+			t.Error("Contains failed", word, err)
+		}
+	}
+	if ok, _ := lazy.Contains("te"); ok {
+		t.Error("Contains of a prefix failed")
+	}
+	if ok, _ := lazy.Contains("tests"); ok {
+		t.Error("Contains of a missing word failed")
+	}
+	if ok, _ := lazy.HasPrefix("no"); !ok {
+		t.Error("HasPrefix failed")
+	}
+	if completions, err := lazy.Completions("te", 0); err != nil || !slices.Equal(completions, dawg.Completions("te", 0)) {
+		t.Error("Completions failed", completions, err)
+	}
+	if completions, _ := lazy.Completions("te", 2); !slices.Equal(completions, []string{"tes", "tese"}) {
+		t.Error("Completions with a maximum failed", completions)
+	}
+	if _, reads := lazy.LoadedNodes(); reads == 0 || reads > uint64(dawg.NodesCount()) {
+		t.Error("LoadedNodes failed", reads)
+	}
+	if lazy.VerifyChecksum() != nil {
+		t.Error("VerifyChecksum failed")
+	}
+	lazy.Close()
+	if _, err := lazy.Contains("test"); err == nil {
+		t.Error("Contains after Close failed")
+	}
+}
+
+func TestNewLazyDAWG(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note"})
+	data, _ := dawg.MarshalBinary()
+
+	// All the nodes read when opened
+	lazy, err := NewLazyDAWG(bytes.NewReader(data), int64(len(data)), LazyOptions{EagerDepth: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if eager, _ := lazy.LoadedNodes(); eager != uint64(dawg.NodesCount()) {
+		t.Error("NewLazyDAWG with all the levels failed", eager)
+	}
+	if completions, _ := lazy.Completions("", 0); !slices.Equal(completions, slices.Collect(dawg.Words())) {
+		t.Error("Completions of all the nodes failed", completions)
+	}
+
+	// The cache is emptied when full
+	lazy, _ = NewLazyDAWG(bytes.NewReader(data), int64(len(data)), LazyOptions{CacheSize: 2})
+	for i := 0; i < 2; i++ {
+		if ok, err := lazy.Contains("test2"); !ok || err != nil {
+			t.Error("Contains with a small cache failed", err)
+		}
+	}
+	if _, reads := lazy.LoadedNodes(); reads != 10 {
+		t.Error("Cache failed", reads)
+	}
+
+	if _, err := NewLazyDAWG(bytes.NewReader(data[:len(data)-1]), int64(len(data)-1), LazyOptions{}); err == nil {
+		t.Error("NewLazyDAWG of a truncated file failed")
+	}
+	corrupted := slices.Clone(data)
+	corrupted[binaryHeaderSize+binaryNodeSize*int(dawg.NodesCount())+7] = 0xff // Target of the first edge, out of range
+	if _, err := NewLazyDAWG(bytes.NewReader(corrupted), int64(len(corrupted)), LazyOptions{}); err == nil {
+		t.Error("NewLazyDAWG of a corrupted file failed")
+	}
+	data[len(data)-binaryFooterSize-binaryEdgeSize]++ // Rune of the last edge
+	lazy, _ = NewLazyDAWG(bytes.NewReader(data), int64(len(data)), LazyOptions{})
+	if lazy.VerifyChecksum() != ErrChecksum {
+		t.Error("VerifyChecksum of a corrupted file failed")
+	}
+}
+
+func TestLazyDAWGCycle(t *testing.T) {
+	// "a" leads to a final node, whose edge "b" goes back to the initial state: "a", "aba", "ababa"...
+	data := appendBinaryHeader(nil, 2, 2)
+	for _, value := range []uint32{0, 1 << 1, 1, 1<<1 | 1, 'a', 1, 'b', 0} {
+		data = binary.LittleEndian.AppendUint32(data, value)
+	}
+	data = binary.LittleEndian.AppendUint32(data, crc32.Checksum(data, checksumTable))
+	fileName := filepath.Join(t.TempDir(), "cycle.dawg")
+	if err := os.WriteFile(fileName, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	lazy, err := OpenLazyDAWG(fileName, LazyOptions{})
+	if err != nil || lazy.VerifyChecksum() != nil {
+		t.Fatal("OpenLazyDAWG of a cycle failed", err)
+	}
+	defer lazy.Close()
+	if ok, err := lazy.Contains("aba"); !ok || err != nil {
+		t.Error("Contains in a cycle failed", err)
+	}
+	for _, prefix := range []string{"", "ab"} {
+		if _, err := lazy.Completions(prefix, 0); err == nil || err.Error() != "Incorrect binary format : cycle." {
+			t.Error("Completions of a cycle failed", prefix, err)
+		}
+	}
+}
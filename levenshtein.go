@@ -0,0 +1,133 @@
+package dawg
+
+// Compute the Levenshtein distance between two words
+func levenshtein(a []rune, b []rune) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		diagonal := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			previous := row[j]
+			row[j] = min(row[j]+1, row[j-1]+1, diagonal+cost)
+			diagonal = previous
+		}
+	}
+	return row[len(b)]
+}
+
+// Costs of the edit operations of the weighted walks
+type editCosts struct {
+	model      CostModel     // Costs of the single letter edits
+	confusions *ConfusionSet // Multi-letter substitutions, may be nil
+}
+
+// Costs of the Levenshtein distance
+var unitCosts = editCosts{model: unitCostModel{}}
+
+// CostModel of the Levenshtein distance, where every edit costs 1
+type unitCostModel struct{}
+
+func (unitCostModel) SubstCost(a rune, b rune) float64 { return 1 }
+func (unitCostModel) InsertCost(r rune) float64        { return 1 }
+func (unitCostModel) DeleteCost(r rune) float64        { return 1 }
+
+// Call fn for each word under initialState whose weighted edit distance to query is at most maxCost.
+// Each word is reported only once. The word slice is only valid during the call.
+// The walk stops as soon as fn returns false.
+func walkWeighted(initialState *state, query []rune, maxCost float64, costs editCosts, fn func(word []rune, cost float64) bool) {
+	walker := &walker{costs: costs}
+	walker.walk(initialState, query, maxCost, fn)
+}
+
+// A walker holds the buffers of the weighted walks, so they can be reused from one walk to the next
+type walker struct {
+	costs   editCosts
+	query   []rune
+	maxCost float64     // Can be lowered during the walk to prune it further
+	rows    [][]float64 // rows[len(word)] is the row of the current word
+	word    []rune
+}
+
+// Call fn for each word under initialState whose weighted edit distance to query is at most the maximum cost.
+// fn may lower walker.maxCost during the walk. See walkWeighted.
+func (walker *walker) walk(initialState *state, query []rune, maxCost float64, fn func(word []rune, cost float64) bool) {
+	if maxCost < 0 {
+		return
+	}
+	walker.query, walker.maxCost, walker.word = query, maxCost, walker.word[:0]
+	row := walker.row(0)
+	row[0] = 0
+	for j := 1; j <= len(query); j++ {
+		row[j] = row[j-1] + walker.costs.model.DeleteCost(query[j-1])
+	}
+	if initialState.final && row[len(query)] <= walker.maxCost {
+		if !fn(nil, row[len(query)]) {
+			return
+		}
+	}
+	walker.walkState(initialState, fn)
+}
+
+// Get the reusable row of the given depth
+func (walker *walker) row(depth int) []float64 {
+	for len(walker.rows) <= depth {
+		walker.rows = append(walker.rows, nil)
+	}
+	if cap(walker.rows[depth]) <= len(walker.query) {
+		walker.rows[depth] = make([]float64, len(walker.query)+1)
+	}
+	walker.rows[depth] = walker.rows[depth][:len(walker.query)+1]
+	return walker.rows[depth]
+}
+
+// Recursive part of walk
+func (walker *walker) walkState(curState *state, fn func(word []rune, cost float64) bool) bool {
+	query, costs, depth := walker.query, walker.costs, len(walker.word)
+	row, nextRow := walker.rows[depth], walker.row(depth+1)
+	for _, curLetter := range curState.letters {
+		walker.word = append(walker.word[:depth], curLetter.char)
+		insertCost := costs.model.InsertCost(curLetter.char)
+		nextRow[0] = row[0] + insertCost
+		for j := 1; j <= len(query); j++ {
+			cost := 0.0
+			if query[j-1] != curLetter.char {
+				cost = costs.model.SubstCost(query[j-1], curLetter.char)
+			}
+			nextRow[j] = min(row[j]+insertCost, nextRow[j-1]+costs.model.DeleteCost(query[j-1]), row[j-1]+cost)
+		}
+		if costs.confusions != nil {
+			costs.confusions.updateRow(query, walker.word, walker.rows, nextRow)
+			for j := 1; j <= len(query); j++ {
+				nextRow[j] = min(nextRow[j], nextRow[j-1]+costs.model.DeleteCost(query[j-1]))
+			}
+		}
+		rowMin := nextRow[0]
+		for _, cost := range nextRow[1:] {
+			rowMin = min(rowMin, cost)
+		}
+		if costs.confusions != nil {
+			if pending, ok := costs.confusions.pendingCost(walker.word, walker.rows); ok {
+				rowMin = min(rowMin, pending)
+			}
+		}
+		if rowMin <= walker.maxCost {
+			if curLetter.state.final && nextRow[len(query)] <= walker.maxCost {
+				if !fn(walker.word, nextRow[len(query)]) {
+					return false
+				}
+			}
+			if !walker.walkState(curLetter.state, fn) {
+				return false
+			}
+		}
+	}
+	walker.word = walker.word[:depth]
+	return true
+}
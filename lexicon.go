@@ -0,0 +1,21 @@
+package dawg
+
+import "iter"
+
+// Lexicon holds the exact queries shared by the representations of a DAWG: DAWG, CompactDAWG and SuccinctDAWG
+type Lexicon interface {
+	Contains(word string) bool
+	HasPrefix(prefix string) bool
+	CountWithPrefix(prefix string) int
+	WordsCount() uint64
+	Completions(prefix string, max int) []string
+	Words() iter.Seq[string]
+	Index(word string) (index uint, ok bool)
+	WordAt(index uint) (word string, ok bool)
+}
+
+var (
+	_ Lexicon = (*DAWG)(nil)
+	_ Lexicon = (*CompactDAWG)(nil)
+	_ Lexicon = (*SuccinctDAWG)(nil)
+)
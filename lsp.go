@@ -0,0 +1,88 @@
+package dawg
+
+import (
+	"strings"
+	"unicode/utf16"
+)
+
+// Suggester is implemented by the types able to check words and suggest corrections, like *DAWG
+type Suggester interface {
+	// Check if the word is known
+	Check(word string) bool
+	// Suggest at most k corrections for the word, best suggestions first
+	Suggest(word string, k int) []Suggestion
+}
+
+// Severity of the diagnostics reported for unknown words (Information, in the Language Server Protocol)
+const diagnosticSeverity = 3
+
+// Position in a text, as defined by the Language Server Protocol:
+// zero-based line and zero-based offset in the line, counted in UTF-16 code units.
+type Position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Range of a text, as defined by the Language Server Protocol. The end is exclusive.
+type Range struct {
+	Start Position `json:"start"`
+	End   Position `json:"end"`
+}
+
+// Diagnostic is an unknown word, in a format directly usable in a Language Server Protocol diagnostic
+type Diagnostic struct {
+	Range       Range    `json:"range"`
+	Severity    int      `json:"severity"`
+	Source      string   `json:"source"`
+	Message     string   `json:"message"`
+	Suggestions []string `json:"data,omitempty"` // The suggested corrections, best suggestions first
+}
+
+// Check if the word is in the DAWG (same as Contains, to implement Suggester)
+func (dawg *DAWG) Check(word string) bool {
+	return dawg.Contains(word)
+}
+
+// Report a diagnostic for each unknown word of the text inside textRange, with at most k suggestions each
+// (all of them if k <= 0)
+func Diagnostics(suggester Suggester, text string, textRange Range, k int) (diagnostics []Diagnostic) {
+	for lineNumber, line := range strings.Split(text, "\n") {
+		if lineNumber < textRange.Start.Line || lineNumber > textRange.End.Line {
+			continue
+		}
+		line = strings.TrimSuffix(line, "\r")
+		scanWords(line, func(word string, start int, end int) {
+			wordRange := Range{
+				Start: Position{Line: lineNumber, Character: utf16Len(line[:start])},
+				End:   Position{Line: lineNumber, Character: utf16Len(line[:end])},
+			}
+			if !textRange.contains(wordRange) || suggester.Check(word) {
+				return
+			}
+			diagnostic := Diagnostic{Range: wordRange, Severity: diagnosticSeverity, Source: "dawg", Message: "Unknown word \"" + word + "\""}
+			for _, suggestion := range suggester.Suggest(word, k) {
+				diagnostic.Suggestions = append(diagnostic.Suggestions, suggestion.Word)
+			}
+			diagnostics = append(diagnostics, diagnostic)
+		})
+	}
+	return
+}
+
+// Check if the other range is inside this range
+func (r Range) contains(other Range) bool {
+	return !other.Start.before(r.Start) && !r.End.before(other.End)
+}
+
+// Check if the position is strictly before the other position
+func (p Position) before(other Position) bool {
+	return p.Line < other.Line || p.Line == other.Line && p.Character < other.Character
+}
+
+// Length of the string in UTF-16 code units
+func utf16Len(s string) (length int) {
+	for _, r := range s {
+		length += utf16.RuneLen(r)
+	}
+	return
+}
@@ -0,0 +1,24 @@
+package dawg
+
+import "testing"
+
+func TestDiagnostics(t *testing.T) {
+	dawg := CreateDAWG([]string{"the", "cat", "sat", "on", "mat", "don't"})
+	var suggester Suggester = dawg
+
+	text := "the cat sat\r\n𝄞 on thw mat, don't\nthw"
+	whole := Range{Start: Position{0, 0}, End: Position{2, 3}}
+	diagnostics := Diagnostics(suggester, text, whole, 1)
+	if len(diagnostics) != 2 {
+		t.Fatal("Diagnostics failed")
+	}
+	// The musical symbol takes 2 UTF-16 code units
+	if diagnostics[0].Range != (Range{Start: Position{1, 6}, End: Position{1, 9}}) || len(diagnostics[0].Suggestions) != 1 || diagnostics[0].Suggestions[0] != "the" {
+		t.Error("Diagnostic position failed")
+	}
+
+	diagnostics = Diagnostics(suggester, text, Range{Start: Position{1, 0}, End: Position{1, 20}}, 1)
+	if len(diagnostics) != 1 || diagnostics[0].Range.Start.Line != 1 {
+		t.Error("Diagnostics range failed")
+	}
+}
@@ -0,0 +1,56 @@
+package dawg
+
+import "sort"
+
+// Map associates a value with each word of a DAWG.
+// The values are stored in a slice, at the index of their word (see DAWG.Index), so a Map of a static
+// set of keys needs much less memory than a Go map when the keys share prefixes and suffixes.
+type Map[V any] struct {
+	dawg   *DAWG
+	values []V
+}
+
+// Create a new Map from the entries
+func CreateMap[V any](entries map[string]V) *Map[V] {
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	dawg, _ := CreateDAWGFromSorted(keys) // Can't fail on sorted keys
+	// The index of a key is its position in the sorted keys
+	values := make([]V, len(keys))
+	for i, key := range keys {
+		values[i] = entries[key]
+	}
+	return &Map[V]{dawg: dawg, values: values}
+}
+
+// Get the value associated with the key. ok is false if the key is not in the Map.
+func (m *Map[V]) Get(key string) (value V, ok bool) {
+	index, ok := m.dawg.Index(key)
+	if !ok {
+		return value, false
+	}
+	return m.values[index], true
+}
+
+// Get the number of keys of the Map
+func (m *Map[V]) Len() int {
+	return len(m.values)
+}
+
+// Get the DAWG of the keys of the Map, to search them (it must not be changed)
+func (m *Map[V]) DAWG() *DAWG {
+	return m.dawg
+}
+
+// Call fn for each key of the Map and its value, in the lexicographic order of the keys.
+// The iteration stops as soon as fn returns false.
+func (m *Map[V]) Range(fn func(key string, value V) bool) {
+	i := 0
+	walkSorted(m.dawg.initialState, nil, func(word []rune) bool {
+		i++
+		return fn(string(word), m.values[i-1])
+	})
+}
@@ -0,0 +1,39 @@
+package dawg
+
+import "testing"
+
+func TestMap(t *testing.T) {
+	entries := map[string]int{"test": 1, "tests": 2, "nest": 3, "": 4, "日本": 5}
+	m := CreateMap(entries)
+
+	if m.Len() != len(entries) {
+		t.Error("Len failed")
+	}
+	for key, value := range entries {
+		if got, ok := m.Get(key); !ok || got != value {
+			t.Error("Get failed for", key, got)
+		}
+	}
+	if _, ok := m.Get("tes"); ok {
+		t.Error("Get should fail for a missing key")
+	}
+	if !m.DAWG().Contains("tests") {
+		t.Error("DAWG failed")
+	}
+
+	keys := ""
+	m.Range(func(key string, value int) bool {
+		if entries[key] != value {
+			t.Error("Range failed for", key, value)
+		}
+		keys += key + ","
+		return key != "test"
+	})
+	if keys != ",nest,test," {
+		t.Error("Range failed:", keys)
+	}
+
+	if empty := CreateMap(map[string]string{}); empty.Len() != 0 {
+		t.Error("Empty Map failed")
+	}
+}
@@ -0,0 +1,406 @@
+package dawg
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Number of steps of a search between two checks of its context
+const contextCheckInterval = 1024
+
+// A step of an approximate search: a state of the DAWG reached after consuming the first letters of the query
+type searchStep struct {
+	state        *state
+	position     int     // Number of letters of the query consumed
+	depth        int     // Size of the word found, including the letters of this step
+	letters      [2]rune // Letters added to the word found by this step
+	lettersCount int
+	distance     int      // Number of edits left
+	ignoreChar   rune     // Letter of the query that can't be used by the next step (0 if none)
+	edits        [3]int32 // Number of substitutions, insertions and deletions of the path leading to the step
+}
+
+// Kind of the steps which don't edit the query
+const unedited EditOp = -1
+
+// A matcher searches the words of a DAWG close to a query.
+// The steps of the search are kept in an explicit stack, so the depth of the search is only bounded
+// by the memory, and the buffers are reused from one search to the next.
+type matcher struct {
+	allowAdd       bool
+	allowDelete    bool
+	allowTranspose bool
+	prefix         bool // The query only has to match a prefix of the words
+	ignoreCase     bool // The letters of the query match the letters of the words with another case
+	minLength      int  // 0 for no limit
+	maxLength      int  // 0 for no limit
+	query          []rune
+	querySize      int // Number of bytes of the query
+	stack          []searchStep
+	word           []rune
+	exactPrefix    int      // Number of letters of the query which can't be edited
+	separators     string   // Separators of the phrases which can be edited for free (empty if none)
+	budgets        [3]int   // Maximum numbers of substitutions, insertions and deletions (0 for no limit, < 0 for none)
+	edits          [3]int32 // Edits of the path leading to the step being expanded
+	completed      int      // In prefix mode, number of letters of the words being completed matched by the query
+
+	compare func(a string, b string) int // Order of the words of a same distance (nil for the lexicographic order)
+	filter  func(prefix string) bool     // Prefixes of the words which can be found (nil for all)
+
+	flagged      *DAWG // DAWG of the flags of the words found, if they are filtered by their flags (nil if not)
+	requireFlags uint32
+	excludeFlags uint32
+	stop         func(match Match) bool // Stops the search when it returns true (nil to never stop)
+	stopped      bool                   // The search was stopped by stop
+
+	// Words found by collect
+	distance  int                 // Maximum distance of the current search
+	limit     int                 // Number of words kept in matches, the first ones in their order (0 for no limit)
+	found     map[string]struct{} // All the words found since the last reset
+	matches   []Match             // Words found by the current search, and not by the previous ones
+	collectFn func(word []rune, distanceLeft int) bool
+
+	// Words found by appendWord, as UTF-8 bytes, without allocating strings
+	initialState *state
+	foundIndexes map[uint]struct{} // Indexes of all the words found since the last reset
+	appended     [][]byte          // The words found, in the buffers of the caller
+	appendFn     func(word []rune, distanceLeft int) bool
+}
+
+// The matchers are reused from one search to the next, to avoid allocating their buffers
+var matcherPool = sync.Pool{New: func() any {
+	matcher := &matcher{found: make(map[string]struct{}), foundIndexes: make(map[uint]struct{})}
+	matcher.collectFn, matcher.appendFn = matcher.collect, matcher.appendWord // Allocated once, not for each search
+	return matcher
+}}
+
+// Get a matcher from the pool, ready to search the word with the options
+func getMatcher(word string, options SearchOptions) *matcher {
+	matcher := matcherPool.Get().(*matcher)
+	matcher.allowAdd, matcher.allowDelete, matcher.allowTranspose = options.AllowAdd, options.AllowDelete, options.Transpose
+	matcher.prefix, matcher.ignoreCase = options.Prefix, options.IgnoreCase
+	matcher.minLength, matcher.maxLength = options.MinLength, options.MaxLength
+	matcher.compare, matcher.filter = options.Compare, options.Filter
+	matcher.exactPrefix = options.ExactPrefix
+	matcher.budgets = [3]int{options.MaxSubstitutions, options.MaxInsertions, options.MaxDeletions}
+	matcher.separators = ""
+	if options.FreeSeparators {
+		matcher.separators = options.Separators
+	}
+	matcher.requireFlags, matcher.excludeFlags = options.RequireFlags, options.ExcludeFlags
+	matcher.stop, matcher.stopped = options.Stop, false
+	matcher.query, matcher.querySize = matcher.query[:0], len(word)
+	for _, char := range word {
+		matcher.query = append(matcher.query, char)
+	}
+	return matcher
+}
+
+// Give the matcher back to the pool
+func putMatcher(matcher *matcher) {
+	clear(matcher.found)
+	clear(matcher.foundIndexes)
+	clear(matcher.matches) // Don't keep the words alive
+	matcher.initialState, matcher.appended = nil, nil
+	matcher.compare, matcher.filter, matcher.flagged, matcher.stop = nil, nil, nil, nil
+	matcher.matches = matcher.matches[:0]
+	matcherPool.Put(matcher)
+}
+
+// Search the words at most at the given distance of the query, and add to matches those not found
+// by the previous searches, sorted lexicographically (or with the Compare function of the options).
+func (matcher *matcher) collectAll(ctx context.Context, initialState *state, distance int) error {
+	matcher.distance = distance
+	matcher.matches = matcher.matches[:0]
+	if err := matcher.search(ctx, initialState, distance, matcher.collectFn); err != nil {
+		return err
+	}
+	matcher.sortMatches()
+	return nil
+}
+
+// Sort the words found lexicographically (or with the Compare function of the options),
+// so they don't depend on the order of the letters in the graph, and keep the limit first ones
+func (matcher *matcher) sortMatches() {
+	slices.SortFunc(matcher.matches, func(a Match, b Match) int {
+		if matcher.compare != nil {
+			if order := matcher.compare(a.Word, b.Word); order != 0 {
+				return order
+			}
+		}
+		return strings.Compare(a.Word, b.Word)
+	})
+	if matcher.limit > 0 && len(matcher.matches) > matcher.limit {
+		clear(matcher.matches[matcher.limit:]) // Don't keep the words alive
+		matcher.matches = matcher.matches[:matcher.limit]
+	}
+}
+
+// Add the word to matches if it wasn't found before, and if its flags are allowed.
+// Return false if the search must stop.
+func (matcher *matcher) collect(word []rune, distanceLeft int) bool {
+	if _, ok := matcher.found[string(word)]; !ok {
+		content := string(word)
+		matcher.found[content] = struct{}{}
+		if matcher.flagged != nil && !allowedFlags(matcher.flagged.Flags(content), matcher.requireFlags, matcher.excludeFlags) {
+			return true
+		}
+		match := newMatch(content, matcher.distance-distanceLeft, matcher.querySize)
+		if matcher.prefix {
+			match.Matched = runesSize(word[:matcher.completed])
+		}
+		matcher.matches = append(matcher.matches, match)
+		if matcher.stop != nil && matcher.stop(match) {
+			matcher.stopped = true
+			return false
+		}
+		if matcher.limit > 0 && len(matcher.matches) >= 2*matcher.limit {
+			// The words after the limit won't be given, the search stopping after this distance
+			matcher.sortMatches()
+		}
+	}
+	return true
+}
+
+// Search the words at most at the given distance of the query, and append to appended those not found by the
+// previous searches, sorted lexicographically, keeping the limit first ones. The buffers after the length of
+// appended are reused.
+func (matcher *matcher) appendAll(ctx context.Context, initialState *state, distance int) error {
+	matcher.initialState = initialState
+	start := len(matcher.appended)
+	if err := matcher.search(ctx, initialState, distance, matcher.appendFn); err != nil {
+		return err
+	}
+	// The lexicographic order of the UTF-8 bytes is the order of the runes
+	slices.SortFunc(matcher.appended[start:], bytes.Compare)
+	if matcher.limit > 0 && len(matcher.appended)-start > matcher.limit {
+		matcher.appended = matcher.appended[:start+matcher.limit]
+	}
+	return nil
+}
+
+// Append the word to appended if it wasn't found before, the words being identified by their index (see
+// DAWG.Index) instead of their string. Return true (the search doesn't stop).
+func (matcher *matcher) appendWord(word []rune, distanceLeft int) bool {
+	index := runesIndex(matcher.initialState, word)
+	if _, ok := matcher.foundIndexes[index]; ok {
+		return true
+	}
+	matcher.foundIndexes[index] = struct{}{}
+	matcher.appended = appendBuffer(matcher.appended, "")
+	last := len(matcher.appended) - 1
+	for _, char := range word {
+		matcher.appended[last] = utf8.AppendRune(matcher.appended[last], char)
+	}
+	return true
+}
+
+// Call fn for each path of at most distance edits from the query to a word under initialState, with the number
+// of edits left at the end of the path. The same word can be found by several paths.
+// The word slice is only valid during the call. The search stops as soon as fn returns false.
+func (matcher *matcher) search(ctx context.Context, initialState *state, distance int, fn func(word []rune, distanceLeft int) bool) error {
+	matcher.stack = append(matcher.stack[:0], searchStep{state: initialState, distance: distance})
+	return matcher.run(ctx, fn)
+}
+
+// Explore the steps of the stack and the steps following them, calling fn for each word reached
+// (see search). The word buffer must hold the letters before the depth of the steps.
+func (matcher *matcher) run(ctx context.Context, fn func(word []rune, distanceLeft int) bool) error {
+	query := matcher.query
+	for steps := 1; len(matcher.stack) > 0; steps++ {
+		if steps%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		step := matcher.stack[len(matcher.stack)-1]
+		matcher.stack = matcher.stack[:len(matcher.stack)-1]
+		// The steps are explored depth-first, so the letters before this step are still in the buffer
+		for len(matcher.word) < step.depth {
+			matcher.word = append(matcher.word, 0)
+		}
+		copy(matcher.word[step.depth-step.lettersCount:], step.letters[:step.lettersCount])
+		if matcher.filter != nil && step.lettersCount > 0 && !matcher.filter(string(matcher.word[:step.depth])) {
+			continue
+		}
+		if !matcher.expand(step, query, fn) {
+			return nil
+		}
+	}
+	return ctx.Err()
+}
+
+// Push the steps following the step on the stack, and call fn if it reaches a word
+func (matcher *matcher) expand(step searchStep, query []rune, fn func(word []rune, distanceLeft int) bool) bool {
+	matcher.edits = step.edits
+	var char rune
+	if step.position < len(query) {
+		char = query[step.position]
+		if matcher.freeSeparator(char) && step.position >= matcher.exactPrefix {
+			// Delete the separator, or replace it by another separator, for free
+			matcher.push(step.state, step.position+1, step.depth, step.distance, unedited, 0)
+			for _, letter := range step.state.letters {
+				if letter.char != char && matcher.freeSeparator(letter.char) {
+					matcher.push(letter.state, step.position+1, step.depth, step.distance, unedited, 0, letter.char)
+				}
+			}
+		}
+		if char != step.ignoreChar {
+			for variant := char; ; {
+				if letter := step.state.getletter(variant); letter != nil { // Same letter
+					matcher.push(letter.state, step.position+1, step.depth, step.distance, unedited, 0, letter.char)
+				}
+				if variant = matcher.nextCase(variant); variant == char {
+					break
+				}
+			}
+		}
+		if step.distance > 0 && step.position >= matcher.exactPrefix {
+			if matcher.allowedEdit(step, Substitution) {
+				for _, letter := range step.state.letters {
+					if !matcher.sameLetter(letter.char, char) && letter.char != step.ignoreChar { // Change one letter
+						// The next letter can't be ignored: "xx" -> "yx" is only one substitution
+						matcher.push(letter.state, step.position+1, step.depth, step.distance-1, Substitution, 0, letter.char)
+					}
+				}
+			}
+			if matcher.allowTranspose && step.position+1 < len(query) && !matcher.sameLetter(query[step.position+1], char) {
+				nextChar := query[step.position+1]
+				for variant := nextChar; ; {
+					if letter := step.state.getletter(variant); letter != nil {
+						for swappedVariant := char; ; {
+							if swappedLetter := letter.state.getletter(swappedVariant); swappedLetter != nil { // Swap two letters
+								matcher.push(swappedLetter.state, step.position+2, step.depth, step.distance-1, Transposition, 0, letter.char, swappedLetter.char)
+							}
+							if swappedVariant = matcher.nextCase(swappedVariant); swappedVariant == char {
+								break
+							}
+						}
+					}
+					if variant = matcher.nextCase(variant); variant == nextChar {
+						break
+					}
+				}
+			}
+			if matcher.allowDelete && matcher.allowedEdit(step, Deletion) { // Remove one letter
+				matcher.push(step.state, step.position+1, step.depth, step.distance-1, Deletion, char)
+			}
+		}
+	} else if matcher.prefix {
+		// All the words starting with the letters found match (so adding letters is useless), the word buffer
+		// being kept for the other steps
+		matcher.completed = step.depth
+		return matcher.walkCompletions(step.state, slices.Clone(matcher.word[:step.depth]), step.distance, fn)
+	} else if step.state.final && matcher.allowedLength(step.depth) {
+		if !fn(matcher.word[:step.depth], step.distance) {
+			return false
+		}
+	}
+
+	if step.distance > 0 && matcher.allowAdd && matcher.allowedEdit(step, Insertion) && step.position >= matcher.exactPrefix {
+		for _, letter := range step.state.letters {
+			if letter.char != char && letter.char != step.ignoreChar { // Add one letter
+				matcher.push(letter.state, step.position, step.depth, step.distance-1, Insertion, 0, letter.char)
+			}
+		}
+	}
+	if matcher.separators != "" && step.position >= matcher.exactPrefix {
+		for _, letter := range step.state.letters {
+			if matcher.freeSeparator(letter.char) { // Add a separator for free
+				matcher.push(letter.state, step.position, step.depth, step.distance, unedited, 0, letter.char)
+			}
+		}
+	}
+	return true
+}
+
+// Check if the path leading to the step can have one more edit of the kind (Substitution, Insertion or Deletion)
+func (matcher *matcher) allowedEdit(step searchStep, op EditOp) bool {
+	budget := matcher.budgets[op]
+	return budget == 0 || int(step.edits[op]) < budget
+}
+
+// Check if the letter is a separator which can be edited for free
+func (matcher *matcher) freeSeparator(char rune) bool {
+	return matcher.separators != "" && strings.ContainsRune(matcher.separators, char)
+}
+
+// Get the next letter of the same case folding as char (char itself if the case isn't ignored).
+// Starting from a letter, all its cases are given before getting back to it.
+func (matcher *matcher) nextCase(char rune) rune {
+	if !matcher.ignoreCase {
+		return char
+	}
+	return unicode.SimpleFold(char)
+}
+
+// Check if a letter of a word matches a letter of the query
+func (matcher *matcher) sameLetter(a rune, b rune) bool {
+	return a == b || matcher.ignoreCase && sameFold(a, b)
+}
+
+// Check if two letters are the same letter with another case (with the simple case folding of Unicode)
+func sameFold(a rune, b rune) bool {
+	for variant := unicode.SimpleFold(a); variant != a; variant = unicode.SimpleFold(variant) {
+		if variant == b {
+			return true
+		}
+	}
+	return false
+}
+
+// Call fn for each word under the state, in lexicographic order, word holding the letters leading to the state.
+// The words of a length which is not allowed, or whose prefixes are rejected by the filter, are skipped.
+func (matcher *matcher) walkCompletions(curState *state, word []rune, distanceLeft int, fn func(word []rune, distanceLeft int) bool) bool {
+	if curState.final && matcher.allowedLength(len(word)) && !fn(word, distanceLeft) {
+		return false
+	}
+	if matcher.maxLength > 0 && len(word) >= matcher.maxLength {
+		return true
+	}
+	for _, curLetter := range curState.letters {
+		next := append(word, curLetter.char)
+		if matcher.filter != nil && !matcher.filter(string(next)) {
+			continue
+		}
+		if !matcher.walkCompletions(curLetter.state, next, distanceLeft, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Check if a word of the size can be found
+func (matcher *matcher) allowedLength(size int) bool {
+	return size >= matcher.minLength && (matcher.maxLength == 0 || size <= matcher.maxLength)
+}
+
+// Push a step adding the letters to the word found with the edit op (unedited if none), unless the words it leads to
+// can't have an allowed length
+func (matcher *matcher) push(curState *state, position int, depth int, distance int, op EditOp, ignoreChar rune, letters ...rune) {
+	if (matcher.minLength > 0 || matcher.maxLength > 0) && matcher.separators == "" {
+		// Each letter of the query left adds a letter to the word, unless it is deleted
+		shortest, longest := depth+len(letters)+len(matcher.query)-position, depth+len(letters)+len(matcher.query)-position
+		if matcher.allowDelete {
+			shortest = max(shortest-distance, depth+len(letters))
+		}
+		if matcher.allowAdd {
+			longest += distance
+		}
+		if longest < matcher.minLength && !matcher.prefix || matcher.maxLength > 0 && shortest > matcher.maxLength {
+			return
+		}
+	}
+	step := searchStep{state: curState, position: position, depth: depth + len(letters), distance: distance, ignoreChar: ignoreChar, lettersCount: len(letters)}
+	copy(step.letters[:], letters)
+	step.edits = matcher.edits
+	if op >= Substitution && op <= Deletion {
+		step.edits[op]++
+	}
+	matcher.stack = append(matcher.stack, step)
+}
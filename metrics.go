@@ -0,0 +1,34 @@
+package dawg
+
+import "time"
+
+// Metrics observes the searches and the builds of DAWGs, to monitor a service using them.
+// Its methods may be called by several goroutines at the same time.
+type Metrics interface {
+	// Called after each approximate search, with the maximum distance searched and the number of words found
+	ObserveSearch(duration time.Duration, distance int, results int)
+	// Called after each build of a DAWG from a word list, with the number of words and of states of the DAWG
+	ObserveBuild(duration time.Duration, words uint64, nodes uint64)
+}
+
+// Observe the searches of the DAWG with metrics (nil to stop observing them).
+// The DAWGs built with BuildOptions.Metrics are already observed by them.
+// It must not be called while the DAWG is searched by other goroutines.
+func (dawg *DAWG) SetMetrics(metrics Metrics) {
+	dawg.metrics = metrics
+}
+
+// Call the metrics of the DAWG, if any, for a search started at start
+func (dawg *DAWG) observeSearch(start time.Time, distance int, results int) {
+	if dawg.metrics != nil {
+		dawg.metrics.ObserveSearch(time.Since(start), distance, results)
+	}
+}
+
+// Call the metrics of the options, if any, for the build of the DAWG started at start, and observe its searches with them
+func (options BuildOptions) observeBuild(start time.Time, dawg *DAWG) {
+	if options.Metrics != nil {
+		options.Metrics.ObserveBuild(time.Since(start), dawg.WordsCount(), dawg.nodesCount)
+		dawg.metrics = options.Metrics
+	}
+}
@@ -0,0 +1,49 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type testMetrics struct {
+	searches, results, distance int
+	builds                      int
+	words, nodes                uint64
+}
+
+func (metrics *testMetrics) ObserveSearch(duration time.Duration, distance int, results int) {
+	metrics.searches++
+	metrics.distance, metrics.results = distance, results
+}
+
+func (metrics *testMetrics) ObserveBuild(duration time.Duration, words uint64, nodes uint64) {
+	metrics.builds++
+	metrics.words, metrics.nodes = words, nodes
+}
+
+func TestMetrics(t *testing.T) {
+	metrics := &testMetrics{}
+	dawg, _ := CreateDAWGWithOptions([]string{"test", "tests", "text"}, BuildOptions{Metrics: metrics})
+	if metrics.builds != 1 || metrics.words != 3 || metrics.nodes != dawg.NodesCount() {
+		t.Error("ObserveBuild failed")
+	}
+	dawg.SearchWithOptions("tast", SearchOptions{Distance: 2})
+	if metrics.searches != 1 || metrics.distance != 2 || metrics.results != 2 {
+		t.Error("ObserveSearch failed")
+	}
+	dawg.SearchFunc("tast", SearchOptions{Distance: 2}, func(match Match) bool { return false })
+	if metrics.searches != 2 || metrics.results != 1 {
+		t.Error("ObserveSearch failed with SearchFunc")
+	}
+
+	dawg.SetMetrics(nil)
+	dawg.Search("tast", 2, 10, false, false)
+	if metrics.searches != 2 {
+		t.Error("SetMetrics failed")
+	}
+
+	if _, err := CreateDAWGFromReader(strings.NewReader("a\nb\n"), BuildOptions{Metrics: metrics}); err != nil || metrics.builds != 2 || metrics.words != 2 {
+		t.Error("ObserveBuild failed with a reader")
+	}
+}
@@ -0,0 +1,188 @@
+package dawg
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"os"
+	"sort"
+)
+
+// MappedDAWG is a read-only DAWG answering queries directly from a file saved by Save,
+// mapped in memory: the file is never loaded into states and letters, and only the pages
+// of the file used by the queries are read.
+// A MappedDAWG is safe for concurrent use, until it is closed.
+type MappedDAWG struct {
+	data       []byte // All the bytes of the DAWG, for VerifyChecksum
+	nodeBytes  []byte
+	edgeBytes  []byte
+	nodesCount uint32
+	edgesCount uint32
+	unmap      func() error
+}
+
+// Open a file saved by Save, mapping it in memory.
+// The header of the file is checked (see ReadDAWG for the errors), but not its checksum, which would read
+// the whole file: call VerifyChecksum for that.
+func OpenDAWG(fileName string) (*MappedDAWG, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() < binaryHeaderSize {
+		return nil, errors.New("Incorrect binary format : file too short.")
+	}
+	data, unmap, err := mapFile(file, int(info.Size()))
+	if err != nil {
+		return nil, err
+	}
+	dawg, err := newMappedDAWG(data, unmap)
+	if err != nil {
+		unmap()
+		return nil, err
+	}
+	return dawg, nil
+}
+
+// Get a read-only DAWG answering queries directly from the bytes of a DAWG written by WriteTo (or Save),
+// without copying nor decoding them (see WriteGo). The bytes must not be changed while the DAWG is used.
+// As for OpenDAWG, the checksum is not checked.
+func NewMappedDAWG(data []byte) (*MappedDAWG, error) {
+	if len(data) < binaryHeaderSize {
+		return nil, errors.New("Incorrect binary format : file too short.")
+	}
+	return newMappedDAWG(data, func() error { return nil })
+}
+
+// Get a DAWG answering queries from the bytes of a DAWG written by WriteTo, unmapped by unmap when closed
+func newMappedDAWG(data []byte, unmap func() error) (*MappedDAWG, error) {
+	nodesCount, edgesCount, err := parseBinaryHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	dawg := &MappedDAWG{nodesCount: nodesCount, edgesCount: edgesCount, unmap: unmap}
+	nodesEnd := binaryHeaderSize + binaryNodeSize*uint64(nodesCount)
+	edgesEnd := nodesEnd + binaryEdgeSize*uint64(edgesCount)
+	if edgesEnd+binaryFooterSize > uint64(len(data)) {
+		return nil, errors.New("Incorrect binary format : file too short.")
+	}
+	dawg.data = data[:edgesEnd+binaryFooterSize]
+	dawg.nodeBytes = data[binaryHeaderSize:nodesEnd]
+	dawg.edgeBytes = data[nodesEnd:edgesEnd]
+	return dawg, nil
+}
+
+// Check the checksum of the DAWG, reading all its bytes. Return ErrChecksum if they are corrupted.
+func (dawg *MappedDAWG) VerifyChecksum() error {
+	footer := dawg.data[len(dawg.data)-binaryFooterSize:]
+	if crc32.Checksum(dawg.data[:len(dawg.data)-binaryFooterSize], checksumTable) != binary.LittleEndian.Uint32(footer) {
+		return ErrChecksum
+	}
+	return nil
+}
+
+// Unmap the file. The DAWG can't be used anymore afterwards. Closing it again does nothing.
+func (dawg *MappedDAWG) Close() error {
+	unmap := dawg.unmap
+	dawg.data, dawg.nodeBytes, dawg.edgeBytes, dawg.nodesCount, dawg.edgesCount, dawg.unmap = nil, nil, nil, 0, 0, nil
+	if unmap == nil {
+		return nil
+	}
+	return unmap()
+}
+
+// Get the node number i: its first edge, its number of edges, and if it is final.
+// Out of range edges (in corrupted files) are ignored.
+func (dawg *MappedDAWG) node(i uint32) (firstEdge uint32, edgesCount uint32, final bool) {
+	node := dawg.nodeBytes[i*binaryNodeSize:]
+	firstEdge = binary.LittleEndian.Uint32(node[0:])
+	flags := binary.LittleEndian.Uint32(node[4:])
+	edgesCount = flags >> 1
+	if uint64(firstEdge)+uint64(edgesCount) > uint64(dawg.edgesCount) {
+		return 0, 0, flags&1 != 0
+	}
+	return firstEdge, edgesCount, flags&1 != 0
+}
+
+// Get the edge number i: its rune, and the node it leads to (ok is false for corrupted edges)
+func (dawg *MappedDAWG) edge(i uint32) (char rune, target uint32, ok bool) {
+	edge := dawg.edgeBytes[i*binaryEdgeSize:]
+	target = binary.LittleEndian.Uint32(edge[4:])
+	return rune(binary.LittleEndian.Uint32(edge[0:])), target, target < dawg.nodesCount
+}
+
+// Follow the edge of the given rune from node (in O(log(n)) time, the edges being sorted)
+func (dawg *MappedDAWG) child(node uint32, char rune) (target uint32, ok bool) {
+	firstEdge, edgesCount, _ := dawg.node(node)
+	i := sort.Search(int(edgesCount), func(i int) bool {
+		c, _, _ := dawg.edge(firstEdge + uint32(i))
+		return c >= char
+	})
+	if i == int(edgesCount) {
+		return 0, false
+	}
+	c, target, ok := dawg.edge(firstEdge + uint32(i))
+	return target, ok && c == char
+}
+
+// Check if the word is in the DAWG
+func (dawg *MappedDAWG) Contains(word string) bool {
+	if dawg.nodesCount == 0 {
+		return false
+	}
+	var node uint32
+	for _, char := range word {
+		var ok bool
+		if node, ok = dawg.child(node, char); !ok {
+			return false
+		}
+	}
+	_, _, final := dawg.node(node)
+	return final
+}
+
+// Approximate string searching in the DAWG, as DAWG.Search: the words are sorted by distance, then
+// lexicographically, and the maxResults first ones are returned (all of them if maxResults <= 0).
+// Each word is returned only once.
+func (dawg *MappedDAWG) Search(word string, levenshteinDistance int, maxResults int, allowAdd bool, allowDelete bool) (words []string, err error) {
+	if dawg.nodesCount == 0 {
+		return nil, errors.New("The DAWG is closed.")
+	}
+	options := SearchOptions{Distance: levenshteinDistance, MaxResults: maxResults, AllowAdd: allowAdd, AllowDelete: allowDelete}
+	return matchedWords(flatSearchWithOptions(dawg, word, options)), nil
+}
+
+// Check if the node is final (implements flatEdges, the nodes out of range having no edges)
+func (dawg *MappedDAWG) final(node uint32) bool {
+	if node >= dawg.nodesCount {
+		return false
+	}
+	_, _, final := dawg.node(node)
+	return final
+}
+
+// Get the edges of the node, first to last (excluded)
+func (dawg *MappedDAWG) edges(node uint32) (first uint32, last uint32) {
+	if node >= dawg.nodesCount {
+		return 0, 0
+	}
+	firstEdge, edgesCount, _ := dawg.node(node)
+	return firstEdge, firstEdge + edgesCount
+}
+
+// Get the rune of the edge
+func (dawg *MappedDAWG) char(edge uint32) rune {
+	char, _, _ := dawg.edge(edge)
+	return char
+}
+
+// Get the node the edge leads to (out of range for the corrupted edges)
+func (dawg *MappedDAWG) target(edge uint32) uint32 {
+	_, target, _ := dawg.edge(edge)
+	return target
+}
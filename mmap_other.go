@@ -0,0 +1,17 @@
+//go:build !unix
+
+package dawg
+
+import (
+	"io"
+	"os"
+)
+
+// Read the whole file, as memory mapping is not available on this platform
+func mapFile(file *os.File, size int) (data []byte, unmap func() error, err error) {
+	data = make([]byte, size)
+	if _, err = io.ReadFull(file, data); err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
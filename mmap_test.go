@@ -0,0 +1,65 @@
+package dawg
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestOpenDAWG(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "日本"})
+	fileName := filepath.Join(t.TempDir(), "words.dawg")
+	if err := dawg.Save(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	mapped, err := OpenDAWG(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !mapped.Contains("test") || !mapped.Contains("日本") || mapped.Contains("te") || mapped.Contains("tests") {
+		t.Error("Contains failed")
+	}
+
+	for _, search := range []struct {
+		distance              int
+		allowAdd, allowDelete bool
+	}{{0, false, false}, {1, false, false}, {1, true, true}, {2, true, false}, {2, false, true}} {
+		// The same words in the same order, the closest ones being kept with few results
+		for _, maxResults := range []int{1, 2, 3, Unlimited} {
+			expected, _ := dawg.Search("test", search.distance, maxResults, search.allowAdd, search.allowDelete)
+			words, err := mapped.Search("test", search.distance, maxResults, search.allowAdd, search.allowDelete)
+			if err != nil || !slices.Equal(words, expected) {
+				t.Error("Search failed", search, maxResults, words, expected)
+			}
+		}
+	}
+
+	if words, _ := mapped.Search("test", 1, 2, true, true); len(words) != 2 {
+		t.Error("Search maxResults failed")
+	}
+	if words, _ := mapped.Search("test", 1, Unlimited, true, true); len(words) <= 2 {
+		t.Error("Search without maxResults failed")
+	}
+	if mapped.VerifyChecksum() != nil {
+		t.Error("VerifyChecksum failed")
+	}
+
+	// The file is unmapped only once
+	if mapped.Close() != nil || mapped.Close() != nil {
+		t.Error("Close failed")
+	}
+}
+
+func TestMappedDAWGChecksum(t *testing.T) {
+	data, _ := CreateDAWG([]string{"test", "tese", "nest"}).MarshalBinary()
+	data[binaryHeaderSize+4]++ // Flags of the initial state
+	mapped, err := NewMappedDAWG(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapped.VerifyChecksum() != ErrChecksum {
+		t.Error("VerifyChecksum of corrupted data failed")
+	}
+}
@@ -0,0 +1,20 @@
+//go:build unix
+
+package dawg
+
+import (
+	"os"
+	"syscall"
+)
+
+// Map the file in memory, read-only
+func mapFile(file *os.File, size int) (data []byte, unmap func() error, err error) {
+	if size == 0 {
+		return nil, func() error { return nil }, nil
+	}
+	data, err = syscall.Mmap(int(file.Fd()), 0, size, syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
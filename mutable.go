@@ -0,0 +1,292 @@
+package dawg
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+// Index of the states of a DAWG, to keep it minimal when words are added or removed
+type mutableIndex struct {
+	registered map[string]*state // The states, by signature
+	signatures map[*state]string // The signature of each registered state
+	ids        map[*state]uint64 // Number of each state, for the signatures
+	nextID     uint64            // Numbers are never reused, even after a state is removed
+	inDegrees  map[*state]int    // Number of letters going to each state
+	key        []byte
+}
+
+// Get the index of the states of the DAWG, built on first use
+func (dawg *DAWG) getMutableIndex() *mutableIndex {
+	if dawg.mutable == nil {
+		dawg.mutable = newMutableIndex(dawg.initialState)
+	}
+	return dawg.mutable
+}
+
+// Create the index of the states under the initial state
+func newMutableIndex(initialState *state) *mutableIndex {
+	index := &mutableIndex{
+		registered: make(map[string]*state),
+		signatures: make(map[*state]string),
+		ids:        make(map[*state]uint64),
+		inDegrees:  make(map[*state]int),
+	}
+	index.addSubStates(initialState)
+	return index
+}
+
+// Register the states under curState (not curState itself), children first
+func (index *mutableIndex) addSubStates(curState *state) {
+	for _, curLetter := range curState.letters {
+		index.inDegrees[curLetter.state]++
+		if _, ok := index.ids[curLetter.state]; !ok {
+			index.addSubStates(curLetter.state)
+			index.ids[curLetter.state] = index.newID()
+			index.register(curLetter.state)
+		}
+	}
+}
+
+// Add the state to the register, unless an equal state is already registered.
+// The registered state equal to curState is returned.
+func (index *mutableIndex) register(curState *state) *state {
+	index.key = curState.signature(index.key[:0], index.ids)
+	if sameState, ok := index.registered[string(index.key)]; ok {
+		return sameState
+	}
+	key := string(index.key)
+	index.registered[key] = curState
+	index.signatures[curState] = key
+	return curState
+}
+
+// Remove the state from the register, before changing it
+func (index *mutableIndex) unregister(curState *state) {
+	if key, ok := index.signatures[curState]; ok {
+		delete(index.registered, key)
+		delete(index.signatures, curState)
+	}
+}
+
+// Get a number for a new state
+func (index *mutableIndex) newID() uint64 {
+	index.nextID++
+	return index.nextID
+}
+
+// Create a new state, with an id
+func (index *mutableIndex) newState() *state {
+	newState := &state{final: false}
+	index.ids[newState] = index.newID()
+	return newState
+}
+
+// Create a copy of the state, going to the same states
+func (index *mutableIndex) clone(curState *state) *state {
+	clone := index.newState()
+	clone.final, clone.wordsCount = curState.final, curState.wordsCount
+	letters := curState.letters
+	cloneLetters := make([]*letter, len(letters))
+	for i, curLetter := range letters {
+		cloneLetters[i] = &letter{char: curLetter.char, state: curLetter.state}
+		index.inDegrees[curLetter.state]++
+	}
+	clone.letters = cloneLetters
+	return clone
+}
+
+// Make the letter go to another state
+func (index *mutableIndex) redirect(curLetter *letter, newState *state) {
+	index.inDegrees[curLetter.state]--
+	index.inDegrees[newState]++
+	curLetter.state = newState
+}
+
+// Add a word to the DAWG, which stays minimal.
+// The states of the prefix of the word already in the DAWG which are shared with other prefixes are cloned,
+// the missing states are added, then the states of the word are merged with the equal states of the DAWG,
+// from the end of the word to its start (Daciuk et al. algorithm for unsorted data).
+// The DAWG must not be used by other goroutines during the call (see ConcurrentDAWG).
+func (dawg *DAWG) Add(word string) error {
+	dawg.add(word, false)
+	return nil
+}
+
+// Add a word to the DAWG. If copyOnWrite is set, all the states of the word are cloned instead of being changed,
+// so they are not changed for the DAWGs sharing them.
+func (dawg *DAWG) add(word string, copyOnWrite bool) {
+	if dawg.Contains(word) {
+		return
+	}
+	index := dawg.getMutableIndex()
+	runes := []rune(word)
+	path, pathLetters := dawg.unshare(runes, copyOnWrite)
+
+	// Add the missing states
+	for _, char := range runes[len(path)-1:] {
+		curState := path[len(path)-1]
+		newLetter := &letter{char: char, state: index.newState()}
+		index.inDegrees[newLetter.state]++
+		curState.addLetter(newLetter)
+		path = append(path, newLetter.state)
+		pathLetters = append(pathLetters, newLetter)
+		dawg.nodesCount++
+	}
+	path[len(path)-1].final = true
+
+	dawg.merge(path, pathLetters)
+	if dawg.frequencies != nil {
+		index, _ := dawg.Index(word)
+		dawg.frequencies = slices.Insert(dawg.frequencies, int(index), 0)
+	}
+	if dawg.flags != nil {
+		index, _ := dawg.Index(word)
+		dawg.flags = slices.Insert(dawg.flags, int(index), 0)
+	}
+	dawg.maxWordSize = max(dawg.maxWordSize, len(runes))
+	dawg.trieNodesCount = 0 // Unknown
+	dawg.resetCaches()
+}
+
+// Remove a word from the DAWG, which stays minimal. Return false if the word wasn't in the DAWG.
+// The states of the word which are shared with other prefixes are cloned, the states which don't
+// lead to any word anymore are deleted, then the states of the word are merged with the equal states
+// of the DAWG, from the end of the word to its start.
+// The DAWG must not be used by other goroutines during the call (see ConcurrentDAWG).
+func (dawg *DAWG) Remove(word string) bool {
+	return dawg.remove(word, false)
+}
+
+// Remove a word from the DAWG. If copyOnWrite is set, all the states of the word are cloned instead of being changed,
+// so they are not changed for the DAWGs sharing them.
+func (dawg *DAWG) remove(word string, copyOnWrite bool) bool {
+	if !dawg.Contains(word) {
+		return false
+	}
+	if dawg.frequencies != nil {
+		index, _ := dawg.Index(word)
+		dawg.frequencies = slices.Delete(dawg.frequencies, int(index), int(index)+1)
+	}
+	if dawg.flags != nil {
+		index, _ := dawg.Index(word)
+		dawg.flags = slices.Delete(dawg.flags, int(index), int(index)+1)
+	}
+	index := dawg.getMutableIndex()
+	runes := []rune(word)
+	path, pathLetters := dawg.unshare(runes, copyOnWrite)
+	path[len(path)-1].final = false
+
+	// Delete the states without words under them
+	for len(path) > 1 {
+		curState := path[len(path)-1]
+		if curState.final || len(curState.letters) > 0 {
+			break
+		}
+		path[len(path)-2].removeLetter(pathLetters[len(path)-1].char)
+		index.inDegrees[curState]--
+		index.remove(curState)
+		dawg.nodesCount--
+		path, pathLetters = path[:len(path)-1], pathLetters[:len(pathLetters)-1]
+	}
+
+	dawg.merge(path, pathLetters)
+	if len(runes) == dawg.maxWordSize {
+		dawg.maxWordSize = longestWord(dawg.initialState, make(map[*state]int))
+	}
+	dawg.trieNodesCount = 0 // Unknown
+	dawg.resetCaches()
+	return true
+}
+
+// Get the states of the longest prefix of the word in the DAWG, starting with the initial state, and the letters
+// leading to them (pathLetters[i] goes from path[i-1] to path[i]).
+// The states are cloned if needed (always if copyOnWrite is set), so they are only reachable through the prefix
+// and can be changed. They are removed from the register.
+func (dawg *DAWG) unshare(runes []rune, copyOnWrite bool) (path []*state, pathLetters []*letter) {
+	index := dawg.mutable
+	path, pathLetters = []*state{dawg.initialState}, []*letter{nil}
+	for _, char := range runes {
+		curLetter := path[len(path)-1].getletter(char)
+		if curLetter == nil {
+			break
+		}
+		if shared := curLetter.state; copyOnWrite || index.inDegrees[shared] > 1 {
+			index.redirect(curLetter, index.clone(shared))
+			if index.inDegrees[shared] > 0 {
+				dawg.nodesCount++
+			} else { // Replaced by its clone
+				index.unregister(shared)
+				index.remove(shared)
+			}
+		}
+		index.unregister(curLetter.state)
+		path = append(path, curLetter.state)
+		pathLetters = append(pathLetters, curLetter)
+	}
+	return
+}
+
+// Register the states of the path (except the initial state), or merge them with the equal states
+// of the DAWG, from the end of the path to its start
+func (dawg *DAWG) merge(path []*state, pathLetters []*letter) {
+	index := dawg.mutable
+	for i := len(path) - 1; i >= 0; i-- {
+		path[i].updateWordsCount()
+		if i == 0 {
+			break // The initial state is never merged
+		}
+		if sameState := index.register(path[i]); sameState != path[i] {
+			index.redirect(pathLetters[i], sameState)
+			index.remove(path[i])
+			dawg.nodesCount--
+		}
+	}
+}
+
+// Forget a state which is not reachable anymore
+func (index *mutableIndex) remove(curState *state) {
+	for _, curLetter := range curState.letters {
+		index.inDegrees[curLetter.state]--
+	}
+	delete(index.inDegrees, curState)
+	delete(index.ids, curState)
+}
+
+// Add a letter to the state, keeping the letters sorted
+func (curState *state) addLetter(newLetter *letter) {
+	i, _ := slices.BinarySearchFunc(curState.letters, newLetter.char, func(curLetter *letter, char rune) int {
+		return cmp.Compare(curLetter.char, char)
+	})
+	curState.letters = slices.Insert(curState.letters, i, newLetter)
+}
+
+// Count the words under the state, from the counts of its sub-states
+func (curState *state) updateWordsCount() {
+	curState.wordsCount = 0
+	if curState.final {
+		curState.wordsCount = 1
+	}
+	for _, curLetter := range curState.letters {
+		curState.wordsCount += curLetter.state.wordsCount
+	}
+}
+
+// Remove a letter from the state, keeping the letters sorted
+func (curState *state) removeLetter(char rune) {
+	letters := curState.letters
+	for i, curLetter := range letters {
+		if curLetter.char == char {
+			curState.letters = append(letters[:i], letters[i+1:]...)
+			return
+		}
+	}
+}
+
+// Forget the data computed from the words of the DAWG, after they changed
+func (dawg *DAWG) resetCaches() {
+	dawg.phoneticIndexOnce = sync.Once{}
+	dawg.phoneticIndex = nil
+	dawg.frequencyMaximaOnce = sync.Once{}
+	dawg.frequencyMaxima = nil
+}
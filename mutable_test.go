@@ -0,0 +1,111 @@
+package dawg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "nest", "note"})
+	for _, word := range []string{"tes", "rest", "tests", "", "notes", "test"} {
+		if err := dawg.Add(word); err != nil {
+			t.Fatal("Add failed:", err)
+		}
+	}
+	expected := CreateDAWG([]string{"test", "nest", "note", "tes", "rest", "tests", "", "notes"})
+	if !dawg.Equal(expected) || dawg.nodesCount != expected.nodesCount || dawg.maxWordSize != 5 {
+		t.Error("Add failed")
+	}
+	if err := dawg.VerifyMinimal(); err != nil {
+		t.Error("Add should keep the DAWG minimal:", err)
+	}
+	if found, _ := dawg.Search("notez", 1, 10, true, true); len(found) != 2 {
+		t.Error("Search failed after Add:", found)
+	}
+}
+
+func TestAddRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	randomWord := func() string {
+		word := make([]rune, r.Intn(7))
+		for j := range word {
+			word[j] = rune('a' + r.Intn(3))
+		}
+		return string(word)
+	}
+	words := make([]string, 300)
+	for i := range words {
+		words[i] = randomWord()
+	}
+	dawg := CreateDAWG(words[:100])
+	for i, word := range words[100:] {
+		if err := dawg.Add(word); err != nil {
+			t.Fatal("Add failed:", err)
+		}
+		if i%50 == 0 {
+			expected := CreateDAWG(words[:101+i])
+			if !dawg.Equal(expected) || dawg.nodesCount != expected.nodesCount {
+				t.Fatal("Add failed after", word)
+			}
+			if err := dawg.VerifyMinimal(); err != nil {
+				t.Fatal("Add should keep the DAWG minimal:", err)
+			}
+		}
+	}
+	if count := dawg.CountWithPrefix(""); count != len(CreateDAWG(words).Completions("", 0)) {
+		t.Error("Add failed: word counts differ", count)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "nest", "note", "tes", "rest", "tests", "", "notes"})
+	if dawg.Remove("tesla") || dawg.Remove("te") {
+		t.Error("Remove should fail for missing words")
+	}
+	for _, word := range []string{"tes", "tests", "", "notes", "rest"} {
+		if !dawg.Remove(word) {
+			t.Fatal("Remove failed for", word)
+		}
+	}
+	expected := CreateDAWG([]string{"test", "nest", "note"})
+	if !dawg.Equal(expected) || dawg.nodesCount != expected.nodesCount || dawg.maxWordSize != 4 {
+		t.Error("Remove failed")
+	}
+	if err := dawg.VerifyMinimal(); err != nil {
+		t.Error("Remove should keep the DAWG minimal:", err)
+	}
+	if dawg.Contains("tests") || !dawg.Contains("test") {
+		t.Error("Contains failed after Remove")
+	}
+}
+
+func TestRemoveRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	words := make(map[string]bool)
+	for len(words) < 200 {
+		word := make([]rune, r.Intn(7))
+		for j := range word {
+			word[j] = rune('a' + r.Intn(3))
+		}
+		words[string(word)] = true
+	}
+	list := make([]string, 0, len(words))
+	for word := range words {
+		list = append(list, word)
+	}
+	dawg := CreateDAWG(list)
+	for i, word := range list {
+		if !dawg.Remove(word) {
+			t.Fatal("Remove failed for", word)
+		}
+		if i%40 == 0 || i == len(list)-1 {
+			expected := CreateDAWG(list[i+1:])
+			if !dawg.Equal(expected) || dawg.nodesCount != expected.nodesCount {
+				t.Fatal("Remove failed after", word)
+			}
+			if err := dawg.VerifyMinimal(); err != nil {
+				t.Fatal("Remove should keep the DAWG minimal:", err)
+			}
+		}
+	}
+}
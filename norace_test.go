@@ -0,0 +1,5 @@
+//go:build !race
+
+package dawg
+
+const raceEnabled = false
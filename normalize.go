@@ -0,0 +1,68 @@
+package dawg
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Letter without its diacritics, for each letter of diacriticLetters
+var diacriticsMap = func() map[rune]rune {
+	bases := []rune(diacriticBases)
+	diacritics := make(map[rune]rune, len(bases))
+	for i, letter := range []rune(diacriticLetters) {
+		diacritics[letter] = bases[i]
+	}
+	return diacritics
+}()
+
+// Fold the case of the word, so that the words differing only by case are equal ("Paris" -> "paris").
+// Each letter is folded to the lower case of its upper case, so the variants of a letter ('ς' and 'σ',
+// the Kelvin sign and 'k') are folded to the same letter.
+func FoldCase(word string) string {
+	return strings.Map(func(char rune) rune {
+		return unicode.ToLower(unicode.ToUpper(char))
+	}, word)
+}
+
+// Remove the diacritics of the word ("déjà" -> "deja"), whatever its normalization form:
+// the combining marks are removed, and the precomposed Latin letters are replaced by their base letter.
+func StripDiacritics(word string) string {
+	return strings.Map(func(char rune) rune {
+		if base, ok := diacriticsMap[char]; ok {
+			return base
+		}
+		if unicode.Is(unicode.Mn, char) {
+			return -1
+		}
+		return char
+	}, word)
+}
+
+// Normalize a word: apply the normalization form, then remove the diacritics, then fold the case, as requested
+func normalizeWord(word string, normalize func(string) string, stripDiacritics bool, foldCase bool) string {
+	if normalize != nil {
+		word = normalize(word)
+	}
+	if stripDiacritics {
+		word = StripDiacritics(word)
+	}
+	if foldCase {
+		word = FoldCase(word)
+	}
+	return word
+}
+
+// Normalize a word added to a DAWG, as configured by the options
+func (options BuildOptions) normalize(word string) string {
+	return NormalizeSeparators(normalizeWord(word, options.Normalize, options.StripDiacritics, options.FoldCase), options.Separators)
+}
+
+// Normalize a searched word, as configured by the options
+func (options SearchOptions) normalize(word string) string {
+	return NormalizeSeparators(normalizeWord(word, options.Normalize, options.StripDiacritics, options.FoldCase), options.Separators)
+}
+
+// Normalize the input of Suggest, as configured by the options
+func (options SuggestOptions) normalize(input string) string {
+	return normalizeWord(input, options.Normalize, options.StripDiacritics, options.FoldCase)
+}
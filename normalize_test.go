@@ -0,0 +1,54 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFoldCase(t *testing.T) {
+	if FoldCase("Paris") != "paris" || FoldCase("ΣΟΦΟΣ") != FoldCase("σοφος") || FoldCase("K") != "k" {
+		t.Error("FoldCase failed")
+	}
+
+	dawg, err := CreateDAWGWithOptions([]string{"Paris", "paris", "PARIS", "Rome"}, BuildOptions{FoldCase: true})
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"paris", "rome"})) {
+		t.Error("Build with case folding failed")
+	}
+
+	matches, err := dawg.SearchWithOptions("ROMA", SearchOptions{Distance: 1, FoldCase: true})
+	if err != nil || len(matches) != 1 || matches[0].Word != "rome" {
+		t.Error("Search with case folding failed")
+	}
+	if suggestions := dawg.SuggestWithOptions("Pariss", 1, SuggestOptions{FoldCase: true}); len(suggestions) != 1 || suggestions[0].Word != "paris" {
+		t.Error("Suggest with case folding failed")
+	}
+}
+
+func TestStripDiacritics(t *testing.T) {
+	// Precomposed (NFC) and decomposed (NFD) forms
+	if StripDiacritics("déjà vu") != "deja vu" || StripDiacritics("de\u0301ja\u0300") != "deja" || StripDiacritics("Łódź Ærø") != "Lodz Æro" {
+		t.Error("StripDiacritics failed")
+	}
+
+	dawg, err := CreateDAWGWithOptions([]string{"déjà", "de\u0301ja\u0300", "Café"}, BuildOptions{StripDiacritics: true, FoldCase: true})
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"deja", "cafe"})) {
+		t.Error("Build with diacritics stripping failed")
+	}
+	matches, err := dawg.SearchWithOptions("CAFÉS", SearchOptions{Distance: 1, AllowDelete: true, StripDiacritics: true, FoldCase: true})
+	if err != nil || len(matches) != 1 || matches[0].Word != "cafe" || matches[0].Distance != 1 {
+		t.Error("Search with diacritics stripping failed")
+	}
+
+	// A normalization form, composing the letters with a combining acute accent
+	compose := func(word string) string {
+		return strings.ReplaceAll(word, "e\u0301", "é")
+	}
+	dawg, err = CreateDAWGWithOptions([]string{"café", "cafe\u0301"}, BuildOptions{Normalize: compose})
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"café"})) {
+		t.Error("Build with normalization failed")
+	}
+	matches, err = dawg.SearchWithOptions("cafe\u0301", SearchOptions{Normalize: compose})
+	if err != nil || len(matches) != 1 || matches[0].Word != "café" {
+		t.Error("Search with normalization failed")
+	}
+}
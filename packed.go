@@ -0,0 +1,194 @@
+package dawg
+
+import (
+	"bufio"
+	"compress/flate"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// The packed format is about 3 times smaller than the binary format of Save, at the cost of a slower loading
+// (see BenchmarkLoad):
+//   - a flags byte, the lowest bit being set if the rest of the data is compressed with DEFLATE
+//   - the number of nodes and the number of edges (uvarints)
+//   - for each node, its number of edges shifted by one, the lowest bit being set if the node is final (uvarint),
+//     then for each edge its rune (UTF-8) and the difference between the node it leads to and the node of the
+//     previous edge (varint)
+//
+// The nodes are numbered as in the binary format, so the nodes following each other lead to nodes close to each
+// other, and most differences only take one byte. The file only depends on the words of the DAWG.
+const packedCompressed = 1
+
+// PackOptions configures the packed format (see WritePacked)
+type PackOptions struct {
+	Compress bool // Compress the packed data with DEFLATE: about a third smaller, for a slightly slower loading
+}
+
+// Save the DAWG to a file in the packed format, to load it later with LoadPackedDAWG
+func (dawg *DAWG) SavePacked(fileName string, options PackOptions) (err error) {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	writer := bufio.NewWriter(file)
+	if err = dawg.WritePacked(writer, options); err != nil {
+		return
+	}
+	return writer.Flush()
+}
+
+// Load from a file a DAWG saved by SavePacked
+func LoadPackedDAWG(fileName string) (*DAWG, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadPackedDAWG(bufio.NewReader(file))
+}
+
+// Write the DAWG to w in the packed format, a compact encoding for the files shipped with an application
+func (dawg *DAWG) WritePacked(w io.Writer, options PackOptions) error {
+	states, numbers := numberStates(dawg.initialState)
+	var edgesCount uint64
+	for _, curState := range states {
+		edgesCount += uint64(len(curState.letters))
+	}
+	buffer := binary.AppendUvarint(nil, uint64(len(states)))
+	buffer = binary.AppendUvarint(buffer, edgesCount)
+	var previousTarget uint32
+	for _, curState := range states {
+		flags := uint64(len(curState.letters)) << 1
+		if curState.final {
+			flags |= 1
+		}
+		buffer = binary.AppendUvarint(buffer, flags)
+		for _, curLetter := range curState.letters {
+			target := numbers[curLetter.state]
+			buffer = utf8.AppendRune(buffer, curLetter.char)
+			buffer = binary.AppendVarint(buffer, int64(target)-int64(previousTarget))
+			previousTarget = target
+		}
+	}
+
+	if !options.Compress {
+		_, err := w.Write(append([]byte{0}, buffer...))
+		return err
+	}
+	if _, err := w.Write([]byte{packedCompressed}); err != nil {
+		return err
+	}
+	compressor, _ := flate.NewWriter(w, flate.BestCompression) // Can't fail with a valid level
+	if _, err := compressor.Write(buffer); err != nil {
+		return err
+	}
+	return compressor.Close()
+}
+
+// Read from r a DAWG written by WritePacked.
+// If r is a bufio.Reader, only the bytes of the DAWG are read, so several DAWGs can be read from the same stream.
+func ReadPackedDAWG(r io.Reader) (*DAWG, error) {
+	reader, ok := r.(*bufio.Reader)
+	if !ok {
+		reader = bufio.NewReader(r)
+	}
+	flags, err := reader.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if flags&packedCompressed != 0 {
+		decompressor := flate.NewReader(reader) // Doesn't read after the compressed data from a bufio.Reader
+		defer decompressor.Close()
+		reader = bufio.NewReader(decompressor)
+	}
+
+	nodesCount, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	edgesCount, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if nodesCount == 0 {
+		return nil, errors.New("Incorrect packed format : no initial state.")
+	}
+	if nodesCount > 1<<32 || edgesCount > 1<<32 {
+		return nil, errors.New("Incorrect packed format : too many nodes.")
+	}
+
+	// The nodes and edges are read before the states are allocated, so the counts of corrupted data can't
+	// allocate more than the data: the slices grow with the records read
+	var nodeFlags []uint64
+	var chars []rune
+	var targets []uint32
+	var target int64
+	for range nodesCount {
+		flags, err := binary.ReadUvarint(reader)
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		if flags>>1 > edgesCount-uint64(len(chars)) {
+			return nil, errors.New("Incorrect packed format : edge out of range.")
+		}
+		nodeFlags = append(nodeFlags, flags)
+		first := len(chars)
+		for range flags >> 1 {
+			char, _, err := reader.ReadRune()
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			delta, err := binary.ReadVarint(reader)
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if target += delta; target < 0 || target >= int64(nodesCount) {
+				return nil, errors.New("Incorrect packed format : node out of range.")
+			}
+			if len(chars) > first && char <= chars[len(chars)-1] {
+				return nil, errors.New("Incorrect packed format : edges not sorted.")
+			}
+			chars, targets = append(chars, char), append(targets, uint32(target))
+		}
+	}
+	if uint64(len(chars)) != edgesCount {
+		return nil, errors.New("Incorrect packed format : edge out of range.")
+	}
+
+	states := make([]state, nodesCount)
+	letters := make([]letter, edgesCount)
+	pointers := make([]*letter, edgesCount)
+	edge := 0
+	for i, flags := range nodeFlags {
+		states[i].final = flags&1 != 0
+		first := edge
+		for ; edge < first+int(flags>>1); edge++ {
+			letters[edge] = letter{char: chars[edge], state: &states[targets[edge]]}
+			pointers[edge] = &letters[edge]
+		}
+		states[i].letters = pointers[first:edge:edge]
+	}
+
+	initialState := &states[0]
+	if _, found := findCycle(initialState, nil, make(map[*state]bool)); found {
+		return nil, errors.New("Incorrect packed format : cycle.")
+	}
+	countWords(initialState, make(map[*state]bool))
+	return &DAWG{initialState: initialState, nodesCount: nodesCount, maxWordSize: longestWord(initialState, make(map[*state]int))}, nil
+}
+
+// Get the error of a read stopped at the end of the data, which is unexpected in the middle of a DAWG
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package dawg
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveLoadPackedDAWG(t *testing.T) {
+	words := []string{"test", "tese", "nest", "test2", "tes", "note", "日本"}
+	dawg := CreateDAWG(words)
+	for _, options := range []PackOptions{{}, {Compress: true}} {
+		fileName := filepath.Join(t.TempDir(), "words.dawg")
+		if err := dawg.SavePacked(fileName, options); err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := LoadPackedDAWG(fileName)
+		if err != nil || loaded.NodesCount() != dawg.NodesCount() || !loaded.Equal(dawg) || loaded.Verify() != nil {
+			t.Fatal("LoadPackedDAWG failed:", err)
+		}
+	}
+
+	// Several DAWGs in the same stream
+	var buffer bytes.Buffer
+	other := CreateDAWG([]string{"other"})
+	dawg.WritePacked(&buffer, PackOptions{Compress: true})
+	other.WritePacked(&buffer, PackOptions{})
+	dawg.WritePacked(&buffer, PackOptions{})
+	reader := bufio.NewReader(&buffer)
+	for _, expected := range []*DAWG{dawg, other, dawg} {
+		if read, err := ReadPackedDAWG(reader); err != nil || !read.Equal(expected) {
+			t.Error("ReadPackedDAWG failed on a stream:", err)
+		}
+	}
+	if _, err := ReadPackedDAWG(reader); err != io.EOF {
+		t.Error("ReadPackedDAWG should fail at the end of the stream:", err)
+	}
+}
+
+func TestReadPackedDAWGIncorrect(t *testing.T) {
+	var buffer bytes.Buffer
+	CreateDAWG([]string{"test", "tests", "nest"}).WritePacked(&buffer, PackOptions{})
+	data := buffer.Bytes()
+	for size := 1; size < len(data); size++ {
+		if _, err := ReadPackedDAWG(bytes.NewReader(data[:size])); err == nil {
+			t.Error("ReadPackedDAWG should fail on truncated data:", size)
+		}
+	}
+	for _, incorrect := range [][]byte{
+		{0, 0, 0},                       // No initial state
+		{0, 1, 1, 2, 'a', 1},            // Node out of range
+		{0, 2, 2, 4, 'b', 1, 'a', 0},    // Edges not sorted
+		{0, 2, 2, 2, 'a', 1, 2, 'a', 0}, // Cycle
+		{0, 2, 1, 4, 'a', 1, 'b', 0},    // Too many edges
+		{0, 0x80, 0x80, 0x80, 0x80, 0x10, 0x80, 0x80, 0x80, 0x80, 0x10, 0}, // Counts much larger than the data
+	} {
+		if _, err := ReadPackedDAWG(bytes.NewReader(incorrect)); err == nil {
+			t.Error("ReadPackedDAWG should fail:", incorrect)
+		}
+	}
+}
+
+// A DAWG of 100000 random words, close to the words of a language
+func benchmarkDAWG() *DAWG {
+	random := rand.New(rand.NewSource(1))
+	syllables := []string{"a", "ba", "ca", "de", "en", "fi", "gu", "ho", "in", "ja", "ke", "li", "mo", "nu", "on", "pa", "que", "ri", "sa", "te", "u", "vi", "xo", "ze"}
+	words := make([]string, 100000)
+	for i := range words {
+		word := ""
+		for range 2 + random.Intn(4) {
+			word += syllables[random.Intn(len(syllables))]
+		}
+		words[i] = fmt.Sprint(word, []string{"", "s", "es", "ed", "ing"}[random.Intn(5)])
+	}
+	return CreateDAWG(words)
+}
+
+// Compare the size and the loading time of the binary and packed formats
+func BenchmarkLoad(b *testing.B) {
+	dawg := benchmarkDAWG()
+	for _, format := range []struct {
+		name  string
+		write func(w io.Writer) error
+		read  func(r io.Reader) (*DAWG, error)
+	}{
+		{"binary", func(w io.Writer) error { _, err := dawg.WriteTo(w); return err }, ReadDAWG},
+		{"packed", func(w io.Writer) error { return dawg.WritePacked(w, PackOptions{}) }, ReadPackedDAWG},
+		{"compressed", func(w io.Writer) error { return dawg.WritePacked(w, PackOptions{Compress: true}) }, ReadPackedDAWG},
+	} {
+		b.Run(format.name, func(b *testing.B) {
+			var buffer bytes.Buffer
+			if err := format.write(&buffer); err != nil {
+				b.Fatal(err)
+			}
+			data := buffer.Bytes()
+			for b.Loop() {
+				if _, err := format.read(bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(len(data)), "file-bytes")
+		})
+	}
+}
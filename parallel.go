@@ -0,0 +1,81 @@
+package dawg
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// Same as matcher.collectAll for the word searched with the options, the steps following the initial state
+// (a letter of the DAWG or an edit of the query) being explored by parallel workers, each with its own matcher.
+// The words found are collected by the matcher, under a lock.
+func (dawg *DAWG) collectParallel(ctx context.Context, collector *matcher, word string, options SearchOptions, distance int) error {
+	collector.distance = distance
+	collector.matches = collector.matches[:0]
+	var lock sync.Mutex
+	workersCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// The words found by a matcher, whose completed letters are given to the collector
+	collectFrom := func(matcher *matcher) func(word []rune, distanceLeft int) bool {
+		return func(word []rune, distanceLeft int) bool {
+			lock.Lock()
+			defer lock.Unlock()
+			collector.completed = matcher.completed
+			if collector.stopped || !collector.collect(word, distanceLeft) {
+				cancel() // The search was stopped by options.Stop: the other workers stop at their next check of the context
+				return false
+			}
+			return true
+		}
+	}
+	stopped := func() bool {
+		lock.Lock()
+		defer lock.Unlock()
+		return collector.stopped
+	}
+
+	// The initial state is expanded by the collector: its steps only have the letters they add to the word
+	collector.stack = collector.stack[:0]
+	collector.expand(searchStep{state: dawg.initialState, distance: distance}, collector.query, collectFrom(collector))
+	steps := slices.Clone(collector.stack)
+
+	pending := make(chan searchStep)
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var err error
+	for i := 0; i < min(runtime.GOMAXPROCS(0), len(steps)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			matcher := getMatcher(word, options)
+			defer putMatcher(matcher)
+			collect := collectFrom(matcher)
+			for step := range pending {
+				matcher.stack = append(matcher.stack[:0], step)
+				if runErr := matcher.run(workersCtx, collect); runErr != nil {
+					errOnce.Do(func() { err = runErr })
+				}
+			}
+		}()
+	}
+	for _, step := range steps {
+		if ctx.Err() != nil || stopped() {
+			break
+		}
+		pending <- step
+	}
+	close(pending)
+	wg.Wait()
+	if stopped() {
+		err = nil // The workers were canceled by the stop
+	}
+	if err == nil {
+		err = ctx.Err()
+	}
+	if err != nil {
+		return err
+	}
+	collector.sortMatches()
+	return nil
+}
@@ -0,0 +1,44 @@
+package dawg
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSearchParallel(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	words := make([]string, 2000)
+	for i := range words {
+		word := make([]byte, 3+random.Intn(6))
+		for j := range word {
+			word[j] = byte('a' + random.Intn(6))
+		}
+		words[i] = string(word)
+	}
+	dawg := CreateDAWG(words)
+
+	for _, options := range []SearchOptions{
+		{Distance: 3},
+		{Distance: 2, AllowAdd: true, AllowDelete: true, Transpose: true},
+		{Distance: 3, AllowAdd: true, AllowDelete: true, MaxResults: 10},
+		{Distance: 1, AllowDelete: true, Prefix: true, MaxResults: 30},
+	} {
+		for _, query := range []string{"abcdef", "fa", "", "eeeeeeeeee"} {
+			expected, _ := dawg.SearchWithOptions(query, options)
+			options.Parallel = true
+			matches, err := dawg.SearchWithOptions(query, options)
+			options.Parallel = false
+			if err != nil || !slices.Equal(matches, expected) {
+				t.Error("Parallel search failed:", query, options, len(matches), len(expected))
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := dawg.SearchContext(ctx, "abcdef", SearchOptions{Distance: 3, Parallel: true}); err != context.Canceled {
+		t.Error("Parallel search should fail with a canceled context:", err)
+	}
+}
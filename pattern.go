@@ -0,0 +1,170 @@
+package dawg
+
+import (
+	"errors"
+	"slices"
+)
+
+// Get the words of the DAWG matching the pattern, in lexicographic order.
+// In the pattern, '?' matches any letter and '*' matches any sequence of letters (possibly empty).
+// At most max words are returned (all of them if max <= 0).
+func (dawg *DAWG) Match(pattern string, max int) []string {
+	matcher := wildcardMatcher([]rune(pattern))
+	words := []string{}
+	matcher.walk(dawg.initialState, nil, matcher.closure([]bool{true}), func(word []rune) bool {
+		words = append(words, string(word))
+		return max <= 0 || len(words) < max
+	})
+	return words
+}
+
+// A wildcard pattern, matched as a non-deterministic automaton whose states are the positions in the pattern
+type wildcardMatcher []rune
+
+// Add to the positions the ones reachable by matching stars with nothing
+func (pattern wildcardMatcher) closure(positions []bool) []bool {
+	positions = append(positions, make([]bool, len(pattern)+1-len(positions))...)
+	for i, char := range pattern {
+		if positions[i] && char == '*' {
+			positions[i+1] = true
+		}
+	}
+	return positions
+}
+
+// Get the positions reached from positions by matching the letter
+func (pattern wildcardMatcher) step(positions []bool, char rune) (next []bool, ok bool) {
+	next = make([]bool, len(pattern)+1)
+	for i, patternChar := range pattern {
+		if !positions[i] {
+			continue
+		}
+		switch patternChar {
+		case '*':
+			next[i] = true
+			ok = true
+		case '?', char:
+			next[i+1] = true
+			ok = true
+		}
+	}
+	return pattern.closure(next), ok
+}
+
+// Call fn for each word under the state matching the pattern, in lexicographic order,
+// the state being reached by prefix with the given positions in the pattern.
+// The walk stops as soon as fn returns false.
+func (pattern wildcardMatcher) walk(curState *state, prefix []rune, positions []bool, fn func(word []rune) bool) bool {
+	if curState.final && positions[len(pattern)] && !fn(prefix) {
+		return false
+	}
+	for _, curLetter := range curState.letters {
+		if next, ok := pattern.step(positions, curLetter.char); ok {
+			if !pattern.walk(curLetter.state, append(prefix, curLetter.char), next, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// RuneSet is the set of letters allowed at a position of a pattern
+type RuneSet struct {
+	runes  []rune // Sorted
+	except bool   // If set, the allowed letters are all the letters except runes
+}
+
+// Get a set allowing any letter
+func AnyRune() RuneSet {
+	return RuneSet{except: true}
+}
+
+// Get a set allowing only the letters of chars
+func Runes(chars string) RuneSet {
+	runes := []rune(chars)
+	slices.Sort(runes)
+	return RuneSet{runes: slices.Compact(runes)}
+}
+
+// Get a set allowing all the letters except the ones of chars
+func ExceptRunes(chars string) RuneSet {
+	set := Runes(chars)
+	set.except = true
+	return set
+}
+
+// Check if the letter is in the set
+func (set RuneSet) Contains(char rune) bool {
+	_, found := slices.BinarySearch(set.runes, char)
+	return found != set.except
+}
+
+// Parse a crossword pattern, one RuneSet per letter: a letter only allows itself, '_' or '?'
+// allows any letter, "[abc]" allows a, b or c, and "[^abc]" allows all the letters but a, b and c.
+func ParsePattern(pattern string) ([]RuneSet, error) {
+	var sets []RuneSet
+	runes := []rune(pattern)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '_', '?':
+			sets = append(sets, AnyRune())
+		case '[':
+			end := slices.Index(runes[i:], ']')
+			if end < 0 {
+				return nil, errors.New("Incorrect pattern : missing ']'.")
+			}
+			chars := runes[i+1 : i+end]
+			if len(chars) > 0 && chars[0] == '^' {
+				sets = append(sets, ExceptRunes(string(chars[1:])))
+			} else {
+				sets = append(sets, Runes(string(chars)))
+			}
+			i += end
+		case ']':
+			return nil, errors.New("Incorrect pattern : unexpected ']'.")
+		default:
+			sets = append(sets, Runes(string(runes[i])))
+		}
+	}
+	return sets, nil
+}
+
+// Get the words of the DAWG having exactly one letter per set of the pattern, each letter
+// being in the set of its position, in lexicographic order.
+// At most max words are returned (all of them if max <= 0).
+func (dawg *DAWG) MatchPattern(pattern []RuneSet, max int) []string {
+	words := []string{}
+	walkPattern(dawg.initialState, pattern, nil, func(word []rune) bool {
+		words = append(words, string(word))
+		return max <= 0 || len(words) < max
+	})
+	return words
+}
+
+// Call fn for each word under the state matching the rest of the pattern, in lexicographic order.
+// The walk stops as soon as fn returns false.
+func walkPattern(curState *state, pattern []RuneSet, prefix []rune, fn func(word []rune) bool) bool {
+	if len(pattern) == 0 {
+		return !curState.final || fn(prefix)
+	}
+	set := pattern[0]
+	if !set.except {
+		// Only look for the allowed letters
+		for _, char := range set.runes {
+			if curLetter := curState.getletter(char); curLetter != nil {
+				if !walkPattern(curLetter.state, pattern[1:], append(prefix, char), fn) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	for _, curLetter := range curState.letters {
+		if set.Contains(curLetter.char) {
+			if !walkPattern(curLetter.state, pattern[1:], append(prefix, curLetter.char), fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
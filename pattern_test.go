@@ -0,0 +1,49 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	dawg := CreateDAWG([]string{"cat", "cart", "chat", "coat", "cast", "act", "cats", "aa"})
+
+	tests := map[string]string{
+		"c?t":  "cat",
+		"c*t":  "cart cast cat chat coat",
+		"c*t?": "cats",
+		"*a*":  "aa act cart cast cat cats chat coat",
+		"*":    "aa act cart cast cat cats chat coat",
+		"??":   "aa",
+		"x*":   "",
+	}
+	for pattern, expected := range tests {
+		if words := strings.Join(dawg.Match(pattern, 0), " "); words != expected {
+			t.Errorf("Match(%q) = %q, expected %q", pattern, words, expected)
+		}
+	}
+	if words := dawg.Match("c*", 2); len(words) != 2 || words[1] != "cast" {
+		t.Error("Match max failed")
+	}
+}
+
+func TestMatchPattern(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tent", "toot", "tart", "text", "taste", "nest"})
+
+	pattern, err := ParsePattern("t[aeiou]_t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if words := strings.Join(dawg.MatchPattern(pattern, 0), " "); words != "tart tent test text toot" {
+		t.Error("MatchPattern failed:", words)
+	}
+	if pattern, _ = ParsePattern("?[^e]?t"); strings.Join(dawg.MatchPattern(pattern, 0), " ") != "tart toot" {
+		t.Error("MatchPattern failed")
+	}
+	if pattern, _ = ParsePattern("t[ae]?t"); len(dawg.MatchPattern(pattern, 1)) != 1 {
+		t.Error("MatchPattern max failed")
+	}
+	if _, err = ParsePattern("t[ae"); err == nil {
+		t.Error("ParsePattern should fail")
+	}
+}
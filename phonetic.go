@@ -0,0 +1,210 @@
+package dawg
+
+import "strings"
+
+// Separator between the phonetic code and the word in the entries of a PhoneticIndex
+const phoneticSeparator = ' '
+
+// PhoneticIndex maps the phonetic codes of the words of a DAWG to these words,
+// to find the words that sound alike even when their spellings are far apart ("fotograf" -> "photograph").
+type PhoneticIndex struct {
+	encode  func(word string) string
+	entries *DAWG // The code, the separator, then the word
+}
+
+// Create a phonetic index of the words of the DAWG, with the given encoding (Metaphone or Soundex for example).
+// The words whose code is empty are not indexed.
+func NewPhoneticIndex(dawg *DAWG, encode func(word string) string) *PhoneticIndex {
+	entries := []string{}
+	for word := range dawg.Words() {
+		if code := encode(word); code != "" {
+			entries = append(entries, code+string(phoneticSeparator)+word)
+		}
+	}
+	return &PhoneticIndex{encode: encode, entries: CreateDAWG(entries)}
+}
+
+// Get the indexed words with the same phonetic code as word, in lexicographic order.
+// At most max words are returned (all of them if max <= 0).
+func (index *PhoneticIndex) Lookup(word string, max int) []string {
+	words := []string{}
+	code := index.encode(word)
+	if code == "" {
+		return words
+	}
+	curState := index.entries.prefixState(code + string(phoneticSeparator))
+	if curState == nil {
+		return words
+	}
+	walkSorted(curState, nil, func(word []rune) bool {
+		words = append(words, string(word))
+		return max <= 0 || len(words) < max
+	})
+	return words
+}
+
+// Get the words of the DAWG which sound like word according to Metaphone, in lexicographic order.
+// The phonetic index is built on first use.
+// At most max words are returned (all of them if max <= 0).
+func (dawg *DAWG) SearchPhonetic(word string, max int) []string {
+	dawg.phoneticIndexOnce.Do(func() {
+		dawg.phoneticIndex = NewPhoneticIndex(dawg, Metaphone)
+	})
+	return dawg.phoneticIndex.Lookup(word, max)
+}
+
+// Compute the American Soundex code of the word (a letter followed by three digits, "Robert" -> "R163").
+// Only the letters from A to Z are taken into account.
+func Soundex(word string) string {
+	const digits = "01230120022455012623010202" // Digit of each letter from A to Z, 0 for the vowels, H, W and Y
+	code := make([]byte, 0, 4)
+	var previous byte
+	for _, char := range strings.ToUpper(word) {
+		if char < 'A' || char > 'Z' {
+			continue
+		}
+		digit := digits[char-'A']
+		if len(code) == 0 {
+			code = append(code, byte(char))
+		} else if digit != '0' && digit != previous {
+			code = append(code, digit)
+			if len(code) == 4 {
+				break
+			}
+		}
+		// H and W don't separate two letters with the same digit, vowels do
+		if char != 'H' && char != 'W' {
+			previous = digit
+		}
+	}
+	if len(code) == 0 {
+		return ""
+	}
+	for len(code) < 4 {
+		code = append(code, '0')
+	}
+	return string(code)
+}
+
+// Compute the Metaphone code of the word (Lawrence Philips' original algorithm, "photograph" -> "FTKRF").
+// Only the letters from A to Z are taken into account. TH is encoded as '0'.
+func Metaphone(word string) string {
+	letters := make([]rune, 0, len(word))
+	for _, char := range strings.ToUpper(word) {
+		if char >= 'A' && char <= 'Z' {
+			letters = append(letters, char)
+		}
+	}
+	// Letter at position i, 0 outside of the word
+	at := func(i int) rune {
+		if i < 0 || i >= len(letters) {
+			return 0
+		}
+		return letters[i]
+	}
+	in := func(char rune, chars string) bool {
+		return char != 0 && strings.ContainsRune(chars, char)
+	}
+	const vowels = "AEIOU"
+
+	code := make([]rune, 0, len(letters))
+	i := 0
+	switch {
+	case len(letters) < 2:
+	case in(letters[0], "KGP") && letters[1] == 'N', letters[0] == 'A' && letters[1] == 'E', letters[0] == 'W' && letters[1] == 'R':
+		i = 1 // Silent first letter
+	case letters[0] == 'X':
+		code, i = append(code, 'S'), 1
+	case letters[0] == 'W' && letters[1] == 'H':
+		code, i = append(code, 'W'), 2
+	}
+	for ; i < len(letters); i++ {
+		char := letters[i]
+		if char == at(i-1) && char != 'C' {
+			continue
+		}
+		switch char {
+		case 'A', 'E', 'I', 'O', 'U':
+			if i == 0 {
+				code = append(code, char)
+			}
+		case 'B':
+			if at(i-1) != 'M' || i != len(letters)-1 {
+				code = append(code, 'B')
+			}
+		case 'C':
+			switch {
+			case at(i-1) == 'S' && at(i+1) == 'H':
+				code = append(code, 'K')
+			case at(i+1) == 'H', at(i+1) == 'I' && at(i+2) == 'A':
+				code = append(code, 'X')
+			case in(at(i+1), "EIY"):
+				if at(i-1) != 'S' {
+					code = append(code, 'S')
+				}
+			default:
+				code = append(code, 'K')
+			}
+		case 'D':
+			if at(i+1) == 'G' && in(at(i+2), "EIY") {
+				code, i = append(code, 'J'), i+1
+			} else {
+				code = append(code, 'T')
+			}
+		case 'G':
+			switch {
+			case at(i+1) == 'H' && i+2 < len(letters) && !in(at(i+2), vowels):
+			case at(i+1) == 'N' && (i+2 == len(letters) || i+4 == len(letters) && at(i+2) == 'E' && at(i+3) == 'D'):
+			case in(at(i+1), "EIY") && at(i-1) != 'G':
+				code = append(code, 'J')
+			default:
+				code = append(code, 'K')
+			}
+		case 'H':
+			if in(at(i+1), vowels) && !in(at(i-1), "CGPST") {
+				code = append(code, 'H')
+			}
+		case 'K':
+			if at(i-1) != 'C' {
+				code = append(code, 'K')
+			}
+		case 'P':
+			if at(i+1) == 'H' {
+				code = append(code, 'F')
+			} else {
+				code = append(code, 'P')
+			}
+		case 'Q':
+			code = append(code, 'K')
+		case 'S':
+			if at(i+1) == 'H' || at(i+1) == 'I' && in(at(i+2), "AO") {
+				code = append(code, 'X')
+			} else {
+				code = append(code, 'S')
+			}
+		case 'T':
+			switch {
+			case at(i+1) == 'I' && in(at(i+2), "AO"):
+				code = append(code, 'X')
+			case at(i+1) == 'H':
+				code = append(code, '0')
+			case at(i+1) == 'C' && at(i+2) == 'H':
+			default:
+				code = append(code, 'T')
+			}
+		case 'V':
+			code = append(code, 'F')
+		case 'W', 'Y':
+			if in(at(i+1), vowels) {
+				code = append(code, char)
+			}
+		case 'X':
+			code = append(code, 'K', 'S')
+		case 'Z':
+			code = append(code, 'S')
+		default: // F, J, L, M, N, R
+			code = append(code, char)
+		}
+	}
+	return string(code)
+}
@@ -0,0 +1,41 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSoundex(t *testing.T) {
+	for word, code := range map[string]string{"Robert": "R163", "Rupert": "R163", "Rubin": "R150", "Ashcraft": "A261", "Tymczak": "T522", "Pfister": "P236", "a": "A000", "": ""} {
+		if Soundex(word) != code {
+			t.Error("Soundex failed for", word, Soundex(word))
+		}
+	}
+}
+
+func TestMetaphone(t *testing.T) {
+	for word, code := range map[string]string{"photograph": "FTKRF", "fotograf": "FTKRF", "knight": "NT", "thumb": "0M", "school": "SKL", "science": "SNS", "edge": "EJ", "wright": "RT", "xylophone": "SLFN", "": ""} {
+		if Metaphone(word) != code {
+			t.Error("Metaphone failed for", word, Metaphone(word))
+		}
+	}
+}
+
+func TestSearchPhonetic(t *testing.T) {
+	dawg := CreateDAWG([]string{"photograph", "photographs", "telegraph", "night", "knight", "nite"})
+
+	if words := strings.Join(dawg.SearchPhonetic("fotograf", 0), " "); words != "photograph" {
+		t.Error("SearchPhonetic failed:", words)
+	}
+	if words := strings.Join(dawg.SearchPhonetic("nyte", 0), " "); words != "knight night nite" {
+		t.Error("SearchPhonetic failed:", words)
+	}
+	if words := dawg.SearchPhonetic("nyte", 2); len(words) != 2 {
+		t.Error("SearchPhonetic failed:", words)
+	}
+
+	index := NewPhoneticIndex(dawg, Soundex)
+	if words := strings.Join(index.Lookup("telegraf", 0), " "); words != "telegraph" {
+		t.Error("Lookup failed:", words)
+	}
+}
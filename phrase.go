@@ -0,0 +1,30 @@
+package dawg
+
+import "strings"
+
+// Normalize the separators of the words of a phrase ("New  York", "state--of-the-art"): each run of separators
+// is replaced by its first separator, and the separators at the start and at the end are removed
+// (" New  York " -> "New York", "state--of-the-art" -> "state-of-the-art").
+// The phrase is returned unchanged if separators is empty.
+func NormalizeSeparators(phrase string, separators string) string {
+	if separators == "" {
+		return phrase
+	}
+	var builder strings.Builder
+	builder.Grow(len(phrase))
+	pending := rune(-1) // First separator of the run after the last word (-1 if none)
+	for _, char := range phrase {
+		if strings.ContainsRune(separators, char) {
+			if pending < 0 {
+				pending = char
+			}
+			continue
+		}
+		if pending >= 0 && builder.Len() > 0 {
+			builder.WriteRune(pending)
+		}
+		pending = -1
+		builder.WriteRune(char)
+	}
+	return builder.String()
+}
@@ -0,0 +1,90 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalizeSeparators(t *testing.T) {
+	for phrase, expected := range map[string]string{
+		" New  York ":       "New York",
+		"state--of-the-art": "state-of-the-art",
+		"New - York":        "New York",
+		"-":                 "",
+		"York":              "York",
+	} {
+		if normalized := NormalizeSeparators(phrase, " -"); normalized != expected {
+			t.Error("NormalizeSeparators failed", phrase, normalized)
+		}
+	}
+	if NormalizeSeparators(" New  York ", "") != " New  York " {
+		t.Error("NormalizeSeparators without separators failed")
+	}
+}
+
+func TestPhrases(t *testing.T) {
+	dawg, err := CreateDAWGWithOptions([]string{" new  york ", "state--of-the-art", "new york", "newark", "york", "new"}, BuildOptions{Separators: " -"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if words := slices.Collect(dawg.Words()); !slices.Equal(words, []string{"new", "new york", "newark", "state-of-the-art", "york"}) {
+		t.Fatal("Build of phrases failed", words)
+	}
+
+	radix := dawg.Radix()
+	search := func(query string, options SearchOptions) []Match {
+		matches, err := dawg.SearchWithOptions(query, options)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count := dawg.CountMatches(query, options); count != len(matches) {
+			t.Error("CountMatches of phrases failed", query, options, count, matches)
+		}
+		if radixMatches := radix.SearchWithOptions(query, options); !options.Prefix && len(radixMatches) != len(matches) {
+			t.Error("Radix search of phrases failed", query, options, radixMatches, matches)
+		}
+		return matches
+	}
+	options := SearchOptions{Separators: " -", FreeSeparators: true}
+	for _, query := range []string{"newyork", "new-york", "new  york", " new york", "new y-ork"} {
+		if matches := search(query, options); !slices.Equal(wordDistances(matches), []wordDistance{{"new york", 0}}) {
+			t.Error("Search across the separators failed", query, matches)
+		}
+	}
+	if matches := search("stateoftheart", options); !slices.Equal(wordDistances(matches), []wordDistance{{"state-of-the-art", 0}}) {
+		t.Error("Search of a phrase failed", matches)
+	}
+	options.Distance, options.AllowAdd = 1, true
+	if matches := search("ew york", options); !slices.Equal(wordDistances(matches), []wordDistance{{"new york", 1}}) {
+		t.Error("Search of a phrase with an edit failed", matches)
+	}
+	// Without FreeSeparators, the separators are letters
+	if matches := search("newyork", SearchOptions{Distance: 1, AllowAdd: true, Separators: " -"}); !slices.Equal(wordDistances(matches), []wordDistance{{"new york", 1}}) {
+		t.Error("Search without FreeSeparators failed", matches)
+	}
+
+	// A query longer than all the words, with its separators
+	short := CreateDAWG([]string{"new", "york"})
+	if matches, _ := short.SearchWithOptions("n-e-w", SearchOptions{Separators: " -", FreeSeparators: true}); !slices.Equal(wordDistances(matches), []wordDistance{{"new", 0}}) {
+		t.Error("Search of a query longer than the words failed", matches)
+	}
+	// Deleting letters on both sides of a separator
+	if matches := search("x zyork", SearchOptions{Distance: 2, AllowDelete: true, Separators: " -", FreeSeparators: true}); !slices.Equal(wordDistances(matches), []wordDistance{{"york", 2}}) {
+		t.Error("Search with deletions around a separator failed", matches)
+	}
+
+	for _, query := range []string{"newyork", "new-yrok", "ny", "york new", "state of", "-", "x zyork", "- xnew", "a b-cnew"} {
+		for distance := range 3 {
+			for _, options := range []SearchOptions{
+				{},
+				{AllowAdd: true, AllowDelete: true, Transpose: true},
+				{AllowAdd: true, ExactPrefix: 3},
+				{AllowDelete: true, MinLength: 4, MaxLength: 8},
+				{Prefix: true, AllowAdd: true},
+			} {
+				options.Distance, options.Separators, options.FreeSeparators = distance, " -", true
+				search(query, options)
+			}
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package dawg
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Check if at least one word of the DAWG starts with the prefix
+func (dawg *DAWG) HasPrefix(prefix string) bool {
+	return dawg.prefixState(prefix) != nil
+}
+
+// Count the words of the DAWG starting with the prefix (the prefix itself included).
+// Each state stores the number of words under it, kept up to date by the builds and the changes of the words,
+// so only the states of the prefix are walked: the count takes O(len(prefix)) whatever the number of words.
+func (dawg *DAWG) CountWithPrefix(prefix string) int {
+	curState := dawg.prefixState(prefix)
+	if curState == nil {
+		return 0
+	}
+	return int(curState.wordsCount)
+}
+
+// Get the words of the DAWG starting with the prefix, in lexicographic order.
+// At most max words are returned (all of them if max <= 0).
+func (dawg *DAWG) Completions(prefix string, max int) []string {
+	completions := []string{}
+	curState := dawg.prefixState(prefix)
+	if curState == nil {
+		return completions
+	}
+	walkSorted(curState, []rune(prefix), func(word []rune) bool {
+		completions = append(completions, string(word))
+		return max <= 0 || len(completions) < max
+	})
+	return completions
+}
+
+// Same as Completions, the words being sorted with compare instead of lexicographically (see SearchOptions.Compare),
+// the words equal for compare being sorted lexicographically. All the words starting with the prefix are walked
+// before keeping the max first ones.
+func (dawg *DAWG) CompletionsWithCompare(prefix string, max int, compare func(a string, b string) int) []string {
+	completions := dawg.Completions(prefix, Unlimited)
+	slices.SortStableFunc(completions, SearchOptions{Compare: compare}.compareWords)
+	if max > 0 && len(completions) > max {
+		completions = completions[:max]
+	}
+	return completions
+}
+
+// Get a page of the words of the DAWG starting with the prefix, in lexicographic order: at most max words
+// (all of them if max <= 0) after the page of the token, and the token of the next page ("" if there are no more
+// words). The first page is given by an empty token. The tokens are opaque strings which can be given to the
+// clients of a web API: the next pages are found without walking the words of the previous ones, and stay right
+// if words are added or removed between two pages.
+func (dawg *DAWG) CompletionsPage(prefix string, token string, max int) (completions []string, next string, err error) {
+	completions = []string{}
+	after, err := decodePageToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+	if token != "" && !strings.HasPrefix(after, prefix) {
+		return nil, "", errors.New("Incorrect page token : the token is for another prefix.")
+	}
+	curState := dawg.prefixState(prefix)
+	if curState == nil {
+		return completions, "", nil
+	}
+
+	// Walk the words from the last one of the previous page (included, as the lower bound), one word more
+	// than the page to know if there is a next page
+	bounds := wordRange{lo: []rune(after), unbounded: true}
+	if token == "" {
+		bounds.lo = []rune(prefix)
+	}
+	bounds.walk(curState, []rune(prefix), true, false, func(word []rune) bool {
+		if token != "" && len(completions) == 0 && string(word) == after {
+			return true
+		}
+		completions = append(completions, string(word))
+		return max <= 0 || len(completions) <= max
+	})
+	if max > 0 && len(completions) > max {
+		completions = completions[:max]
+		next = encodePageToken(completions[max-1])
+	}
+	return completions, next, nil
+}
+
+// Version of the page tokens, their first byte (so the token of the empty word is not empty)
+const pageTokenVersion = 1
+
+// Get the token of the page following the word
+func encodePageToken(word string) string {
+	return base64.RawURLEncoding.EncodeToString(append([]byte{pageTokenVersion}, word...))
+}
+
+// Get the last word of the previous page from a token of encodePageToken ("" for the first page)
+func decodePageToken(token string) (string, error) {
+	if token == "" {
+		return "", nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(data) == 0 || data[0] != pageTokenVersion {
+		return "", errors.New("Incorrect page token.")
+	}
+	return string(data[1:]), nil
+}
+
+// Get the words of the DAWG starting with a prefix within levenshteinDistance of prefix,
+// sorted by distance, then lexicographically.
+// At most max words are returned (all of them if max <= 0).
+func (dawg *DAWG) FuzzyCompletions(prefix string, levenshteinDistance int, max int) []string {
+	if max <= 0 {
+		max = math.MaxInt
+	}
+	query := dawg.NewQuery(levenshteinDistance)
+	for _, char := range prefix {
+		query.Extend(char)
+	}
+	suggestions := query.Completions(max)
+	completions := make([]string, len(suggestions))
+	for i, suggestion := range suggestions {
+		completions[i] = suggestion.Word
+	}
+	return completions
+}
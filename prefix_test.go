@@ -0,0 +1,122 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHasPrefix(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note"})
+
+	if !dawg.HasPrefix("") || !dawg.HasPrefix("te") || !dawg.HasPrefix("test2") || dawg.HasPrefix("test3") || dawg.HasPrefix("x") {
+		t.Error("HasPrefix failed")
+	}
+}
+
+func TestCountWithPrefix(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note"})
+
+	if dawg.CountWithPrefix("") != 6 || dawg.CountWithPrefix("tes") != 4 || dawg.CountWithPrefix("test") != 2 || dawg.CountWithPrefix("n") != 2 || dawg.CountWithPrefix("x") != 0 {
+		t.Error("CountWithPrefix failed")
+	}
+
+	// The counts stay right when words are added and removed, and in the other representations
+	dawg.Add("tests")
+	dawg.Add("nested")
+	dawg.Remove("tese")
+	for _, prefix := range []string{"", "t", "tes", "test", "nest", "note", "x"} {
+		count := len(dawg.Completions(prefix, 0))
+		if dawg.CountWithPrefix(prefix) != count || dawg.Compact().CountWithPrefix(prefix) != count || dawg.Compact().Succinct().CountWithPrefix(prefix) != count {
+			t.Error("CountWithPrefix after changes failed")
+		}
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note"})
+
+	completions := dawg.Completions("tes", 10)
+	if len(completions) != 4 || completions[0] != "tes" || completions[1] != "tese" || completions[2] != "test" || completions[3] != "test2" {
+		t.Error("Completions failed")
+	}
+	if completions = dawg.Completions("tes", 2); len(completions) != 2 || completions[1] != "tese" {
+		t.Error("Completions max failed")
+	}
+	if completions = dawg.Completions("", 0); len(completions) != 6 || completions[0] != "nest" {
+		t.Error("Completions without max failed")
+	}
+	if completions = dawg.Completions("x", 10); len(completions) != 0 {
+		t.Error("Completions failed")
+	}
+}
+
+func TestFuzzyCompletions(t *testing.T) {
+	dawg := CreateDAWG([]string{"restaurant", "restaurants", "rest", "result", "test"})
+
+	completions := dawg.FuzzyCompletions("restau", 0, 0)
+	if len(completions) != 2 || completions[0] != "restaurant" || completions[1] != "restaurants" {
+		t.Error("FuzzyCompletions failed")
+	}
+	completions = dawg.FuzzyCompletions("resteu", 1, 0)
+	if len(completions) != 2 || completions[0] != "restaurant" || completions[1] != "restaurants" {
+		t.Error("FuzzyCompletions failed")
+	}
+	if completions = dawg.FuzzyCompletions("resteu", 1, 1); len(completions) != 1 {
+		t.Error("FuzzyCompletions max failed")
+	}
+}
+
+func TestCompletionsPage(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "testing", "tesla"})
+
+	var pages [][]string
+	token := ""
+	for {
+		page, next, err := dawg.CompletionsPage("tes", token, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages = append(pages, page)
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	if len(pages) != 3 || !slices.Equal(slices.Concat(pages...), dawg.Completions("tes", 0)) || len(pages[2]) != 2 {
+		t.Error("CompletionsPage failed", pages)
+	}
+
+	// The next page stays right when words are added or removed
+	first, next, _ := dawg.CompletionsPage("tes", "", 2)
+	dawg.Add("tesa")
+	dawg.Remove("test")
+	second, _, _ := dawg.CompletionsPage("tes", next, 2)
+	if !slices.Equal(first, []string{"tes", "tese"}) || !slices.Equal(second, []string{"tesla", "test2"}) {
+		t.Error("CompletionsPage after changes failed", first, second)
+	}
+
+	if page, next, err := dawg.CompletionsPage("tes", "", 0); err != nil || next != "" || len(page) != dawg.CountWithPrefix("tes") {
+		t.Error("CompletionsPage without max failed")
+	}
+	if page, next, err := dawg.CompletionsPage("x", "", 2); err != nil || next != "" || len(page) != 0 {
+		t.Error("CompletionsPage of an unknown prefix failed")
+	}
+	if _, _, err := dawg.CompletionsPage("tes", "!", 2); err == nil {
+		t.Error("CompletionsPage of an incorrect token failed")
+	}
+	if _, next, _ := dawg.CompletionsPage("n", "", 1); next == "" {
+		t.Error("CompletionsPage next failed")
+	} else if _, _, err := dawg.CompletionsPage("tes", next, 1); err == nil {
+		t.Error("CompletionsPage of a token of another prefix failed")
+	}
+
+	// The empty word has a token
+	withEmpty := CreateDAWG([]string{"", "a", "b"})
+	page, next, _ := withEmpty.WordsPage("", 1)
+	if !slices.Equal(page, []string{""}) || next == "" {
+		t.Error("WordsPage of the empty word failed")
+	}
+	if page, next, _ = withEmpty.WordsPage(next, 5); !slices.Equal(page, []string{"a", "b"}) || next != "" {
+		t.Error("WordsPage failed", page)
+	}
+}
@@ -0,0 +1,73 @@
+package dawg
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// Number of words added between two calls of BuildOptions.Progress
+const progressInterval = 10000
+
+// BuildPhase is a step of the build of a DAWG
+type BuildPhase int
+
+const (
+	BuildReading    BuildPhase = iota // The words are read and added to the trie (or to the DAWG, while they are sorted)
+	BuildMinimizing                   // The trie is compressed into the DAWG
+	BuildDone
+)
+
+// BuildProgress describes the progress of the build of a DAWG, see BuildOptions.Progress
+type BuildProgress struct {
+	Phase BuildPhase
+	Lines int    // Number of lines (or words of an array) read
+	Nodes uint64 // Number of nodes created, before the minimization
+}
+
+// Same as CreateDAWGFromFileWithOptions, aborted with the error of the context as soon as it is done
+func CreateDAWGFromFileContext(ctx context.Context, fileName string, options BuildOptions) (dawg *DAWG, err error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	return CreateDAWGFromReaderContext(ctx, file, options)
+}
+
+// Same as CreateDAWGFromReader, aborted with the error of the context as soon as it is done
+func CreateDAWGFromReaderContext(ctx context.Context, r io.Reader, options BuildOptions) (dawg *DAWG, err error) {
+	start := time.Now()
+	builder := newAdaptiveBuilder(options)
+	lines := 0
+	err = scanLines(r, options, func(text string, line int) error {
+		if line%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+		if options.Progress != nil && line%progressInterval == 0 {
+			options.Progress(BuildProgress{Phase: BuildReading, Lines: line, Nodes: builder.nodes()})
+		}
+		lines = line
+		return builder.add(text, line)
+	})
+	if err != nil {
+		return
+	}
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	if options.Progress != nil {
+		options.Progress(BuildProgress{Phase: BuildMinimizing, Lines: lines, Nodes: builder.nodes()})
+	}
+	if dawg, err = builder.dawgContext(ctx); err != nil {
+		return
+	}
+	if options.Progress != nil {
+		options.Progress(BuildProgress{Phase: BuildDone, Lines: lines, Nodes: dawg.nodesCount})
+	}
+	options.observeBuild(start, dawg)
+	return dawg, nil
+}
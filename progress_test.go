@@ -0,0 +1,45 @@
+package dawg
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestBuildProgress(t *testing.T) {
+	var words strings.Builder
+	for i := 0; i < 25000; i++ {
+		words.WriteString(strconv.Itoa(i) + "\n")
+	}
+
+	var progresses []BuildProgress
+	options := BuildOptions{Progress: func(progress BuildProgress) { progresses = append(progresses, progress) }}
+	dawg, err := CreateDAWGFromReaderContext(context.Background(), strings.NewReader(words.String()), options)
+	if err != nil || dawg.CountWithPrefix("") != 25000 {
+		t.Fatal("Build with progress failed")
+	}
+	if len(progresses) != 4 || progresses[0].Phase != BuildReading || progresses[0].Lines != 10000 || progresses[1].Lines != 20000 ||
+		progresses[1].Nodes <= progresses[0].Nodes || progresses[2].Phase != BuildMinimizing || progresses[2].Lines != 25000 ||
+		progresses[3].Phase != BuildDone || progresses[3].Nodes != dawg.nodesCount {
+		t.Error("Build progress failed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	options.Progress = func(progress BuildProgress) { cancel() }
+	if _, err := CreateDAWGFromReaderContext(ctx, strings.NewReader(words.String()), options); !errors.Is(err, context.Canceled) {
+		t.Error("Build cancellation failed")
+	}
+
+	// Canceled while the trie of the unsorted words is minimized
+	ctx, cancel = context.WithCancel(context.Background())
+	options.Progress = func(progress BuildProgress) {
+		if progress.Phase == BuildMinimizing {
+			cancel()
+		}
+	}
+	if _, err := CreateDAWGFromReaderContext(ctx, strings.NewReader("b\na\n"+words.String()), options); !errors.Is(err, context.Canceled) {
+		t.Error("Build cancellation while minimizing failed")
+	}
+}
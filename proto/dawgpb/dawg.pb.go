@@ -0,0 +1,623 @@
+// Definition of a dictionary service backed by a DAWG, for the backends which can't use the Go package.
+// The service mirrors the queries of the httpserver package, and is served by the grpcserver package.
+//
+// The Go package dawgpb is generated with:
+//
+//	protoc --go_out=. --go_opt=module=github.com/ftbe/dawg --go-grpc_out=. --go-grpc_opt=module=github.com/ftbe/dawg proto/dawg.proto
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: proto/dawg.proto
+
+package dawgpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ContainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainsRequest) Reset() {
+	*x = ContainsRequest{}
+	mi := &file_proto_dawg_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainsRequest) ProtoMessage() {}
+
+func (x *ContainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainsRequest.ProtoReflect.Descriptor instead.
+func (*ContainsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ContainsRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+type ContainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Contains      bool                   `protobuf:"varint,1,opt,name=contains,proto3" json:"contains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainsResponse) Reset() {
+	*x = ContainsResponse{}
+	mi := &file_proto_dawg_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainsResponse) ProtoMessage() {}
+
+func (x *ContainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainsResponse.ProtoReflect.Descriptor instead.
+func (*ContainsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ContainsResponse) GetContains() bool {
+	if x != nil {
+		return x.Contains
+	}
+	return false
+}
+
+type SearchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Distance      uint32                 `protobuf:"varint,2,opt,name=distance,proto3" json:"distance,omitempty"`                          // Maximum number of edits (at most the maximum distance of the server)
+	MaxResults    uint32                 `protobuf:"varint,3,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"`    // 0 for the maximum number of results of the server
+	AllowAdd      bool                   `protobuf:"varint,4,opt,name=allow_add,json=allowAdd,proto3" json:"allow_add,omitempty"`          // The words found can have letters inserted
+	AllowDelete   bool                   `protobuf:"varint,5,opt,name=allow_delete,json=allowDelete,proto3" json:"allow_delete,omitempty"` // The words found can have letters deleted
+	Transpose     bool                   `protobuf:"varint,6,opt,name=transpose,proto3" json:"transpose,omitempty"`                        // Swapping two adjacent letters counts as one edit
+	Prefix        bool                   `protobuf:"varint,7,opt,name=prefix,proto3" json:"prefix,omitempty"`                              // The word only has to match a prefix of the words found
+	MinLength     uint32                 `protobuf:"varint,8,opt,name=min_length,json=minLength,proto3" json:"min_length,omitempty"`       // Minimum length of the words found, in runes (0 for no limit)
+	MaxLength     uint32                 `protobuf:"varint,9,opt,name=max_length,json=maxLength,proto3" json:"max_length,omitempty"`       // Maximum length of the words found, in runes (0 for no limit)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRequest) Reset() {
+	*x = SearchRequest{}
+	mi := &file_proto_dawg_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRequest) ProtoMessage() {}
+
+func (x *SearchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRequest.ProtoReflect.Descriptor instead.
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SearchRequest) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetDistance() uint32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetMaxResults() uint32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetAllowAdd() bool {
+	if x != nil {
+		return x.AllowAdd
+	}
+	return false
+}
+
+func (x *SearchRequest) GetAllowDelete() bool {
+	if x != nil {
+		return x.AllowDelete
+	}
+	return false
+}
+
+func (x *SearchRequest) GetTranspose() bool {
+	if x != nil {
+		return x.Transpose
+	}
+	return false
+}
+
+func (x *SearchRequest) GetPrefix() bool {
+	if x != nil {
+		return x.Prefix
+	}
+	return false
+}
+
+func (x *SearchRequest) GetMinLength() uint32 {
+	if x != nil {
+		return x.MinLength
+	}
+	return 0
+}
+
+func (x *SearchRequest) GetMaxLength() uint32 {
+	if x != nil {
+		return x.MaxLength
+	}
+	return 0
+}
+
+type Match struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Distance      uint32                 `protobuf:"varint,2,opt,name=distance,proto3" json:"distance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Match) Reset() {
+	*x = Match{}
+	mi := &file_proto_dawg_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Match) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Match) ProtoMessage() {}
+
+func (x *Match) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Match.ProtoReflect.Descriptor instead.
+func (*Match) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Match) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *Match) GetDistance() uint32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+type SearchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*Match               `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchResponse) Reset() {
+	*x = SearchResponse{}
+	mi := &file_proto_dawg_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchResponse) ProtoMessage() {}
+
+func (x *SearchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchResponse.ProtoReflect.Descriptor instead.
+func (*SearchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchResponse) GetMatches() []*Match {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+type CompleteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	MaxResults    uint32                 `protobuf:"varint,2,opt,name=max_results,json=maxResults,proto3" json:"max_results,omitempty"` // 0 for the maximum number of results of the server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteRequest) Reset() {
+	*x = CompleteRequest{}
+	mi := &file_proto_dawg_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteRequest) ProtoMessage() {}
+
+func (x *CompleteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteRequest.ProtoReflect.Descriptor instead.
+func (*CompleteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CompleteRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *CompleteRequest) GetMaxResults() uint32 {
+	if x != nil {
+		return x.MaxResults
+	}
+	return 0
+}
+
+type CompleteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Words         []string               `protobuf:"bytes,1,rep,name=words,proto3" json:"words,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompleteResponse) Reset() {
+	*x = CompleteResponse{}
+	mi := &file_proto_dawg_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompleteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompleteResponse) ProtoMessage() {}
+
+func (x *CompleteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompleteResponse.ProtoReflect.Descriptor instead.
+func (*CompleteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CompleteResponse) GetWords() []string {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type RandomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Length        uint32                 `protobuf:"varint,2,opt,name=length,proto3" json:"length,omitempty"` // Length of the word in runes (0 for any length)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RandomRequest) Reset() {
+	*x = RandomRequest{}
+	mi := &file_proto_dawg_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RandomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RandomRequest) ProtoMessage() {}
+
+func (x *RandomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RandomRequest.ProtoReflect.Descriptor instead.
+func (*RandomRequest) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RandomRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *RandomRequest) GetLength() uint32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+type RandomResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Word          string                 `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RandomResponse) Reset() {
+	*x = RandomResponse{}
+	mi := &file_proto_dawg_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RandomResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RandomResponse) ProtoMessage() {}
+
+func (x *RandomResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_dawg_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RandomResponse.ProtoReflect.Descriptor instead.
+func (*RandomResponse) Descriptor() ([]byte, []int) {
+	return file_proto_dawg_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RandomResponse) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+var File_proto_dawg_proto protoreflect.FileDescriptor
+
+const file_proto_dawg_proto_rawDesc = "" +
+	"\n" +
+	"\x10proto/dawg.proto\x12\x04dawg\"%\n" +
+	"\x0fContainsRequest\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\".\n" +
+	"\x10ContainsResponse\x12\x1a\n" +
+	"\bcontains\x18\x01 \x01(\bR\bcontains\"\x94\x02\n" +
+	"\rSearchRequest\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\rR\bdistance\x12\x1f\n" +
+	"\vmax_results\x18\x03 \x01(\rR\n" +
+	"maxResults\x12\x1b\n" +
+	"\tallow_add\x18\x04 \x01(\bR\ballowAdd\x12!\n" +
+	"\fallow_delete\x18\x05 \x01(\bR\vallowDelete\x12\x1c\n" +
+	"\ttranspose\x18\x06 \x01(\bR\ttranspose\x12\x16\n" +
+	"\x06prefix\x18\a \x01(\bR\x06prefix\x12\x1d\n" +
+	"\n" +
+	"min_length\x18\b \x01(\rR\tminLength\x12\x1d\n" +
+	"\n" +
+	"max_length\x18\t \x01(\rR\tmaxLength\"7\n" +
+	"\x05Match\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word\x12\x1a\n" +
+	"\bdistance\x18\x02 \x01(\rR\bdistance\"7\n" +
+	"\x0eSearchResponse\x12%\n" +
+	"\amatches\x18\x01 \x03(\v2\v.dawg.MatchR\amatches\"J\n" +
+	"\x0fCompleteRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x1f\n" +
+	"\vmax_results\x18\x02 \x01(\rR\n" +
+	"maxResults\"(\n" +
+	"\x10CompleteResponse\x12\x14\n" +
+	"\x05words\x18\x01 \x03(\tR\x05words\"?\n" +
+	"\rRandomRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06length\x18\x02 \x01(\rR\x06length\"$\n" +
+	"\x0eRandomResponse\x12\x12\n" +
+	"\x04word\x18\x01 \x01(\tR\x04word2\xec\x01\n" +
+	"\n" +
+	"Dictionary\x129\n" +
+	"\bContains\x12\x15.dawg.ContainsRequest\x1a\x16.dawg.ContainsResponse\x123\n" +
+	"\x06Search\x12\x13.dawg.SearchRequest\x1a\x14.dawg.SearchResponse\x129\n" +
+	"\bComplete\x12\x15.dawg.CompleteRequest\x1a\x16.dawg.CompleteResponse\x123\n" +
+	"\x06Random\x12\x13.dawg.RandomRequest\x1a\x14.dawg.RandomResponseB#Z!github.com/ftbe/dawg/proto/dawgpbb\x06proto3"
+
+var (
+	file_proto_dawg_proto_rawDescOnce sync.Once
+	file_proto_dawg_proto_rawDescData []byte
+)
+
+func file_proto_dawg_proto_rawDescGZIP() []byte {
+	file_proto_dawg_proto_rawDescOnce.Do(func() {
+		file_proto_dawg_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_dawg_proto_rawDesc), len(file_proto_dawg_proto_rawDesc)))
+	})
+	return file_proto_dawg_proto_rawDescData
+}
+
+var file_proto_dawg_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_dawg_proto_goTypes = []any{
+	(*ContainsRequest)(nil),  // 0: dawg.ContainsRequest
+	(*ContainsResponse)(nil), // 1: dawg.ContainsResponse
+	(*SearchRequest)(nil),    // 2: dawg.SearchRequest
+	(*Match)(nil),            // 3: dawg.Match
+	(*SearchResponse)(nil),   // 4: dawg.SearchResponse
+	(*CompleteRequest)(nil),  // 5: dawg.CompleteRequest
+	(*CompleteResponse)(nil), // 6: dawg.CompleteResponse
+	(*RandomRequest)(nil),    // 7: dawg.RandomRequest
+	(*RandomResponse)(nil),   // 8: dawg.RandomResponse
+}
+var file_proto_dawg_proto_depIdxs = []int32{
+	3, // 0: dawg.SearchResponse.matches:type_name -> dawg.Match
+	0, // 1: dawg.Dictionary.Contains:input_type -> dawg.ContainsRequest
+	2, // 2: dawg.Dictionary.Search:input_type -> dawg.SearchRequest
+	5, // 3: dawg.Dictionary.Complete:input_type -> dawg.CompleteRequest
+	7, // 4: dawg.Dictionary.Random:input_type -> dawg.RandomRequest
+	1, // 5: dawg.Dictionary.Contains:output_type -> dawg.ContainsResponse
+	4, // 6: dawg.Dictionary.Search:output_type -> dawg.SearchResponse
+	6, // 7: dawg.Dictionary.Complete:output_type -> dawg.CompleteResponse
+	8, // 8: dawg.Dictionary.Random:output_type -> dawg.RandomResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_dawg_proto_init() }
+func file_proto_dawg_proto_init() {
+	if File_proto_dawg_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_dawg_proto_rawDesc), len(file_proto_dawg_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_dawg_proto_goTypes,
+		DependencyIndexes: file_proto_dawg_proto_depIdxs,
+		MessageInfos:      file_proto_dawg_proto_msgTypes,
+	}.Build()
+	File_proto_dawg_proto = out.File
+	file_proto_dawg_proto_goTypes = nil
+	file_proto_dawg_proto_depIdxs = nil
+}
@@ -0,0 +1,250 @@
+// Definition of a dictionary service backed by a DAWG, for the backends which can't use the Go package.
+// The service mirrors the queries of the httpserver package, and is served by the grpcserver package.
+//
+// The Go package dawgpb is generated with:
+//
+//	protoc --go_out=. --go_opt=module=github.com/ftbe/dawg --go-grpc_out=. --go-grpc_opt=module=github.com/ftbe/dawg proto/dawg.proto
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: proto/dawg.proto
+
+package dawgpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Dictionary_Contains_FullMethodName = "/dawg.Dictionary/Contains"
+	Dictionary_Search_FullMethodName   = "/dawg.Dictionary/Search"
+	Dictionary_Complete_FullMethodName = "/dawg.Dictionary/Complete"
+	Dictionary_Random_FullMethodName   = "/dawg.Dictionary/Random"
+)
+
+// DictionaryClient is the client API for Dictionary service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type DictionaryClient interface {
+	// Check if a word is in the dictionary
+	Contains(ctx context.Context, in *ContainsRequest, opts ...grpc.CallOption) (*ContainsResponse, error)
+	// Get the words close to a word, by distance then lexicographically
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// Get the words starting with a prefix, lexicographically
+	Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error)
+	// Draw a random word
+	Random(ctx context.Context, in *RandomRequest, opts ...grpc.CallOption) (*RandomResponse, error)
+}
+
+type dictionaryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewDictionaryClient(cc grpc.ClientConnInterface) DictionaryClient {
+	return &dictionaryClient{cc}
+}
+
+func (c *dictionaryClient) Contains(ctx context.Context, in *ContainsRequest, opts ...grpc.CallOption) (*ContainsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ContainsResponse)
+	err := c.cc.Invoke(ctx, Dictionary_Contains_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, Dictionary_Search_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryClient) Complete(ctx context.Context, in *CompleteRequest, opts ...grpc.CallOption) (*CompleteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompleteResponse)
+	err := c.cc.Invoke(ctx, Dictionary_Complete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dictionaryClient) Random(ctx context.Context, in *RandomRequest, opts ...grpc.CallOption) (*RandomResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RandomResponse)
+	err := c.cc.Invoke(ctx, Dictionary_Random_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DictionaryServer is the server API for Dictionary service.
+// All implementations must embed UnimplementedDictionaryServer
+// for forward compatibility.
+type DictionaryServer interface {
+	// Check if a word is in the dictionary
+	Contains(context.Context, *ContainsRequest) (*ContainsResponse, error)
+	// Get the words close to a word, by distance then lexicographically
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// Get the words starting with a prefix, lexicographically
+	Complete(context.Context, *CompleteRequest) (*CompleteResponse, error)
+	// Draw a random word
+	Random(context.Context, *RandomRequest) (*RandomResponse, error)
+	mustEmbedUnimplementedDictionaryServer()
+}
+
+// UnimplementedDictionaryServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDictionaryServer struct{}
+
+func (UnimplementedDictionaryServer) Contains(context.Context, *ContainsRequest) (*ContainsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Contains not implemented")
+}
+func (UnimplementedDictionaryServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedDictionaryServer) Complete(context.Context, *CompleteRequest) (*CompleteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Complete not implemented")
+}
+func (UnimplementedDictionaryServer) Random(context.Context, *RandomRequest) (*RandomResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Random not implemented")
+}
+func (UnimplementedDictionaryServer) mustEmbedUnimplementedDictionaryServer() {}
+func (UnimplementedDictionaryServer) testEmbeddedByValue()                    {}
+
+// UnsafeDictionaryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DictionaryServer will
+// result in compilation errors.
+type UnsafeDictionaryServer interface {
+	mustEmbedUnimplementedDictionaryServer()
+}
+
+func RegisterDictionaryServer(s grpc.ServiceRegistrar, srv DictionaryServer) {
+	// If the following call panics, it indicates UnimplementedDictionaryServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Dictionary_ServiceDesc, srv)
+}
+
+func _Dictionary_Contains_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ContainsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServer).Contains(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dictionary_Contains_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServer).Contains(ctx, req.(*ContainsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dictionary_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dictionary_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dictionary_Complete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServer).Complete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dictionary_Complete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServer).Complete(ctx, req.(*CompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Dictionary_Random_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RandomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DictionaryServer).Random(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Dictionary_Random_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DictionaryServer).Random(ctx, req.(*RandomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Dictionary_ServiceDesc is the grpc.ServiceDesc for Dictionary service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Dictionary_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dawg.Dictionary",
+	HandlerType: (*DictionaryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Contains",
+			Handler:    _Dictionary_Contains_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Dictionary_Search_Handler,
+		},
+		{
+			MethodName: "Complete",
+			Handler:    _Dictionary_Complete_Handler,
+		},
+		{
+			MethodName: "Random",
+			Handler:    _Dictionary_Random_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/dawg.proto",
+}
@@ -0,0 +1,166 @@
+package dawg
+
+import "sort"
+
+// Query is a fuzzy query which can be extended or shortened one letter at a time.
+// The intersection of the DAWG with the Levenshtein automaton of the query (the prefixes of
+// the DAWG close to the query) is kept for each length of the query, so Extend only computes
+// the new intersection from the previous one, and Backspace doesn't compute anything.
+// The letters inserted after the last letter of the query are not part of the intersection:
+// they are only added when the matches or the completions are requested.
+type Query struct {
+	dawg                *DAWG
+	levenshteinDistance int
+	query               []rune
+	frontiers           [][]activeNode // frontiers[i] holds the active nodes for the i first letters of the query
+}
+
+// A prefix of the words of the DAWG within the maximum distance of the query
+type activeNode struct {
+	state    *state
+	prefix   string
+	distance int // Edit distance between the query and prefix
+}
+
+// Create a new empty query, matching the words within levenshteinDistance of it
+func (dawg *DAWG) NewQuery(levenshteinDistance int) *Query {
+	query := &Query{dawg: dawg, levenshteinDistance: levenshteinDistance}
+	// For an empty query, the active nodes are all the prefixes short enough to be inserted
+	var frontier []activeNode
+	var addPrefixes func(curState *state, prefix string, size int)
+	addPrefixes = func(curState *state, prefix string, size int) {
+		frontier = append(frontier, activeNode{state: curState, prefix: prefix, distance: size})
+		if size < levenshteinDistance {
+			for _, curLetter := range curState.letters {
+				addPrefixes(curLetter.state, prefix+string(curLetter.char), size+1)
+			}
+		}
+	}
+	addPrefixes(dawg.initialState, "", 0)
+	query.frontiers = [][]activeNode{frontier}
+	return query
+}
+
+// Get the content of the query
+func (query *Query) String() string {
+	return string(query.query)
+}
+
+// Append a letter to the query
+func (query *Query) Extend(char rune) {
+	query.query = append(query.query, char)
+	frontier := query.frontiers[len(query.frontiers)-1]
+	maxDistance := query.levenshteinDistance
+
+	indexes := make(map[string]int)
+	var nextFrontier []activeNode
+	add := func(curState *state, prefix string, distance int) {
+		if i, ok := indexes[prefix]; ok {
+			nextFrontier[i].distance = min(nextFrontier[i].distance, distance)
+			return
+		}
+		indexes[prefix] = len(nextFrontier)
+		nextFrontier = append(nextFrontier, activeNode{state: curState, prefix: prefix, distance: distance})
+	}
+	// Insert letters in the prefix until a letter matches the new one
+	var matchDescendants func(curState *state, prefix string, distance int)
+	matchDescendants = func(curState *state, prefix string, distance int) {
+		for _, curLetter := range curState.letters {
+			if curLetter.char == char {
+				add(curLetter.state, prefix+string(char), distance)
+			}
+			if distance < maxDistance {
+				matchDescendants(curLetter.state, prefix+string(curLetter.char), distance+1)
+			}
+		}
+	}
+	for _, node := range frontier {
+		if node.distance < maxDistance {
+			add(node.state, node.prefix, node.distance+1) // Delete the new letter
+			for _, curLetter := range node.state.letters {
+				if curLetter.char != char {
+					add(curLetter.state, node.prefix+string(curLetter.char), node.distance+1) // Substitute the new letter
+				}
+			}
+		}
+		matchDescendants(node.state, node.prefix, node.distance)
+	}
+	query.frontiers = append(query.frontiers, nextFrontier)
+}
+
+// Remove the last letter of the query, if any
+func (query *Query) Backspace() (removed bool) {
+	if len(query.query) == 0 {
+		return false
+	}
+	query.query = query.query[:len(query.query)-1]
+	query.frontiers = query.frontiers[:len(query.frontiers)-1]
+	return true
+}
+
+// Get the words of the DAWG within the maximum distance of the query, sorted by distance then lexicographically
+func (query *Query) Matches() []Suggestion {
+	found := make(map[string]Suggestion)
+	// The active nodes don't include the letters inserted after the end of the query
+	var insertLetters func(curState *state, word string, distance int)
+	insertLetters = func(curState *state, word string, distance int) {
+		if previous, ok := found[word]; curState.final && (!ok || distance < previous.Distance) {
+			found[word] = Suggestion{Match: newMatch(word, distance, runesSize(query.query)), Score: float64(distance)}
+		}
+		if distance < query.levenshteinDistance {
+			for _, curLetter := range curState.letters {
+				insertLetters(curLetter.state, word+string(curLetter.char), distance+1)
+			}
+		}
+	}
+	for _, node := range query.frontiers[len(query.frontiers)-1] {
+		insertLetters(node.state, node.prefix, node.distance)
+	}
+	return rankSuggestions(found, len(found))
+}
+
+// Get at most maxResults words of the DAWG starting with a prefix within the maximum distance
+// of the query (all of them if maxResults <= 0), sorted by distance then lexicographically
+func (query *Query) Completions(maxResults int) []Suggestion {
+	nodes := append([]activeNode(nil), query.frontiers[len(query.frontiers)-1]...)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].distance < nodes[j].distance
+	})
+	found := make(map[string]Suggestion)
+	for i := 0; i < len(nodes); {
+		// The nodes of the same distance can't be beaten by the following ones:
+		// stop if there are already enough results before them
+		if maxResults > 0 && len(found) >= maxResults {
+			break
+		}
+		distance := nodes[i].distance
+		for ; i < len(nodes) && nodes[i].distance == distance; i++ {
+			// The maxResults first new completions of a node are enough, the other ones would not be kept
+			var completions int
+			walkSorted(nodes[i].state, []rune(nodes[i].prefix), func(word []rune) bool {
+				if _, ok := found[string(word)]; !ok {
+					suggestion := Suggestion{Match: newMatch(string(word), distance, runesSize(query.query)), Score: float64(distance)}
+					suggestion.Matched = len(nodes[i].prefix)
+					found[string(word)] = suggestion
+					completions++
+				}
+				return maxResults <= 0 || completions < maxResults
+			})
+		}
+	}
+	return rankSuggestions(found, maxResults)
+}
+
+// Call fn for each word under the state, in lexicographic order, prefixed by prefix.
+// The word slice is only valid during the call. The walk stops as soon as fn returns false.
+func walkSorted(curState *state, prefix []rune, fn func(word []rune) bool) bool {
+	if curState.final && !fn(prefix) {
+		return false
+	}
+	for _, curLetter := range curState.letters {
+		if !walkSorted(curLetter.state, append(prefix, curLetter.char), fn) {
+			return false
+		}
+	}
+	return true
+}
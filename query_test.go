@@ -0,0 +1,27 @@
+package dawg
+
+import "testing"
+
+func TestQuery(t *testing.T) {
+	dawg := CreateDAWG([]string{"cat", "cart", "car", "cut", "dog"})
+	query := dawg.NewQuery(1)
+
+	for _, char := range "cat" {
+		query.Extend(char)
+	}
+	matches := query.Matches()
+	if query.String() != "cat" || len(matches) != 4 || matches[0].Word != "cat" || matches[0].Distance != 0 || matches[1].Word != "car" {
+		t.Error("Query failed")
+	}
+
+	query.Backspace()
+	query.Extend('r')
+	matches = query.Matches()
+	if len(matches) != 3 || matches[0].Word != "car" || matches[1].Word != "cart" || matches[2].Word != "cat" {
+		t.Error("Query after backspace failed")
+	}
+
+	if !query.Backspace() || !query.Backspace() || !query.Backspace() || query.Backspace() {
+		t.Error("Query backspace failed")
+	}
+}
@@ -0,0 +1,6 @@
+//go:build race
+
+package dawg
+
+// The race detector makes sync.Pool drop its items at random, so the allocations can't be checked
+const raceEnabled = true
@@ -0,0 +1,230 @@
+package dawg
+
+import (
+	"iter"
+	"slices"
+	"sort"
+)
+
+// RadixDAWG is an immutable DAWG whose chains of states with a single letter are collapsed into edges labeled
+// with several letters ("inter", "ation"), as in a radix tree: only the initial state, the final states and the
+// states with several letters are kept as nodes, so the words are followed with fewer nodes and hops.
+// Like CompactDAWG, it is stored in a few flat slices. The labels of the chains shared by several states
+// are stored once per edge leading to them.
+type RadixDAWG struct {
+	firstEdges  []uint32 // The edges of the node i are the edges firstEdges[i] to firstEdges[i+1] (excluded)
+	finals      []uint64 // Bit set of the final nodes
+	labelStarts []uint32 // The label of the edge j is labels[labelStarts[j]:labelStarts[j+1]]
+	labels      []rune   // Labels of the edges, the edges of a node being sorted by their first rune
+	targets     []uint32 // Node each edge leads to
+	wordsCounts []uint64 // Number of words under each node
+}
+
+// Create the radix version of the DAWG (see RadixDAWG). The node 0 is the initial state.
+func (dawg *DAWG) Radix() *RadixDAWG {
+	// The states kept as nodes, numbered in breadth-first order
+	kept := func(curState *state) bool {
+		return curState.final || len(curState.letters) != 1
+	}
+	states := []*state{dawg.initialState}
+	numbers := map[*state]uint32{dawg.initialState: 0}
+	radix := &RadixDAWG{firstEdges: []uint32{0}, labelStarts: []uint32{0}}
+	for i := 0; i < len(states); i++ {
+		for _, curLetter := range states[i].letters {
+			// Follow the chain of the letter up to a node
+			radix.labels = append(radix.labels, curLetter.char)
+			target := curLetter.state
+			for !kept(target) {
+				radix.labels = append(radix.labels, target.letters[0].char)
+				target = target.letters[0].state
+			}
+			if _, ok := numbers[target]; !ok {
+				numbers[target] = uint32(len(states))
+				states = append(states, target)
+			}
+			radix.labelStarts = append(radix.labelStarts, uint32(len(radix.labels)))
+			radix.targets = append(radix.targets, numbers[target])
+		}
+		radix.firstEdges = append(radix.firstEdges, uint32(len(radix.targets)))
+	}
+	radix.finals = make([]uint64, (len(states)+63)/64)
+	radix.wordsCounts = make([]uint64, len(states))
+	for i, curState := range states {
+		if curState.final {
+			radix.finals[i/64] |= 1 << (i % 64)
+		}
+		radix.wordsCounts[i] = curState.wordsCount
+	}
+	return radix
+}
+
+// Check if the node is final
+func (radix *RadixDAWG) final(node uint32) bool {
+	return radix.finals[node/64]&(1<<(node%64)) != 0
+}
+
+// Get the label of the edge
+func (radix *RadixDAWG) label(edge uint32) []rune {
+	return radix.labels[radix.labelStarts[edge]:radix.labelStarts[edge+1]]
+}
+
+// Get the node reached from the initial node by the prefix, and the letters of the label which remain to be followed
+// to reach it when the prefix ends inside a label. ok is false if no word starts with the prefix.
+func (radix *RadixDAWG) follow(prefix string) (node uint32, rest []rune, ok bool) {
+	for _, char := range prefix {
+		if len(rest) > 0 {
+			if rest[0] != char {
+				return 0, nil, false
+			}
+			rest = rest[1:]
+			continue
+		}
+		first, last := radix.firstEdges[node], radix.firstEdges[node+1]
+		edge := first + uint32(sort.Search(int(last-first), func(i int) bool {
+			return radix.labels[radix.labelStarts[first+uint32(i)]] >= char
+		}))
+		if edge == last || radix.labels[radix.labelStarts[edge]] != char {
+			return 0, nil, false
+		}
+		node, rest = radix.targets[edge], radix.label(edge)[1:]
+	}
+	return node, rest, true
+}
+
+// Check if the word is in the DAWG
+func (radix *RadixDAWG) Contains(word string) bool {
+	node, rest, ok := radix.follow(word)
+	return ok && len(rest) == 0 && radix.final(node)
+}
+
+// Check if at least one word of the DAWG starts with the prefix
+func (radix *RadixDAWG) HasPrefix(prefix string) bool {
+	_, _, ok := radix.follow(prefix)
+	return ok
+}
+
+// Count the words of the DAWG starting with the prefix (the prefix itself included)
+func (radix *RadixDAWG) CountWithPrefix(prefix string) int {
+	node, _, ok := radix.follow(prefix)
+	if !ok {
+		return 0
+	}
+	return int(radix.wordsCounts[node])
+}
+
+// Get the number of words of the DAWG
+func (radix *RadixDAWG) WordsCount() uint64 {
+	return radix.wordsCounts[0]
+}
+
+// Get the number of nodes of the DAWG (the states which were not collapsed into a label)
+func (radix *RadixDAWG) NodesCount() uint64 {
+	return uint64(len(radix.wordsCounts))
+}
+
+// Get the number of edges of the DAWG
+func (radix *RadixDAWG) EdgesCount() uint64 {
+	return uint64(len(radix.targets))
+}
+
+// Get the words of the DAWG starting with the prefix, in lexicographic order (at most max words if max > 0)
+func (radix *RadixDAWG) Completions(prefix string, max int) []string {
+	completions := []string{}
+	node, rest, ok := radix.follow(prefix)
+	if !ok {
+		return completions
+	}
+	radix.walkSorted(node, append([]rune(prefix), rest...), func(word []rune) bool {
+		completions = append(completions, string(word))
+		return max <= 0 || len(completions) < max
+	})
+	return completions
+}
+
+// Iterate over all the words of the DAWG, in lexicographic order
+func (radix *RadixDAWG) Words() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		radix.walkSorted(0, nil, func(word []rune) bool {
+			return yield(string(word))
+		})
+	}
+}
+
+// Call fn for each word under the node, in lexicographic order, until it returns false.
+// The word slice is only valid during the call.
+func (radix *RadixDAWG) walkSorted(node uint32, word []rune, fn func(word []rune) bool) bool {
+	if radix.final(node) && !fn(word) {
+		return false
+	}
+	for edge := radix.firstEdges[node]; edge < radix.firstEdges[node+1]; edge++ {
+		if !radix.walkSorted(radix.targets[edge], append(word, radix.label(edge)...), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// Approximate string searching in the DAWG, with the edits of the options (Distance, AllowAdd, AllowDelete, Transpose,
+// ExactPrefix, IgnoreCase) and the lengths of the words found (MinLength, MaxLength). The other options are ignored.
+// The labels are followed letter by letter, the edit distances of the letters of a label being computed one after
+// the other, so a label is left as soon as no word under it can be within the distance.
+// The words are sorted by distance, then lexicographically, and the MaxResults first ones are returned.
+func (radix *RadixDAWG) SearchWithOptions(word string, options SearchOptions) []Match {
+	matches := []Match{}
+	if options.Distance < 0 {
+		return matches
+	}
+	searcher := &radixSearcher{editRows: editRows{options: options, query: []rune(word)}, radix: radix}
+	searcher.firstRow()
+	searcher.visit(0, func(match Match) {
+		matches = append(matches, match)
+	})
+	slices.SortFunc(matches, func(a Match, b Match) int {
+		if a.Distance != b.Distance {
+			return a.Distance - b.Distance
+		}
+		return options.compareWords(a.Word, b.Word)
+	})
+	if options.MaxResults > 0 && len(matches) > options.MaxResults {
+		matches = matches[:options.MaxResults]
+	}
+	return matches
+}
+
+// A radixSearcher walks a RadixDAWG depth-first with the edit distances of the prefixes of the words
+type radixSearcher struct {
+	editRows
+	radix *RadixDAWG
+}
+
+// Give to fn the words under the node within the distance, the row of the letters leading to it being computed
+func (searcher *radixSearcher) visit(node uint32, fn func(match Match)) {
+	options, radix := searcher.options, searcher.radix
+	depth := len(searcher.word)
+	row := searcher.rows[depth]
+	if radix.final(node) && row[len(searcher.query)] <= options.Distance && depth >= options.MinLength {
+		fn(newMatch(string(searcher.word), row[len(searcher.query)], runesSize(searcher.query)))
+	}
+	if slices.Min(row) > options.Distance {
+		return
+	}
+	for edge := radix.firstEdges[node]; edge < radix.firstEdges[node+1]; edge++ {
+		searcher.word = searcher.word[:depth]
+		matching := true
+		for _, char := range radix.label(edge) {
+			if options.MaxLength > 0 && len(searcher.word) >= options.MaxLength {
+				matching = false
+				break
+			}
+			searcher.word = append(searcher.word, char)
+			if slices.Min(searcher.nextRow()) > options.Distance {
+				matching = false // No word under the label can be within the distance
+				break
+			}
+		}
+		if matching {
+			searcher.visit(radix.targets[edge], fn)
+		}
+	}
+	searcher.word = searcher.word[:depth]
+}
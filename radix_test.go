@@ -0,0 +1,54 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRadix(t *testing.T) {
+	words := []string{"international", "internet", "interval", "nation", "national", "station", "stations", "a", "日本語"}
+	dawg := CreateDAWG(words)
+	radix := dawg.Radix()
+
+	if radix.WordsCount() != dawg.WordsCount() || radix.NodesCount() >= dawg.NodesCount() || radix.EdgesCount() >= dawg.EdgesCount() {
+		t.Error("Radix failed", radix.NodesCount(), radix.EdgesCount())
+	}
+	if found := slices.Collect(radix.Words()); !slices.Equal(found, slices.Collect(dawg.Words())) {
+		t.Error("Radix words failed", found)
+	}
+	for _, prefix := range []string{"", "i", "inter", "intern", "internets", "nation", "nati", "st", "station", "日本", "x", "ax"} {
+		if radix.Contains(prefix) != dawg.Contains(prefix) || radix.HasPrefix(prefix) != dawg.HasPrefix(prefix) || radix.CountWithPrefix(prefix) != dawg.CountWithPrefix(prefix) {
+			t.Error("Radix queries failed", prefix)
+		}
+		if completions := radix.Completions(prefix, 2); !slices.Equal(completions, dawg.Completions(prefix, 2)) {
+			t.Error("Radix completions failed", prefix, completions)
+		}
+	}
+
+	// The searches follow the labels letter by letter
+	for _, word := range []string{"intrenet", "natoin", "staton", "a", "INTERVAL", "日"} {
+		for _, options := range []SearchOptions{
+			{Distance: 1},
+			{Distance: 2, AllowAdd: true, AllowDelete: true},
+			{Distance: 2, AllowAdd: true, AllowDelete: true, Transpose: true, MaxResults: 2},
+			{Distance: 1, AllowAdd: true, ExactPrefix: 2, MinLength: 2, MaxLength: 7},
+			{Distance: 0, IgnoreCase: true},
+		} {
+			expected, err := dawg.SearchWithOptions(word, options)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matches := radix.SearchWithOptions(word, options); !slices.Equal(matches, expected) {
+				t.Error("Radix search failed", word, options, matches, expected)
+			}
+		}
+	}
+	if matches := radix.SearchWithOptions("a", SearchOptions{Distance: -1}); len(matches) != 0 {
+		t.Error("Radix search of a negative distance failed")
+	}
+
+	empty := CreateDAWG(nil).Radix()
+	if empty.WordsCount() != 0 || empty.Contains("") || len(empty.Completions("", 0)) != 0 {
+		t.Error("Radix of an empty DAWG failed")
+	}
+}
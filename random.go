@@ -0,0 +1,299 @@
+package dawg
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+)
+
+// Count the words under the state and the states under it, and store the counts in the states
+// (counted holds the states already counted)
+func countWords(curState *state, counted map[*state]bool) uint64 {
+	if counted[curState] {
+		return curState.wordsCount
+	}
+	var count uint64
+	if curState.final {
+		count = 1
+	}
+	for _, curLetter := range curState.letters {
+		count += countWords(curLetter.state, counted)
+	}
+	curState.wordsCount = count
+	counted[curState] = true
+	return count
+}
+
+// Get a random word starting with fromPrefix.
+// At each state, the walk stops or follows a letter with a probability proportional to the
+// number of words it leads to, so all the words starting with fromPrefix are equally likely.
+func (dawg *DAWG) RandomPath(fromPrefix string) (string, error) {
+	return dawg.randomPath(fromPrefix, func(n uint64) uint64 {
+		return uint64(rand.Int63n(int64(n)))
+	})
+}
+
+// Get a random word starting with fromPrefix, using random to draw numbers in [0, n)
+func (dawg *DAWG) randomPath(fromPrefix string, random func(n uint64) uint64) (string, error) {
+	word := []rune(fromPrefix)
+	curState := dawg.prefixState(fromPrefix)
+	if curState == nil || curState.wordsCount == 0 {
+		return "", errors.New("No word starting with this prefix.")
+	}
+	for {
+		n := random(curState.wordsCount)
+		if curState.final {
+			if n == 0 {
+				return string(word), nil
+			}
+			n--
+		}
+		for _, curLetter := range curState.letters {
+			if n < curLetter.state.wordsCount {
+				word = append(word, curLetter.char)
+				curState = curLetter.state
+				break
+			}
+			n -= curLetter.state.wordsCount
+		}
+	}
+}
+
+// Get a random word of length runes starting with prefix, all such words being equally likely.
+// The prefix is followed first, then the letters are drawn among those leading to words of the right length.
+func (dawg *DAWG) FindRandomWordWithPrefix(prefix string, length int) (string, error) {
+	return dawg.randomWordWithPrefix(prefix, length, func(n uint64) uint64 {
+		return uint64(rand.Int63n(int64(n)))
+	})
+}
+
+// Get a random word of length runes starting with prefix, using random to draw numbers in [0, n)
+func (dawg *DAWG) randomWordWithPrefix(prefix string, length int, random func(n uint64) uint64) (string, error) {
+	word := []rune(prefix)
+	curState := dawg.prefixState(prefix)
+	if curState == nil || length < len(word) {
+		return "", errors.New("No word of this size starting with this prefix.")
+	}
+	counts := make(map[lengthState]uint64)
+	left := length - len(word)
+	if countWordsOfLength(curState, left, counts) == 0 {
+		return "", errors.New("No word of this size starting with this prefix.")
+	}
+	for ; left > 0; left-- {
+		n := random(countWordsOfLength(curState, left, counts))
+		for _, curLetter := range curState.letters {
+			count := countWordsOfLength(curLetter.state, left-1, counts)
+			if n < count {
+				word = append(word, curLetter.char)
+				curState = curLetter.state
+				break
+			}
+			n -= count
+		}
+	}
+	return string(word), nil
+}
+
+// A state, and a number of letters to add to reach a word
+type lengthState struct {
+	state  *state
+	length int
+}
+
+// Count the words of exactly length runes under the state (memoized in counts)
+func countWordsOfLength(curState *state, length int, counts map[lengthState]uint64) uint64 {
+	if length == 0 {
+		if curState.final {
+			return 1
+		}
+		return 0
+	}
+	key := lengthState{curState, length}
+	if count, ok := counts[key]; ok {
+		return count
+	}
+	var count uint64
+	for _, curLetter := range curState.letters {
+		count += countWordsOfLength(curLetter.state, length-1, counts)
+	}
+	counts[key] = count
+	return count
+}
+
+// Get a random word matching the crossword pattern (see ParsePattern), all such words being equally likely.
+// For a hangman game, "_a__[^ers]" gives a word of 5 letters whose second letter is an a and whose last letter
+// is not an e, an r or an s.
+func (dawg *DAWG) FindRandomWordMatching(pattern string) (string, error) {
+	return dawg.randomWordMatching(pattern, func(n uint64) uint64 {
+		return uint64(rand.Int63n(int64(n)))
+	})
+}
+
+// Get a random word matching the crossword pattern, using random to draw numbers in [0, n)
+func (dawg *DAWG) randomWordMatching(pattern string, random func(n uint64) uint64) (string, error) {
+	sets, err := ParsePattern(pattern)
+	if err != nil {
+		return "", err
+	}
+	counts := make(map[lengthState]uint64)
+	curState := dawg.initialState
+	if countWordsMatching(curState, sets, counts) == 0 {
+		return "", errors.New("No word matching this pattern.")
+	}
+	word := make([]rune, 0, len(sets))
+	for ; len(sets) > 0; sets = sets[1:] {
+		n := random(countWordsMatching(curState, sets, counts))
+		for _, curLetter := range curState.letters {
+			if !sets[0].Contains(curLetter.char) {
+				continue
+			}
+			count := countWordsMatching(curLetter.state, sets[1:], counts)
+			if n < count {
+				word = append(word, curLetter.char)
+				curState = curLetter.state
+				break
+			}
+			n -= count
+		}
+	}
+	return string(word), nil
+}
+
+// Count the words under the state matching the end of a pattern (memoized in counts by the length of the end)
+func countWordsMatching(curState *state, pattern []RuneSet, counts map[lengthState]uint64) uint64 {
+	if len(pattern) == 0 {
+		if curState.final {
+			return 1
+		}
+		return 0
+	}
+	key := lengthState{curState, len(pattern)}
+	if count, ok := counts[key]; ok {
+		return count
+	}
+	var count uint64
+	for _, curLetter := range curState.letters {
+		if pattern[0].Contains(curLetter.char) {
+			count += countWordsMatching(curLetter.state, pattern[1:], counts)
+		}
+	}
+	counts[key] = count
+	return count
+}
+
+// Get n distinct words of the DAWG drawn uniformly (all the words if there are less than n words), in random order.
+// The words are drawn by their index (see WordAt), so the shape of the DAWG doesn't bias the sample.
+func (dawg *DAWG) SampleUniform(n int) []string {
+	return dawg.sampleUniform(n, func(n uint64) uint64 {
+		return uint64(rand.Int63n(int64(n)))
+	})
+}
+
+// Get n words of the DAWG drawn independently (a word can be drawn several times), each word with a probability
+// proportional to its frequency. The DAWG must have been built with frequencies (see CreateDAWGWithFrequencies).
+func (dawg *DAWG) SampleByFrequency(n int) ([]string, error) {
+	return dawg.sampleByFrequency(n, func(n uint64) uint64 {
+		return uint64(rand.Int63n(int64(n)))
+	})
+}
+
+// Get n distinct words drawn uniformly, using random to draw numbers in [0, n)
+func (dawg *DAWG) sampleUniform(n int, random func(n uint64) uint64) []string {
+	count := dawg.WordsCount()
+	n = max(n, 0) // No words for a negative count
+	if uint64(n) > count {
+		n = int(count)
+	}
+	// Floyd's algorithm: distinct indexes, each subset of n indexes being equally likely
+	indexes := make([]uint64, 0, n)
+	drawn := make(map[uint64]bool, n)
+	for j := count - uint64(n); j < count; j++ {
+		index := random(j + 1)
+		if drawn[index] {
+			index = j
+		}
+		drawn[index] = true
+		indexes = append(indexes, index)
+	}
+	// The order of Floyd's algorithm is not random
+	for i := len(indexes) - 1; i > 0; i-- {
+		j := random(uint64(i + 1))
+		indexes[i], indexes[j] = indexes[j], indexes[i]
+	}
+	sample := make([]string, len(indexes))
+	for i, index := range indexes {
+		sample[i], _ = dawg.WordAt(uint(index))
+	}
+	return sample
+}
+
+// Get n words drawn by frequency, using random to draw numbers in [0, n)
+func (dawg *DAWG) sampleByFrequency(n int, random func(n uint64) uint64) ([]string, error) {
+	if dawg.frequencies == nil {
+		return nil, errors.New("No frequencies.")
+	}
+	// cumulated[i] is the sum of the frequencies of the words lower than the word i, and of the word i
+	cumulated := make([]uint64, len(dawg.frequencies))
+	var total uint64
+	for i, frequency := range dawg.frequencies {
+		total += frequency
+		cumulated[i] = total
+	}
+	if total == 0 {
+		return nil, errors.New("No frequencies.")
+	}
+	sample := make([]string, max(n, 0))
+	for i := range sample {
+		drawn := random(total)
+		index, _ := slices.BinarySearch(cumulated, drawn+1)
+		sample[i], _ = dawg.WordAt(uint(index))
+	}
+	return sample, nil
+}
+
+// RandomSource draws random words from a DAWG with a given source of randomness instead of the global one,
+// so the sequences of words are reproducible from a seed. It must not be used by several goroutines at the same time.
+type RandomSource struct {
+	dawg   *DAWG
+	random *rand.Rand
+}
+
+// Get a RandomSource drawing the words of the DAWG with random
+func (dawg *DAWG) WithRand(random *rand.Rand) *RandomSource {
+	return &RandomSource{dawg: dawg, random: random}
+}
+
+// Draw a number in [0, n)
+func (source *RandomSource) draw(n uint64) uint64 {
+	return uint64(source.random.Int63n(int64(n)))
+}
+
+// Same as DAWG.RandomPath, with the source of randomness
+func (source *RandomSource) RandomPath(fromPrefix string) (string, error) {
+	return source.dawg.randomPath(fromPrefix, source.draw)
+}
+
+// Same as DAWG.FindRandomWord, with the source of randomness
+func (source *RandomSource) FindRandomWord(wordSize int) (string, error) {
+	return source.dawg.randomWordWithPrefix("", wordSize, source.draw)
+}
+
+// Same as DAWG.FindRandomWordWithPrefix, with the source of randomness
+func (source *RandomSource) FindRandomWordWithPrefix(prefix string, length int) (string, error) {
+	return source.dawg.randomWordWithPrefix(prefix, length, source.draw)
+}
+
+// Same as DAWG.FindRandomWordMatching, with the source of randomness
+func (source *RandomSource) FindRandomWordMatching(pattern string) (string, error) {
+	return source.dawg.randomWordMatching(pattern, source.draw)
+}
+
+// Same as DAWG.SampleUniform, with the source of randomness
+func (source *RandomSource) SampleUniform(n int) []string {
+	return source.dawg.sampleUniform(n, source.draw)
+}
+
+// Same as DAWG.SampleByFrequency, with the source of randomness
+func (source *RandomSource) SampleByFrequency(n int) ([]string, error) {
+	return source.dawg.sampleByFrequency(n, source.draw)
+}
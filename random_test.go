@@ -0,0 +1,159 @@
+package dawg
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRandomPath(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tests", "team", "note", "no"})
+
+	found := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		word, err := dawg.RandomPath("te")
+		if err != nil || !strings.HasPrefix(word, "te") || !dawg.Check(word) {
+			t.Fatal("RandomPath failed")
+		}
+		found[word]++
+	}
+	// Each of the 3 words should be drawn about 333 times
+	if len(found) != 3 || found["test"] < 200 || found["tests"] < 200 || found["team"] < 200 {
+		t.Error("RandomPath distribution failed")
+	}
+
+	if word, err := dawg.RandomPath("note"); err != nil || word != "note" {
+		t.Error("RandomPath failed")
+	}
+	if _, err := dawg.RandomPath("x"); err == nil {
+		t.Error("RandomPath should fail without word starting with the prefix")
+	}
+}
+
+func TestFindRandomWordWithPrefix(t *testing.T) {
+	dawg := CreateDAWG([]string{"quarter", "quickly", "quietly", "quit", "queen", "banquet", "qat"})
+
+	found := make(map[string]int)
+	for i := 0; i < 900; i++ {
+		word, err := dawg.FindRandomWordWithPrefix("q", 7)
+		if err != nil || !strings.HasPrefix(word, "q") || len(word) != 7 || !dawg.Contains(word) {
+			t.Fatal("FindRandomWordWithPrefix failed")
+		}
+		found[word]++
+	}
+	// Each of the 3 words should be drawn about 300 times
+	if len(found) != 3 || found["quarter"] < 200 || found["quickly"] < 200 || found["quietly"] < 200 {
+		t.Error("FindRandomWordWithPrefix distribution failed")
+	}
+
+	if word, err := dawg.FindRandomWordWithPrefix("qui", 4); err != nil || word != "quit" {
+		t.Error("FindRandomWordWithPrefix failed")
+	}
+	if word, err := dawg.FindRandomWordWithPrefix("qat", 3); err != nil || word != "qat" {
+		t.Error("FindRandomWordWithPrefix of the prefix failed")
+	}
+	for _, test := range []struct {
+		prefix string
+		length int
+	}{{"q", 6}, {"x", 3}, {"quit", 2}} {
+		if _, err := dawg.FindRandomWordWithPrefix(test.prefix, test.length); err == nil {
+			t.Error("FindRandomWordWithPrefix should fail without word")
+		}
+	}
+	if _, err := dawg.FindRandomWord(6); err == nil {
+		t.Error("FindRandomWord should fail without word of the size")
+	}
+}
+
+func TestWithRand(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tests", "team", "note", "no", "nest", "rest"})
+
+	draw := func(seed int64) (words []string) {
+		source := dawg.WithRand(rand.New(rand.NewSource(seed)))
+		for i := 0; i < 20; i++ {
+			path, _ := source.RandomPath("")
+			word, _ := source.FindRandomWord(4)
+			prefixed, _ := source.FindRandomWordWithPrefix("te", 4)
+			words = append(words, path, word, prefixed)
+		}
+		return
+	}
+	first, second, other := draw(1), draw(1), draw(2)
+	if !slices.Equal(first, second) || slices.Equal(first, other) {
+		t.Error("WithRand failed")
+	}
+	for i := 0; i < len(first); i += 3 {
+		if !dawg.Contains(first[i]) || len(first[i+1]) != 4 || !strings.HasPrefix(first[i+2], "te") {
+			t.Error("WithRand failed")
+		}
+	}
+}
+
+func TestSampleUniform(t *testing.T) {
+	// Most words are under "a", a random walk choosing among the letters would favor "b"
+	dawg := CreateDAWG([]string{"aa", "ab", "ac", "ad", "ae", "af", "b"})
+
+	found := make(map[string]int)
+	for i := 0; i < 1400; i++ {
+		sample := dawg.SampleUniform(2)
+		if len(sample) != 2 || sample[0] == sample[1] || !dawg.Contains(sample[0]) || !dawg.Contains(sample[1]) {
+			t.Fatal("SampleUniform failed")
+		}
+		found[sample[0]]++
+	}
+	// Each word should be drawn first about 200 times
+	for _, count := range found {
+		if count < 130 || count > 270 {
+			t.Error("SampleUniform distribution failed")
+		}
+	}
+
+	all := dawg.WithRand(rand.New(rand.NewSource(1))).SampleUniform(10)
+	slices.Sort(all)
+	if !slices.Equal(all, slices.Collect(dawg.Words())) || len(dawg.SampleUniform(0)) != 0 || len(dawg.SampleUniform(-1)) != 0 {
+		t.Error("SampleUniform of all the words failed")
+	}
+}
+
+func TestSampleByFrequency(t *testing.T) {
+	dawg := CreateDAWGWithFrequencies(map[string]uint64{"the": 90, "a": 10, "zebra": 0})
+	sample, err := dawg.WithRand(rand.New(rand.NewSource(1))).SampleByFrequency(1000)
+	found := make(map[string]int)
+	for _, word := range sample {
+		found[word]++
+	}
+	if err != nil || len(sample) != 1000 || found["zebra"] != 0 || found["the"] < 850 || found["a"] < 50 {
+		t.Error("SampleByFrequency failed")
+	}
+
+	if _, err := CreateDAWG([]string{"the"}).SampleByFrequency(1); err == nil {
+		t.Error("SampleByFrequency without frequencies should fail")
+	}
+}
+
+func TestFindRandomWordMatching(t *testing.T) {
+	dawg := CreateDAWG([]string{"table", "cable", "sable", "tables", "fable", "tabby", "able"})
+
+	found := make(map[string]int)
+	for i := 0; i < 900; i++ {
+		word, err := dawg.FindRandomWordMatching("[^s]able")
+		if err != nil {
+			t.Fatal("FindRandomWordMatching failed")
+		}
+		found[word]++
+	}
+	// Each of the 3 words should be drawn about 300 times
+	if len(found) != 3 || found["table"] < 200 || found["cable"] < 200 || found["fable"] < 200 {
+		t.Error("FindRandomWordMatching distribution failed")
+	}
+
+	if word, err := dawg.WithRand(rand.New(rand.NewSource(1))).FindRandomWordMatching("_a_b_"); err != nil || word != "tabby" {
+		t.Error("FindRandomWordMatching failed")
+	}
+	for _, pattern := range []string{"[xyz]able", "_______", "t[ab"} {
+		if _, err := dawg.FindRandomWordMatching(pattern); err == nil {
+			t.Error("FindRandomWordMatching should fail without word matching the pattern")
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package dawg
+
+import "sort"
+
+// Ranker scores the words found by a search or suggested by Suggest, to order them with other signals
+// than the edit distance (frequency, recency, domain-specific weights...).
+// The lower the score, the better the word.
+type Ranker interface {
+	Score(candidate string, distance int) float64
+}
+
+// RankerFunc is a function used as a Ranker
+type RankerFunc func(candidate string, distance int) float64
+
+func (fn RankerFunc) Score(candidate string, distance int) float64 {
+	return fn(candidate, distance)
+}
+
+// Sort the matches by score of the ranker, distance, then with compare, and keep the maxResults
+// first ones (all of them if maxResults <= 0)
+func rankMatches(matches []Match, ranker Ranker, maxResults int, compare func(a string, b string) int) []Match {
+	scores := make(map[string]float64, len(matches))
+	for _, match := range matches {
+		scores[match.Word] = ranker.Score(match.Word, match.Distance)
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if scoreI, scoreJ := scores[matches[i].Word], scores[matches[j].Word]; scoreI != scoreJ {
+			return scoreI < scoreJ
+		}
+		if matches[i].Distance != matches[j].Distance {
+			return matches[i].Distance < matches[j].Distance
+		}
+		return compare(matches[i].Word, matches[j].Word) < 0
+	})
+	if maxResults > 0 && len(matches) > maxResults {
+		matches = matches[:maxResults]
+	}
+	return matches
+}
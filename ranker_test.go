@@ -0,0 +1,31 @@
+package dawg
+
+import "testing"
+
+func TestRanker(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "note", "the"})
+	popularity := map[string]float64{"nest": 10, "note": 5}
+	ranker := RankerFunc(func(candidate string, distance int) float64 {
+		return float64(distance) - popularity[candidate]
+	})
+
+	// "nest" is popular enough to come before the exact match, even if it is found after it
+	matches, err := dawg.SearchWithOptions("test", SearchOptions{Distance: 1, MaxResults: 2, Ranker: ranker})
+	if err != nil || len(matches) != 2 || matches[0] != newMatch("nest", 1, 4) || matches[1] != newMatch("test", 0, 4) {
+		t.Error("SearchWithOptions with a ranker failed:", matches)
+	}
+	// SearchFunc gives the words by distance
+	var first Match
+	dawg.SearchFunc("test", SearchOptions{Distance: 1, Ranker: ranker}, func(match Match) bool {
+		first = match
+		return false
+	})
+	if first != newMatch("test", 0, 4) {
+		t.Error("SearchFunc with a ranker failed:", first)
+	}
+
+	suggestions := dawg.SuggestWithOptions("nost", 3, SuggestOptions{Ranker: ranker, Distance: func(int) int { return 2 }})
+	if len(suggestions) != 3 || suggestions[0].Word != "nest" || suggestions[0].Score != -9 || suggestions[1].Word != "note" || suggestions[2].Word != "test" {
+		t.Error("SuggestWithOptions with a ranker failed:", suggestions)
+	}
+}
@@ -0,0 +1,97 @@
+package dawg
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Number of shared suffixes kept in a Report
+const reportSharedSuffixes = 10
+
+// Report describes how much the DAWG shares the suffixes of its words, to evaluate a dictionary
+// (whether a normalization of the words makes them share more, for example)
+type Report struct {
+	TrieNodes    uint64  // Number of nodes of the trie of the words, before its minimization
+	Nodes        uint64  // Number of nodes of the DAWG
+	Edges        uint64  // Number of transitions of the DAWG
+	Words        uint64  // Number of words of the DAWG
+	SharingRatio float64 // Part of the nodes of the trie removed by sharing the suffixes (0 to 1)
+	BytesPerWord float64 // Approximate memory used by the DAWG per word (see ApproxMemory)
+	// The states sharing the most trie nodes, by decreasing weight
+	SharedSuffixes []SharedSuffix
+}
+
+// SharedSuffix is a state of the DAWG reached by several prefixes, whose suffixes are shared
+type SharedSuffix struct {
+	State    uint     // Number of the state (see StateOf)
+	Prefix   string   // First prefix reaching the state, in lexicographic order
+	Prefixes uint64   // Number of prefixes reaching the state
+	Suffixes []string // First suffixes of the words under the state, in lexicographic order (at most 5)
+	Words    uint64   // Number of suffixes of the words under the state
+	Weight   uint64   // Number of trie nodes saved by sharing the state: its trie nodes for each prefix but one
+}
+
+// Compute the report on the sharing of the suffixes of the DAWG. The trie nodes are counted from the DAWG (the
+// prefixes of the words), so the report of a DAWG loaded from a file is complete too. The final states without
+// letters (the ends of the words) are not reported as shared suffixes.
+func (dawg *DAWG) Report() Report {
+	states, numbers := numberStates(dawg.initialState)
+	report := Report{Nodes: uint64(len(states)), Words: dawg.WordsCount()}
+
+	// The number of paths from the initial state to each state (its prefixes), counted in topological order,
+	// and the number of nodes of the trie under each state
+	var order []*state // The states after the states under them
+	trieNodes := make(map[*state]uint64, len(states))
+	report.TrieNodes = countTrieNodes(dawg.initialState, trieNodes, &order)
+	prefixes := map[*state]uint64{dawg.initialState: 1}
+	for i := len(order) - 1; i >= 0; i-- {
+		report.Edges += uint64(len(order[i].letters))
+		for _, curLetter := range order[i].letters {
+			prefixes[curLetter.state] += prefixes[order[i]]
+		}
+	}
+
+	report.SharingRatio = 1 - float64(report.Nodes)/float64(report.TrieNodes)
+	if report.Words > 0 {
+		report.BytesPerWord = float64(dawg.ApproxMemory()) / float64(report.Words)
+	}
+
+	var shared []*state
+	for _, curState := range states {
+		if prefixes[curState] > 1 && len(curState.letters) > 0 {
+			shared = append(shared, curState)
+		}
+	}
+	weight := func(curState *state) uint64 {
+		return (prefixes[curState] - 1) * trieNodes[curState]
+	}
+	slices.SortStableFunc(shared, func(a *state, b *state) int {
+		return cmp.Compare(weight(b), weight(a))
+	})
+	for _, curState := range shared[:min(len(shared), reportSharedSuffixes)] {
+		suffix := SharedSuffix{State: uint(numbers[curState]), Prefixes: prefixes[curState], Words: curState.wordsCount, Weight: weight(curState)}
+		walker := &stateWalker{target: curState, reaches: make(map[*state]bool)}
+		suffix.Prefix = string(walker.firstPrefix(dawg.initialState, nil))
+		walkSorted(curState, nil, func(word []rune) bool {
+			suffix.Suffixes = append(suffix.Suffixes, string(word))
+			return len(suffix.Suffixes) < 5
+		})
+		report.SharedSuffixes = append(report.SharedSuffixes, suffix)
+	}
+	return report
+}
+
+// Get the number of nodes of the trie under the state (memoized in trieNodes), the state itself included,
+// and append the states to order after the states under them
+func countTrieNodes(curState *state, trieNodes map[*state]uint64, order *[]*state) uint64 {
+	if nodes, ok := trieNodes[curState]; ok {
+		return nodes
+	}
+	nodes := uint64(1)
+	for _, curLetter := range curState.letters {
+		nodes += countTrieNodes(curLetter.state, trieNodes, order)
+	}
+	trieNodes[curState] = nodes
+	*order = append(*order, curState)
+	return nodes
+}
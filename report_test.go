@@ -0,0 +1,45 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestReport(t *testing.T) {
+	words := []string{"walk", "walked", "walking", "walks", "talk", "talked", "talking", "talks", "jump", "jumped", "jumping", "jumps"}
+	dawg := CreateDAWG(words)
+	report := dawg.Report()
+
+	stats := dawg.Stats()
+	if report.TrieNodes != stats.TrieNodes || report.Nodes != stats.Nodes || report.Edges != stats.Transitions || report.Words != 12 {
+		t.Fatal("Report failed", report)
+	}
+	if report.SharingRatio != 1-float64(report.Nodes)/float64(report.TrieNodes) || report.BytesPerWord != float64(dawg.ApproxMemory())/12 {
+		t.Error("Report ratios failed", report)
+	}
+
+	// The suffixes "", "ed", "ing" and "s" are shared by the 3 stems, "alk" by "w" and "t"
+	if len(report.SharedSuffixes) == 0 {
+		t.Fatal("Report shared suffixes failed")
+	}
+	top := report.SharedSuffixes[0]
+	if top.Prefix != "jump" || top.Prefixes != 3 || top.Words != 4 || !slices.Equal(top.Suffixes, []string{"", "ed", "ing", "s"}) || top.Weight != 2*7 {
+		t.Error("Report top shared suffix failed", top)
+	}
+	if id, _ := dawg.StateOf("walk"); top.State != id {
+		t.Error("Report state failed", top)
+	}
+	for i, suffix := range report.SharedSuffixes {
+		if suffix.Prefixes < 2 || i > 0 && suffix.Weight > report.SharedSuffixes[i-1].Weight {
+			t.Error("Report order failed", suffix)
+		}
+	}
+
+	// The trie is counted from the DAWG, even when it was loaded
+	if loaded := dawg.Sub(""); loaded.Report().TrieNodes != report.TrieNodes {
+		t.Error("Report of a DAWG without trie failed")
+	}
+	if empty := CreateDAWG(nil).Report(); empty.Words != 0 || empty.BytesPerWord != 0 || len(empty.SharedSuffixes) != 0 {
+		t.Error("Report of an empty DAWG failed", empty)
+	}
+}
@@ -0,0 +1,67 @@
+package dawg
+
+// BidirectionalDAWG is a DAWG with the DAWG of its reversed words, to answer the queries on the ends
+// of the words (rhymes, suffixes) as efficiently as the queries on their beginnings.
+// Changing the DAWG after the reversed DAWG is built is not supported.
+type BidirectionalDAWG struct {
+	dawg     *DAWG
+	reversed *DAWG // The reversed words of dawg
+}
+
+// Build the DAWG of the reversed words of the DAWG
+func (dawg *DAWG) WithReverse() *BidirectionalDAWG {
+	builder := newBuilder(BuildOptions{})
+	walkSorted(dawg.initialState, nil, func(word []rune) bool {
+		builder.add(reverse(word), 0) // Can't fail without options
+		return true
+	})
+	return &BidirectionalDAWG{dawg: dawg, reversed: builder.dawg()}
+}
+
+// Get the DAWG of the words
+func (bidirectional *BidirectionalDAWG) Forward() *DAWG {
+	return bidirectional.dawg
+}
+
+// Get the DAWG of the reversed words
+func (bidirectional *BidirectionalDAWG) Reversed() *DAWG {
+	return bidirectional.reversed
+}
+
+// Check if the word is in the DAWG
+func (bidirectional *BidirectionalDAWG) Contains(word string) bool {
+	return bidirectional.dawg.Contains(word)
+}
+
+// Check if at least one word of the DAWG ends with the suffix
+func (bidirectional *BidirectionalDAWG) HasSuffix(suffix string) bool {
+	return bidirectional.reversed.HasPrefix(reverse([]rune(suffix)))
+}
+
+// Count the words of the DAWG ending with the suffix (the suffix itself included)
+func (bidirectional *BidirectionalDAWG) CountWithSuffix(suffix string) int {
+	return bidirectional.reversed.CountWithPrefix(reverse([]rune(suffix)))
+}
+
+// Get the words of the DAWG ending with the suffix, sorted by their reversed words (so the words
+// sharing the longest endings are next to each other: "ring", "bring", "string", "sing", ...).
+// At most max words are returned (all of them if max <= 0).
+func (bidirectional *BidirectionalDAWG) EndsWith(suffix string, max int) []string {
+	words := bidirectional.reversed.Completions(reverse([]rune(suffix)), max)
+	for i, word := range words {
+		words[i] = reverse([]rune(word))
+	}
+	return words
+}
+
+// Get the words of the DAWG ending with a suffix within levenshteinDistance of the suffix, letters being
+// substituted, added or deleted, sorted by the distance of their suffix, then by their reversed words
+// (Matched being the number of bytes of the suffix of the word matched).
+// At most maxResults words are returned (all of them if maxResults <= 0).
+func (bidirectional *BidirectionalDAWG) SearchSuffix(suffix string, levenshteinDistance int, maxResults int) ([]Match, error) {
+	matches, err := bidirectional.reversed.SearchPrefixTolerant(reverse([]rune(suffix)), levenshteinDistance, maxResults)
+	for i := range matches {
+		matches[i].Word = reverse([]rune(matches[i].Word))
+	}
+	return matches, err
+}
@@ -0,0 +1,29 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWithReverse(t *testing.T) {
+	dawg := CreateDAWG([]string{"ring", "bring", "string", "sing", "song", "singer", "rang"})
+	bidirectional := dawg.WithReverse()
+
+	if words := bidirectional.EndsWith("ing", 0); !slices.Equal(words, []string{"ring", "bring", "string", "sing"}) {
+		t.Error("EndsWith failed:", words)
+	}
+	if words := bidirectional.EndsWith("ing", 2); len(words) != 2 {
+		t.Error("EndsWith max failed:", words)
+	}
+	if !bidirectional.HasSuffix("ger") || bidirectional.HasSuffix("gs") || bidirectional.CountWithSuffix("ng") != 6 {
+		t.Error("HasSuffix or CountWithSuffix failed")
+	}
+	if !bidirectional.Contains("song") || bidirectional.Forward() != dawg || bidirectional.Reversed().WordsCount() != dawg.WordsCount() {
+		t.Error("BidirectionalDAWG failed")
+	}
+
+	matches, err := bidirectional.SearchSuffix("ong", 1, 0)
+	if err != nil || len(matches) != 6 || matches[0] != (Match{Word: "song", End: 3, Matched: 3}) || matches[1].Word != "rang" || matches[1].Distance != 1 {
+		t.Error("SearchSuffix failed:", matches)
+	}
+}
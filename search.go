@@ -0,0 +1,374 @@
+package dawg
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SearchOptions configures an approximate search
+type SearchOptions struct {
+	Distance    int  // Maximum number of edits between the searched word and the words found
+	MaxResults  int  // Maximum number of words returned (Unlimited, or any value <= 0, for no limit)
+	AllowAdd    bool // The words found can have letters inserted
+	AllowDelete bool // The words found can have letters deleted
+	Transpose   bool // Swapping two adjacent letters ("teh" -> "the") counts as one edit (Damerau-Levenshtein distance)
+	MinLength   int  // Minimum length of the words found, in runes (0 for no limit)
+	MaxLength   int  // Maximum length of the words found, in runes (0 for no limit)
+	Prefix      bool // The searched word only has to match a prefix of the words found (the Distance being the one of the prefix)
+	ExactPrefix int  // Number of letters at the start of the searched word which can't be edited (the first letter for 1, as spell checkers often do)
+	// Maximum numbers of substitutions, insertions (letters added, with AllowAdd) and deletions (with AllowDelete) of
+	// the words found, besides Distance which bounds their sum: 0 for no other limit than Distance, -1 for none.
+	// The transpositions only count in Distance. A search without AllowAdd, AllowDelete, Transpose, Prefix nor
+	// FreeSeparators only substitutes letters (Hamming distance, for OCR or DNA): the words found have the length
+	// of the searched word. The searches on graphemes ignore them.
+	MaxSubstitutions int
+	MaxInsertions    int
+	MaxDeletions     int
+	// Normalization of the searched word, to match the normalization of the words of the DAWG (see BuildOptions)
+	Normalize       func(word string) string
+	StripDiacritics bool
+	FoldCase        bool
+	Separators      string
+	// The separators of the phrases (Separators) can be inserted into or deleted from the searched word, or replaced by
+	// another separator, without counting as edits: "newyork" and "new-york" find "new york" at distance 0, while
+	// "ew york" is at distance 1. CountMatches counts the same words, the searches on graphemes ignore it.
+	FreeSeparators bool
+	// The letters of the searched word match the letters of the words with another case ("paris" finds "Paris"
+	// at distance 0), the words found keeping the case of the DAWG. Unlike FoldCase, the DAWG doesn't need to be
+	// folded: the letters of the graph are compared with the simple case folding of Unicode ("ß" doesn't match "SS").
+	IgnoreCase bool
+	// The edits are counted on graphemes instead of letters (see Graphemes): replacing "é" written with a combining
+	// accent by "e", or an emoji sequence by another emoji, is a single substitution. The search walks all the words
+	// within Distance before giving them, so it is slower than a search on letters.
+	Graphemes bool
+	// If not nil, the words of a same distance are sorted with this function instead of lexicographically (the order of
+	// the runes): collate.New(language.German).CompareString of golang.org/x/text sorts "Ärger" before "Zorn" for example.
+	// The words equal for the function are sorted lexicographically. CompletionsWithCompare sorts the completions with it.
+	Compare func(a string, b string) int
+	// Search with parallel workers, sharing the subtrees of the initial state, to bound the latency of an expensive
+	// search (a high Distance on a large DAWG). The words found are the same, in the same order.
+	// It is ignored with Graphemes.
+	Parallel bool
+	// If not nil, called with the prefixes of the words while the DAWG is walked: the words starting with a prefix for which
+	// it returns false are skipped without being walked (to only allow some letters, or skip a part of the DAWG).
+	// It may be called several times with the same prefix, and by several goroutines at the same time with Parallel.
+	Filter func(prefix string) bool
+	// Flags of the words found (see CreateDAWGWithFlags): the words must have all the RequireFlags and none of the
+	// ExcludeFlags. The words are filtered as they are found, so the words excluded don't take the place of other words.
+	RequireFlags uint32
+	ExcludeFlags uint32
+	// If not nil, called with each word as soon as it is found, before the words are sorted: the whole search stops as
+	// soon as it returns true (an exact match was found, or the latency budget is spent, for example), the words found
+	// before being given as usual (with Graphemes, the words are not found by increasing distance). CountMatches ignores it.
+	Stop func(match Match) bool
+	// If not nil, the words are sorted by score of the Ranker (then by distance) instead of by distance.
+	// All the words within Distance are searched before keeping the MaxResults best ones.
+	// SearchFunc and SearchIter ignore it, as they give the words as they are found.
+	Ranker Ranker
+	// Maximum duration of the search (0 for no limit): once it is spent, the search stops and returns the words found
+	// so far, without error. The words are searched by increasing distance, so the closest words are found first: the
+	// words of the lower distances are all given, with the words of the distance being searched found so far.
+	// SearchPartial tells if the search was truncated. CountMatches ignores it.
+	MaxDuration time.Duration
+}
+
+// Error of the searches stopped by SearchOptions.MaxDuration, their words being given
+var errTruncated = errors.New("Search truncated.")
+
+// Unlimited can be given as the maximum number of words returned by a search (SearchOptions.MaxResults, or the
+// maxResults or max arguments), to get all the words found
+const Unlimited = 0
+
+// Match is a word found by an approximate search, with the parts of the input and of the word aligned by the edits
+type Match struct {
+	Word     string
+	Distance int // Number of edits between the searched word and Word (only using the allowed edits)
+	// Span of the input (once normalized) consumed by Word, in bytes: the whole input, but for the suggestions
+	// joining two words of a phrase (see Suggest), where it is the span of the two words joined
+	Start int
+	End   int
+	// Number of bytes of Word matched by the input: len(Word), but in prefix mode (SearchOptions.Prefix,
+	// SearchPrefixTolerant) where only a prefix of Word is matched, the rest completing it
+	Matched int
+}
+
+// Get the match of the word with the whole input, of inputSize bytes
+func newMatch(word string, distance int, inputSize int) Match {
+	return Match{Word: word, Distance: distance, End: inputSize, Matched: len(word)}
+}
+
+// Get the number of bytes of the runes encoded in UTF-8
+func runesSize(runes []rune) int {
+	size := 0
+	for _, char := range runes {
+		size += utf8.RuneLen(char)
+	}
+	return size
+}
+
+// Approximate string searching in the DAWG.
+// Letters can always be substituted, the other edits depend on the options.
+// The words are sorted by distance, then lexicographically, and the MaxResults first ones are returned.
+// Each word is returned only once, with its lowest distance, whatever the number of edit sequences leading
+// to it ("tst" -> "test" by inserting either letter of "es"), so the duplicates don't take the place of other words.
+func (dawg *DAWG) SearchWithOptions(word string, options SearchOptions) ([]Match, error) {
+	matches, _, err := dawg.search(context.Background(), word, options)
+	return matches, err
+}
+
+// Same as SearchWithOptions, telling if the search was truncated because options.MaxDuration was spent
+// (the words found being the closest ones found before, see SearchOptions.MaxDuration)
+func (dawg *DAWG) SearchPartial(word string, options SearchOptions) (matches []Match, truncated bool, err error) {
+	return dawg.search(context.Background(), word, options)
+}
+
+// Same as SearchWithOptions, giving the words to fn as they are found instead of returning them.
+// The words are found by increasing distance: all the words at a given distance are given to fn
+// (lexicographically) before the words at the next distance are searched, each word only once.
+// The search stops as soon as fn returns false.
+func (dawg *DAWG) SearchFunc(word string, options SearchOptions, fn func(match Match) bool) error {
+	return dawg.observedSearchFunc(context.Background(), word, options, fn)
+}
+
+// Iterate over the words found by an approximate search, in the order of SearchFunc.
+// Breaking out of the loop stops the search. If the search fails, its error is given last, with an empty Match:
+//
+//	for match, err := range dawg.SearchIter(word, options) {
+//		if err != nil {
+//			// Do something
+//		}
+//	}
+func (dawg *DAWG) SearchIter(word string, options SearchOptions) iter.Seq2[Match, error] {
+	return dawg.SearchIterContext(context.Background(), word, options)
+}
+
+// Same as SearchIter, the search failing with the error of the context as soon as it is done
+func (dawg *DAWG) SearchIterContext(ctx context.Context, word string, options SearchOptions) iter.Seq2[Match, error] {
+	return func(yield func(Match, error) bool) {
+		stopped := false
+		err := dawg.observedSearchFunc(ctx, word, options, func(match Match) bool {
+			stopped = !yield(match, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield(Match{}, err)
+		}
+	}
+}
+
+// Same as SearchWithOptions, appending the words found to dst as UTF-8 bytes instead of returning them as strings,
+// for the searches run in tight loops: the byte slices of dst after its length (from a previous call, with
+// dst[:0]) are reused, so once they are large enough the words don't allocate. The distances are not given.
+// With Graphemes, Parallel, Compare, Stop, Ranker, MaxDuration or flags (RequireFlags, ExcludeFlags), the words
+// are found as strings by SearchWithOptions before being appended.
+func (dawg *DAWG) AppendMatches(dst [][]byte, word string, options SearchOptions) [][]byte {
+	if options.Graphemes || options.Parallel || options.Compare != nil || options.Stop != nil || options.Ranker != nil || options.filtersFlags() || options.MaxDuration > 0 {
+		matches, _ := dawg.SearchWithOptions(word, options)
+		for _, match := range matches {
+			dst = appendBuffer(dst, match.Word)
+		}
+		return dst
+	}
+	start := time.Now()
+	word = options.normalize(word)
+	matcher := getMatcher(word, options)
+	defer putMatcher(matcher)
+	matcher.appended = dst
+	for distance := 0; distance <= options.Distance; distance++ {
+		matcher.limit = 0
+		if options.MaxResults > 0 {
+			if matcher.limit = options.MaxResults - (len(matcher.appended) - len(dst)); matcher.limit == 0 {
+				break
+			}
+		}
+		matcher.appendAll(context.Background(), dawg.initialState, distance) // Can't fail without a deadline
+	}
+	dawg.observeSearch(start, options.Distance, len(matcher.appended)-len(dst))
+	return matcher.appended
+}
+
+// Append the word to dst, reusing the byte slice after the length of dst if any
+func appendBuffer(dst [][]byte, word string) [][]byte {
+	if len(dst) < cap(dst) {
+		dst = dst[:len(dst)+1]
+		dst[len(dst)-1] = append(dst[len(dst)-1][:0], word...)
+		return dst
+	}
+	return append(dst, []byte(word))
+}
+
+// Same as SearchWithOptions, aborted with the error of the context as soon as it is done
+// (to bound the latency of the searches with a high distance on a large DAWG)
+func (dawg *DAWG) SearchContext(ctx context.Context, word string, options SearchOptions) ([]Match, error) {
+	matches, _, err := dawg.search(ctx, word, options)
+	return matches, err
+}
+
+// Get the words of the DAWG starting with a prefix within levenshteinDistance of the word ("restau" finds
+// "restaurant" and "restaurants"), letters being substituted, added or deleted, for a search-as-you-type.
+// The words are sorted by the distance of their prefix, then lexicographically, and the maxResults first ones
+// are returned (all of them if maxResults <= 0).
+func (dawg *DAWG) SearchPrefixTolerant(word string, levenshteinDistance int, maxResults int) ([]Match, error) {
+	return dawg.SearchWithOptions(word, SearchOptions{Distance: levenshteinDistance, MaxResults: maxResults, AllowAdd: true, AllowDelete: true, Prefix: true})
+}
+
+// Same as Search, with the Damerau-Levenshtein distance: swapping two adjacent letters ("teh" -> "the") counts as one edit
+//
+// Deprecated: use SearchWithOptions with Transpose.
+func (dawg *DAWG) SearchWithTranspositions(word string, levenshteinDistance int, maxResults int, allowAdd bool, allowDelete bool) (words []string, err error) {
+	matches, err := dawg.SearchWithOptions(word, SearchOptions{Distance: levenshteinDistance, MaxResults: maxResults, AllowAdd: allowAdd, AllowDelete: allowDelete, Transpose: true})
+	return matchedWords(matches), err
+}
+
+// Same as Search, with the distance of each word found
+//
+// Deprecated: use SearchWithOptions.
+func (dawg *DAWG) SearchWithDistance(word string, levenshteinDistance int, maxResults int, allowAdd bool, allowDelete bool) (matches []Match, err error) {
+	return dawg.SearchWithOptions(word, SearchOptions{Distance: levenshteinDistance, MaxResults: maxResults, AllowAdd: allowAdd, AllowDelete: allowDelete})
+}
+
+// Get the MaxResults words with the lowest distances, sorted by distance then lexicographically, each word only once
+// (or the MaxResults best words of the Ranker), and if the search was truncated by options.MaxDuration
+func (dawg *DAWG) search(ctx context.Context, word string, options SearchOptions) (matches []Match, truncated bool, err error) {
+	start := time.Now()
+	searchOptions := options
+	if options.Ranker != nil {
+		searchOptions.MaxResults = 0
+	}
+	matches = []Match{}
+	err = dawg.searchFunc(ctx, word, searchOptions, func(match Match) bool {
+		matches = append(matches, match)
+		return true
+	})
+	if truncated = err == errTruncated; truncated {
+		err = nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if options.Ranker != nil {
+		matches = rankMatches(matches, options.Ranker, options.MaxResults, options.compareWords)
+	}
+	dawg.observeSearch(start, options.Distance, len(matches))
+	return
+}
+
+// Same as searchFunc, observed by the metrics of the DAWG, if any
+func (dawg *DAWG) observedSearchFunc(ctx context.Context, word string, options SearchOptions, fn func(match Match) bool) error {
+	if dawg.metrics == nil {
+		return ignoreTruncation(dawg.searchFunc(ctx, word, options, fn))
+	}
+	start, results := time.Now(), 0
+	err := dawg.searchFunc(ctx, word, options, func(match Match) bool {
+		results++
+		return fn(match)
+	})
+	dawg.observeSearch(start, options.Distance, results)
+	return ignoreTruncation(err)
+}
+
+// Get the error of a search, nil if it was truncated by SearchOptions.MaxDuration (its words being given)
+func ignoreTruncation(err error) error {
+	if err == errTruncated {
+		return nil
+	}
+	return err
+}
+
+// Call fn for each of the MaxResults words with the lowest distances, sorted by distance then lexicographically,
+// each word only once. The search stops as soon as fn returns false.
+// The distance is increased until enough words are found, so the cheap searches with a low distance
+// avoid most of the expensive ones, and the words of a distance are given to fn before searching the next distance.
+func (dawg *DAWG) searchFunc(ctx context.Context, word string, options SearchOptions, fn func(match Match) bool) error {
+	word = options.normalize(word)
+	if options.MaxDuration > 0 {
+		// The traversals check their context regularly, its cause telling if the budget was spent
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, options.MaxDuration, errTruncated)
+		defer cancel()
+	}
+	if options.Graphemes {
+		return dawg.searchGraphemes(ctx, word, options, fn)
+	}
+	// A word too long to match any word of the DAWG would only make the search recurse deeper
+	minSize := utf8.RuneCountInString(word)
+	if options.AllowDelete {
+		minSize -= options.Distance
+	}
+	if options.FreeSeparators {
+		for _, char := range word {
+			if strings.ContainsRune(options.Separators, char) { // Deleted for free
+				minSize--
+			}
+		}
+	}
+	if minSize > dawg.maxWordSize {
+		return nil
+	}
+	matcher := getMatcher(word, options)
+	defer putMatcher(matcher)
+	if options.filtersFlags() {
+		matcher.flagged = dawg
+	}
+	emitted := 0
+	for distance := 0; distance <= options.Distance; distance++ {
+		// The words not found with a lower distance are exactly at this distance
+		// Only the words which can be given to fn are kept
+		matcher.limit = 0
+		if options.MaxResults > 0 {
+			matcher.limit = options.MaxResults - emitted
+		}
+		var err error
+		if options.Parallel {
+			err = dawg.collectParallel(ctx, matcher, word, options, distance)
+		} else {
+			err = matcher.collectAll(ctx, dawg.initialState, distance)
+		}
+		var end error // Returned once the words of this distance are given
+		if err != nil {
+			if context.Cause(ctx) != errTruncated {
+				return err
+			}
+			end = errTruncated
+			matcher.sortMatches() // The words of this distance found before the end of the budget
+		}
+		for _, match := range matcher.matches {
+			if !fn(match) {
+				return end
+			}
+			if emitted++; options.MaxResults > 0 && emitted >= options.MaxResults {
+				return end
+			}
+		}
+		if end != nil || matcher.stopped {
+			return end
+		}
+	}
+	return nil
+}
+
+// Compare two words found, with the Compare function of the options if any, then lexicographically
+func (options SearchOptions) compareWords(a string, b string) int {
+	if options.Compare != nil {
+		if order := options.Compare(a, b); order != 0 {
+			return order
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// Get the words of the matches
+func matchedWords(matches []Match) []string {
+	if matches == nil {
+		return nil
+	}
+	words := make([]string, len(matches))
+	for i, match := range matches {
+		words[i] = match.Word
+	}
+	return words
+}
@@ -0,0 +1,659 @@
+package dawg
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode"
+)
+
+// Word and distance of a match, compared without its spans
+type wordDistance struct {
+	Word     string
+	Distance int
+}
+
+// Get the words and the distances of the matches
+func wordDistances(matches []Match) []wordDistance {
+	result := make([]wordDistance, len(matches))
+	for i, match := range matches {
+		result[i] = wordDistance{match.Word, match.Distance}
+	}
+	return result
+}
+
+func TestSearchWithOptions(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "the"})
+
+	matches, err := dawg.SearchWithOptions("test", SearchOptions{Distance: 1})
+	if err != nil || len(matches) != 3 || matches[0] != newMatch("test", 0, 4) || matches[1] != newMatch("nest", 1, 4) || matches[2] != newMatch("tese", 1, 4) {
+		t.Error("SearchWithOptions failed:", matches)
+	}
+	matches, err = dawg.SearchWithOptions("test", SearchOptions{Distance: 1, AllowAdd: true, AllowDelete: true, MaxResults: 4})
+	if err != nil || len(matches) != 4 || matches[2].Word != "tes" || matches[3].Word != "tese" {
+		t.Error("SearchWithOptions failed:", matches)
+	}
+	matches, err = dawg.SearchWithOptions("teh", SearchOptions{Distance: 1, Transpose: true})
+	if err != nil || len(matches) != 2 || matches[0] != newMatch("tes", 1, 3) || matches[1] != newMatch("the", 1, 3) {
+		t.Error("SearchWithOptions failed:", matches)
+	}
+	if matches, err = dawg.SearchWithOptions("teh", SearchOptions{Distance: 1}); err != nil || len(matches) != 1 {
+		t.Error("SearchWithOptions failed:", matches)
+	}
+}
+
+func TestSearchContext(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "the"})
+
+	matches, err := dawg.SearchContext(context.Background(), "test", SearchOptions{Distance: 1})
+	if err != nil || len(matches) != 3 {
+		t.Error("SearchContext failed:", matches)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = dawg.SearchContext(ctx, "test", SearchOptions{Distance: 1}); err != context.Canceled {
+		t.Error("SearchContext should be canceled:", err)
+	}
+	ctx, cancel = context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+	if _, err = dawg.SearchContext(ctx, "test", SearchOptions{Distance: 1}); err != context.DeadlineExceeded {
+		t.Error("SearchContext should time out:", err)
+	}
+}
+
+func TestSearchFunc(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "the"})
+
+	var matches []Match
+	err := dawg.SearchFunc("test", SearchOptions{Distance: 1}, func(match Match) bool {
+		matches = append(matches, match)
+		return true
+	})
+	if err != nil || len(matches) != 3 || matches[0] != newMatch("test", 0, 4) || matches[2] != newMatch("tese", 1, 4) {
+		t.Error("SearchFunc failed:", matches)
+	}
+
+	matches = nil
+	err = dawg.SearchFunc("test", SearchOptions{Distance: 1}, func(match Match) bool {
+		matches = append(matches, match)
+		return len(matches) < 2
+	})
+	if err != nil || len(matches) != 2 || matches[1] != newMatch("nest", 1, 4) {
+		t.Error("SearchFunc should stop:", matches)
+	}
+
+	matches = nil
+	err = dawg.SearchFunc("test", SearchOptions{Distance: 1, MaxResults: 1}, func(match Match) bool {
+		matches = append(matches, match)
+		return true
+	})
+	if err != nil || len(matches) != 1 {
+		t.Error("SearchFunc failed:", matches)
+	}
+}
+
+func TestSearchIter(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "the"})
+
+	var matches []Match
+	for match, err := range dawg.SearchIter("test", SearchOptions{Distance: 1, AllowAdd: true}) {
+		if err != nil {
+			t.Fatal("SearchIter failed:", err)
+		}
+		matches = append(matches, match)
+	}
+	if len(matches) != 4 || matches[0] != newMatch("test", 0, 4) || matches[3] != newMatch("test2", 1, 4) {
+		t.Error("SearchIter failed:", matches)
+	}
+
+	var words []string
+	for match := range dawg.SearchIter("test", SearchOptions{Distance: 1}) {
+		if words = append(words, match.Word); len(words) == 2 {
+			break
+		}
+	}
+	if len(words) != 2 {
+		t.Error("SearchIter should stop:", words)
+	}
+
+	// The error of the search is given last
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var errs []error
+	for _, err := range dawg.SearchIterContext(ctx, "test", SearchOptions{Distance: 1}) {
+		errs = append(errs, err)
+	}
+	if len(errs) != 1 || errs[0] != context.Canceled {
+		t.Error("SearchIterContext of a canceled context failed:", errs)
+	}
+}
+
+func TestSearchLongWords(t *testing.T) {
+	long := strings.Repeat("abcdefghij", 2000)
+	dawg := CreateDAWG([]string{long, long + "k", "abc"})
+
+	matches, err := dawg.SearchWithOptions(long[1:]+"x", SearchOptions{Distance: 2, AllowAdd: true, AllowDelete: true})
+	if err != nil || len(matches) != 2 || matches[0] != newMatch(long, 2, len(long)) || matches[1] != newMatch(long+"k", 2, len(long)) {
+		t.Error("Search of long words failed")
+	}
+}
+
+func TestSearchLevenshtein(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	randomWord := func() string {
+		word := make([]rune, 1+r.Intn(6))
+		for i := range word {
+			word[i] = rune('a' + r.Intn(3))
+		}
+		return string(word)
+	}
+	words := make([]string, 200)
+	for i := range words {
+		words[i] = randomWord()
+	}
+	dawg := CreateDAWG(words)
+
+	for i := 0; i < 50; i++ {
+		query := randomWord()
+		expected := make(map[string]int)
+		for _, word := range words {
+			if distance := levenshtein([]rune(query), []rune(word)); distance <= 2 {
+				expected[word] = distance
+			}
+		}
+		matches, err := dawg.SearchWithOptions(query, SearchOptions{Distance: 2, AllowAdd: true, AllowDelete: true})
+		if err != nil || len(matches) != len(expected) {
+			t.Fatal("Search failed for", query, matches, expected)
+		}
+		for _, match := range matches {
+			if expected[match.Word] != match.Distance {
+				t.Error("Search failed for", query, match)
+			}
+		}
+	}
+}
+
+func TestSearchEditBudgets(t *testing.T) {
+	dawg := CreateDAWG([]string{"ACGT", "ACGA", "AGGA", "ACG", "ACGTT", "CGT", "TACGT"})
+
+	// Substitutions only (Hamming distance): the words found have the length of the searched word
+	options := SearchOptions{Distance: 2}
+	matches, _ := dawg.SearchWithOptions("ACGT", options)
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"ACGT", 0}, {"ACGA", 1}, {"AGGA", 2}}) {
+		t.Error("Hamming search failed", matches)
+	}
+	options = SearchOptions{Distance: 2, AllowAdd: true, AllowDelete: true, MaxSubstitutions: -1, MaxDeletions: 1}
+	matches, _ = dawg.SearchWithOptions("ACGT", options)
+	if !slices.Equal(matchedWords(matches), []string{"ACGT", "ACG", "ACGTT", "CGT", "TACGT", "ACGA"}) {
+		t.Error("Search without substitutions failed", matches)
+	}
+	options.MaxInsertions = -1
+	matches, _ = dawg.SearchWithOptions("ACGT", options)
+	if !slices.Equal(matchedWords(matches), []string{"ACGT", "ACG", "CGT"}) || dawg.CountMatches("ACGT", options) != 3 {
+		t.Error("Search with deletions only failed", matches)
+	}
+
+	// Compare with the lowest distance of the alignments within the budgets
+	r := rand.New(rand.NewSource(11))
+	randomWord := func() string {
+		word := make([]rune, 1+r.Intn(6))
+		for i := range word {
+			word[i] = rune('a' + r.Intn(3))
+		}
+		return string(word)
+	}
+	words := make([]string, 200)
+	for i := range words {
+		words[i] = randomWord()
+	}
+	dawg = CreateDAWG(words)
+	for i := 0; i < 50; i++ {
+		query := randomWord()
+		options := SearchOptions{Distance: 3, AllowAdd: true, AllowDelete: true, MaxSubstitutions: r.Intn(3) - 1, MaxInsertions: r.Intn(3) - 1, MaxDeletions: r.Intn(3) - 1}
+		expected := make(map[string]int)
+		for _, word := range words {
+			if distance := budgetedDistance([]rune(query), []rune(word), options); distance <= options.Distance {
+				expected[word] = distance
+			}
+		}
+		matches, err := dawg.SearchWithOptions(query, options)
+		if err != nil || len(matches) != len(expected) || dawg.CountMatches(query, options) != len(expected) {
+			t.Fatal("Search with budgets failed for", query, options, matches, expected)
+		}
+		for _, match := range matches {
+			if expected[match.Word] != match.Distance {
+				t.Error("Search with budgets failed for", query, match)
+			}
+		}
+	}
+}
+
+// Get the lowest number of edits turning a into b with the budgets of the options (Distance+1 if none)
+func budgetedDistance(a []rune, b []rune, options SearchOptions) int {
+	budget := func(limit int) int {
+		if limit == 0 {
+			return options.Distance
+		}
+		return max(limit, 0)
+	}
+	maxSubstitutions, maxInsertions, maxDeletions := budget(options.MaxSubstitutions), budget(options.MaxInsertions), budget(options.MaxDeletions)
+	best := options.Distance + 1
+	// Walk the alignments, counting each kind of edit
+	var walk func(i int, j int, substitutions int, insertions int, deletions int)
+	walk = func(i int, j int, substitutions int, insertions int, deletions int) {
+		if substitutions > maxSubstitutions || insertions > maxInsertions || deletions > maxDeletions || substitutions+insertions+deletions >= best {
+			return
+		}
+		if i == len(a) && j == len(b) {
+			best = substitutions + insertions + deletions
+			return
+		}
+		if i < len(a) && j < len(b) {
+			if a[i] == b[j] {
+				walk(i+1, j+1, substitutions, insertions, deletions)
+			} else {
+				walk(i+1, j+1, substitutions+1, insertions, deletions)
+			}
+		}
+		if j < len(b) {
+			walk(i, j+1, substitutions, insertions+1, deletions)
+		}
+		if i < len(a) {
+			walk(i+1, j, substitutions, insertions, deletions+1)
+		}
+	}
+	walk(0, 0, 0, 0, 0)
+	return best
+}
+
+func TestSearchLength(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	words := make([]string, 300)
+	for i := range words {
+		word := make([]rune, 1+r.Intn(7))
+		for j := range word {
+			word[j] = rune('a' + r.Intn(3))
+		}
+		words[i] = string(word)
+	}
+	dawg := CreateDAWG(words)
+
+	for _, query := range []string{"abc", "aabba", "c", "abcabca"} {
+		for _, options := range []SearchOptions{{Distance: 2, AllowAdd: true, AllowDelete: true, Transpose: true}, {Distance: 1}, {Distance: 3, AllowAdd: true}, {Distance: 3, AllowDelete: true}} {
+			all, _ := dawg.SearchWithOptions(query, options)
+			options.MinLength, options.MaxLength = 3, 5
+			matches, err := dawg.SearchWithOptions(query, options)
+			var expected []Match
+			for _, match := range all {
+				if length := len([]rune(match.Word)); length >= 3 && length <= 5 {
+					expected = append(expected, match)
+				}
+			}
+			if err != nil || len(matches) != len(expected) {
+				t.Fatal("Search with lengths failed for", query)
+			}
+			for i := range matches {
+				if matches[i] != expected[i] {
+					t.Error("Search with lengths failed for", query)
+				}
+			}
+		}
+	}
+
+	matches, err := dawg.SearchWithOptions("ab", SearchOptions{Distance: 1, AllowAdd: true, MinLength: 3})
+	for _, match := range matches {
+		if len(match.Word) != 3 {
+			t.Error("Search with minimum length failed")
+		}
+	}
+	if err != nil || len(matches) == 0 {
+		t.Error("Search with minimum length failed")
+	}
+}
+
+func TestSearchAllocations(t *testing.T) {
+	if raceEnabled {
+		t.Skip("The allocations can't be checked with the race detector")
+	}
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "the"})
+	options := SearchOptions{Distance: 2, AllowAdd: true, AllowDelete: true, Transpose: true}
+	ignore := func(match Match) bool { return true }
+
+	// Without results, the buffers of the pool are enough
+	if allocs := testing.AllocsPerRun(100, func() { dawg.SearchFunc("xyzzy", options, ignore) }); allocs != 0 {
+		t.Error("SearchFunc should not allocate without results:", allocs)
+	}
+	// With results, only the words found and the returned slice are allocated
+	matches, _ := dawg.SearchWithOptions("tst", options)
+	allocs := testing.AllocsPerRun(100, func() { dawg.SearchWithOptions("tst", options) })
+	if allocs > float64(2*len(matches)) {
+		t.Error("SearchWithOptions allocates too much:", allocs, len(matches))
+	}
+}
+
+func TestAppendMatches(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "the", "tst", "tëst"})
+
+	for _, options := range []SearchOptions{
+		{Distance: 2, AllowAdd: true, AllowDelete: true, Transpose: true},
+		{Distance: 2, AllowAdd: true, AllowDelete: true, MaxResults: 3},
+		{Distance: 1, Prefix: true},
+		{Distance: 2, Compare: func(a string, b string) int { return strings.Compare(b, a) }},
+	} {
+		matches, _ := dawg.SearchWithOptions("tst", options)
+		var words []string
+		for _, word := range dawg.AppendMatches(nil, "tst", options) {
+			words = append(words, string(word))
+		}
+		if !slices.Equal(words, matchedWords(matches)) {
+			t.Error("AppendMatches failed", words, matches)
+		}
+	}
+
+	// The buffers are reused
+	options := SearchOptions{Distance: 2, AllowAdd: true, AllowDelete: true}
+	buffers := dawg.AppendMatches(nil, "tst", options)
+	if allocs := testing.AllocsPerRun(100, func() { buffers = dawg.AppendMatches(buffers[:0], "tst", options) }); allocs != 0 && !raceEnabled {
+		t.Error("AppendMatches should not allocate with buffers:", allocs)
+	}
+	if buffers = dawg.AppendMatches(buffers[:1], "nest", SearchOptions{}); len(buffers) != 2 || string(buffers[1]) != "nest" {
+		t.Error("AppendMatches after words failed", len(buffers))
+	}
+}
+
+func TestSearchPrefixTolerant(t *testing.T) {
+	dawg := CreateDAWG([]string{"restaurant", "restaurants", "rest", "resto", "restore", "trestle"})
+
+	matches, err := dawg.SearchPrefixTolerant("restau", 1, 20)
+	if err != nil || len(matches) != 2 || matches[0] != (Match{Word: "restaurant", End: 6, Matched: 6}) || matches[1] != (Match{Word: "restaurants", End: 6, Matched: 6}) {
+		t.Error("SearchPrefixTolerant failed:", matches)
+	}
+	// A deleted letter
+	if matches, err = dawg.SearchPrefixTolerant("restp", 1, 0); err != nil || len(matches) != 5 || matches[0] != (Match{Word: "rest", Distance: 1, End: 5, Matched: 4}) {
+		t.Error("SearchPrefixTolerant failed:", matches)
+	}
+	if matches, err = dawg.SearchPrefixTolerant("restau", 1, 1); err != nil || len(matches) != 1 {
+		t.Error("SearchPrefixTolerant max results failed:", matches)
+	}
+	// An inserted letter
+	if matches, err = dawg.SearchPrefixTolerant("rstaur", 1, 0); err != nil || len(matches) != 2 || matches[0].Distance != 1 {
+		t.Error("SearchPrefixTolerant failed:", matches)
+	}
+	matches, err = dawg.SearchWithOptions("resto", SearchOptions{Prefix: true, MaxLength: 5})
+	if err != nil || len(matches) != 1 || matches[0].Word != "resto" {
+		t.Error("Prefix search with a maximum length failed:", matches)
+	}
+	// The prefix of the words matched, with the other searches
+	for _, options := range []SearchOptions{{Parallel: true}, {Graphemes: true}} {
+		options.Distance, options.AllowDelete, options.Prefix = 1, true, true
+		if matches, err = dawg.SearchWithOptions("restp", options); err != nil || len(matches) != 5 || matches[0] != (Match{Word: "rest", Distance: 1, End: 5, Matched: 4}) || matches[4].Matched != 4 {
+			t.Error("Prefix search failed:", options, matches)
+		}
+	}
+}
+
+func TestSearchIgnoreCase(t *testing.T) {
+	dawg := CreateDAWG([]string{"Paris", "London", "paris", "Straße", "ÉCOLE"})
+
+	matches, _ := dawg.SearchWithOptions("paris", SearchOptions{IgnoreCase: true})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"Paris", 0}, {"paris", 0}}) {
+		t.Error("SearchWithOptions with IgnoreCase failed:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("lodnon", SearchOptions{Distance: 1, Transpose: true, IgnoreCase: true})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"London", 1}}) {
+		t.Error("SearchWithOptions with IgnoreCase failed with a transposition:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("STRASE", SearchOptions{Distance: 1, IgnoreCase: true})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"Straße", 1}}) {
+		t.Error("SearchWithOptions with IgnoreCase failed with a substitution:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("écol", SearchOptions{Distance: 1, AllowAdd: true, IgnoreCase: true})
+	if !slices.Equal(wordDistances(matches), []wordDistance{{"ÉCOLE", 1}}) {
+		t.Error("SearchWithOptions with IgnoreCase failed with an insertion:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("PARIS", SearchOptions{})
+	if len(matches) != 0 {
+		t.Error("SearchWithOptions without IgnoreCase failed:", matches)
+	}
+}
+
+func TestSearchCompare(t *testing.T) {
+	dawg := CreateDAWG([]string{"Zorn", "Ärger", "apfel", "Arger"})
+	// Close to a collation: diacritics and case only matter for equal words
+	compare := func(a string, b string) int {
+		return strings.Compare(FoldCase(StripDiacritics(a)), FoldCase(StripDiacritics(b)))
+	}
+
+	matches, _ := dawg.SearchWithOptions("", SearchOptions{Prefix: true})
+	if !slices.Equal(matchedWords(matches), []string{"Arger", "Zorn", "apfel", "Ärger"}) {
+		t.Error("SearchWithOptions failed:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("", SearchOptions{Prefix: true, Compare: compare})
+	if !slices.Equal(matchedWords(matches), []string{"apfel", "Arger", "Ärger", "Zorn"}) {
+		t.Error("SearchWithOptions with Compare failed:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("Zrger", SearchOptions{Distance: 1, Compare: compare, Graphemes: true})
+	if !slices.Equal(matchedWords(matches), []string{"Arger", "Ärger"}) {
+		t.Error("SearchWithOptions with Compare and Graphemes failed:", matches)
+	}
+	matches, _ = dawg.SearchWithOptions("Zrger", SearchOptions{Distance: 1, Compare: compare, Ranker: RankerFunc(func(string, int) float64 { return 0 })})
+	if !slices.Equal(matchedWords(matches), []string{"Arger", "Ärger"}) {
+		t.Error("SearchWithOptions with Compare and a Ranker failed:", matches)
+	}
+
+	if completions := dawg.CompletionsWithCompare("", 3, compare); !slices.Equal(completions, []string{"apfel", "Arger", "Ärger"}) {
+		t.Error("CompletionsWithCompare failed:", completions)
+	}
+}
+
+func TestSearchExactPrefix(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "best", "tent", "txst", "text", "atest", "ttest", "tst", "etst", "tets"})
+
+	for _, graphemes := range []bool{false, true} {
+		options := SearchOptions{Distance: 1, AllowAdd: true, AllowDelete: true, Transpose: true, Graphemes: graphemes}
+		matches, _ := dawg.SearchWithOptions("test", options)
+		if len(matches) != 10 {
+			t.Error("SearchWithOptions failed:", matches)
+		}
+		options.ExactPrefix = 1
+		matches, _ = dawg.SearchWithOptions("test", options)
+		if !slices.Equal(matchedWords(matches), []string{"test", "tent", "tets", "text", "tst", "ttest", "txst"}) {
+			t.Error("SearchWithOptions with an exact prefix failed:", graphemes, matches)
+		}
+		options.ExactPrefix = 2
+		matches, _ = dawg.SearchWithOptions("test", options)
+		if !slices.Equal(matchedWords(matches), []string{"test", "tent", "tets", "text"}) {
+			t.Error("SearchWithOptions with an exact prefix failed:", graphemes, matches)
+		}
+	}
+}
+
+func TestSearchMaxResults(t *testing.T) {
+	words := []string{}
+	for _, first := range "abcdefghij" {
+		for _, second := range "abcdefghij" {
+			words = append(words, string([]rune{first, second, 'x'}))
+		}
+	}
+	dawg := CreateDAWG(words)
+
+	all, _ := dawg.SearchWithOptions("aax", SearchOptions{Distance: 2, MaxResults: Unlimited})
+	if len(all) != 100 || all[0] != newMatch("aax", 0, 3) || all[1] != newMatch("abx", 1, 3) {
+		t.Error("SearchWithOptions failed without a limit:", len(all))
+	}
+	for _, maxResults := range []int{1, 3, 19, 20, 50, 99, 100, 200} {
+		matches, _ := dawg.SearchWithOptions("aax", SearchOptions{Distance: 2, MaxResults: maxResults})
+		if !slices.Equal(matches, all[:min(maxResults, len(all))]) {
+			t.Error("SearchWithOptions failed with a limit:", maxResults, len(matches))
+		}
+		matches, _ = dawg.SearchWithOptions("aax", SearchOptions{Distance: 2, MaxResults: maxResults, Parallel: true})
+		if !slices.Equal(matches, all[:min(maxResults, len(all))]) {
+			t.Error("Parallel search failed with a limit:", maxResults, len(matches))
+		}
+	}
+	if matches, _ := dawg.SearchWithOptions("aax", SearchOptions{Distance: 2, MaxResults: -1}); len(matches) != 100 {
+		t.Error("SearchWithOptions failed with a negative limit:", len(matches))
+	}
+	if words, _ := dawg.Search("aax", 2, 0, false, false); len(words) != 100 {
+		t.Error("Search failed without a limit:", len(words))
+	}
+	if matches, _ := dawg.SearchWithDistance("aax", 1, Unlimited, false, false); len(matches) != 19 {
+		t.Error("SearchWithDistance failed without a limit:", len(matches))
+	}
+}
+
+func TestSearchUnique(t *testing.T) {
+	random := rand.New(rand.NewSource(2))
+	words := make([]string, 500)
+	for i := range words {
+		word := make([]byte, 1+random.Intn(6))
+		for j := range word {
+			word[j] = byte('a' + random.Intn(3)) // Many repeated letters, so many edit paths to the same word
+		}
+		words[i] = string(word)
+	}
+	dawg := CreateDAWG(words)
+
+	for _, options := range []SearchOptions{
+		{Distance: 3, AllowAdd: true, AllowDelete: true, Transpose: true},
+		{Distance: 2, AllowAdd: true, AllowDelete: true, Prefix: true},
+		{Distance: 3, AllowAdd: true, AllowDelete: true, MaxResults: 20},
+		{Distance: 3, AllowAdd: true, AllowDelete: true, Parallel: true},
+		{Distance: 3, AllowAdd: true, AllowDelete: true, Graphemes: true},
+	} {
+		for _, query := range []string{"aaa", "abab", "c", ""} {
+			matches, _ := dawg.SearchWithOptions(query, options)
+			found := make(map[string]bool)
+			for _, match := range matches {
+				if found[match.Word] {
+					t.Error("Search found a word twice:", query, options, match)
+				}
+				found[match.Word] = true
+			}
+			if options.MaxResults > 0 && len(matches) != options.MaxResults {
+				t.Error("Search didn't find enough words:", query, options, len(matches))
+			}
+		}
+	}
+}
+
+func TestSearchFilter(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tests", "Test", "text", "rest", "nest", "zest"})
+	var calls sync.Map
+	// No capitalized words, and no word starting with "tex"
+	filter := func(prefix string) bool {
+		calls.Store(prefix, true)
+		return !unicode.IsUpper([]rune(prefix)[0]) && !strings.HasPrefix(prefix, "tex")
+	}
+
+	for _, options := range []SearchOptions{
+		{Distance: 1, Filter: filter},
+		{Distance: 1, Filter: filter, Parallel: true},
+		{Distance: 1, Filter: filter, Graphemes: true},
+		{Distance: 1, Filter: filter, Prefix: true, MaxLength: 4},
+	} {
+		matches, _ := dawg.SearchWithOptions("test", options)
+		if !slices.Equal(matchedWords(matches), []string{"test", "nest", "rest", "zest"}) {
+			t.Error("SearchWithOptions with a filter failed:", options, matches)
+		}
+	}
+	// The subtrees rejected are not walked
+	if _, ok := calls.Load("text"); ok {
+		t.Error("SearchWithOptions with a filter failed to prune the DAWG")
+	}
+}
+
+func TestSearchStop(t *testing.T) {
+	random := rand.New(rand.NewSource(3))
+	words := make([]string, 500)
+	for i := range words {
+		word := make([]byte, 1+random.Intn(6))
+		for j := range word {
+			word[j] = byte('a' + random.Intn(3))
+		}
+		words[i] = string(word)
+	}
+	dawg := CreateDAWG(append(words, "abab"))
+
+	for _, options := range []SearchOptions{
+		{Distance: 3, AllowAdd: true, AllowDelete: true},
+		{Distance: 2, AllowAdd: true, AllowDelete: true, Prefix: true},
+		{Distance: 3, AllowAdd: true, AllowDelete: true, Parallel: true},
+		{Distance: 3, AllowAdd: true, AllowDelete: true, Graphemes: true},
+	} {
+		// Stop at the third word found: only the words found before are given
+		calls := 0
+		options.Stop = func(match Match) bool {
+			calls++
+			return calls == 3
+		}
+		matches, err := dawg.SearchWithOptions("abab", options)
+		if err != nil || len(matches) != 3 || calls != 3 {
+			t.Error("Stop failed", options, matches, calls)
+		}
+
+		// Stop at the exact match (or at the first word starting with the query in prefix mode)
+		options.Stop = func(match Match) bool { return match.Distance == 0 }
+		var given []Match
+		err = dawg.SearchFunc("abab", options, func(match Match) bool {
+			given = append(given, match)
+			return true
+		})
+		// The search on graphemes walks the words depth-first, finding words of any distance before the exact match
+		if err != nil || len(given) == 0 || given[0].Distance != 0 || len(given) != 1 && !options.Graphemes {
+			t.Error("Stop at the exact match failed", options, given)
+		}
+	}
+}
+
+func TestSearchMaxDuration(t *testing.T) {
+	random := rand.New(rand.NewSource(4))
+	words := make([]string, 3000)
+	for i := range words {
+		word := make([]byte, 4+random.Intn(6))
+		for j := range word {
+			word[j] = byte('a' + random.Intn(4))
+		}
+		words[i] = string(word)
+	}
+	dawg := CreateDAWG(words)
+
+	for _, options := range []SearchOptions{
+		{Distance: 4, AllowAdd: true, AllowDelete: true},
+		{Distance: 4, AllowAdd: true, AllowDelete: true, Parallel: true},
+		{Distance: 4, AllowAdd: true, AllowDelete: true, Graphemes: true},
+	} {
+		all, _ := dawg.SearchWithOptions("abcdabcd", options)
+		distances := make(map[string]int)
+		for _, match := range all {
+			distances[match.Word] = match.Distance
+		}
+
+		options.MaxDuration = time.Hour
+		matches, truncated, err := dawg.SearchPartial("abcdabcd", options)
+		if err != nil || truncated || len(matches) != len(all) {
+			t.Error("SearchPartial within the budget failed", options, len(matches), truncated, err)
+		}
+
+		// The budget is spent at the first check of the deadline: the words found before are given
+		options.MaxDuration = time.Nanosecond
+		matches, truncated, err = dawg.SearchPartial("abcdabcd", options)
+		if err != nil || !truncated || len(matches) >= len(all) {
+			t.Error("SearchPartial out of the budget failed", options, len(matches), truncated, err)
+		}
+		for i, match := range matches {
+			if distance, ok := distances[match.Word]; !ok || distance != match.Distance || i > 0 && match.Distance < matches[i-1].Distance {
+				t.Error("SearchPartial out of the budget failed", options, match)
+			}
+		}
+		if matches, err := dawg.SearchWithOptions("abcdabcd", options); err != nil || len(matches) >= len(all) {
+			t.Error("SearchWithOptions out of the budget failed", options, len(matches), err)
+		}
+	}
+}
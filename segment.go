@@ -0,0 +1,75 @@
+package dawg
+
+import "unicode/utf8"
+
+// Get the longest word of the DAWG which is a prefix of the input, and its size n in bytes
+// (input[n:] being the rest of the input). n is 0 if no word is a prefix of the input.
+func (dawg *DAWG) LongestPrefix(input string) (word string, n int) {
+	dawg.walkPrefixes(input, func(end int) bool {
+		n = end
+		return true
+	})
+	return input[:n], n
+}
+
+// Get the words of the DAWG which are prefixes of the input, by increasing size, in a single walk of the input
+// ("android" gives "a", "an", "and", "android")
+func (dawg *DAWG) CommonPrefixes(input string) []string {
+	words := []string{}
+	dawg.walkPrefixes(input, func(end int) bool {
+		words = append(words, input[:end])
+		return true
+	})
+	return words
+}
+
+// Call fn with the end (in bytes) of each word of the DAWG which is a prefix of the input, by increasing size,
+// in a single walk of the input. The walk stops as soon as fn returns false.
+func (dawg *DAWG) walkPrefixes(input string, fn func(end int) bool) {
+	curState := dawg.initialState
+	if curState.final && !fn(0) {
+		return
+	}
+	for i, char := range input {
+		curLetter := curState.getletter(char)
+		if curLetter == nil {
+			return
+		}
+		curState = curLetter.state
+		if curState.final && !fn(i+utf8.RuneLen(char)) {
+			return
+		}
+	}
+}
+
+// Split a text written without spaces ("#dawgsarefast") into words of the DAWG, with as few words as possible.
+// ok is false if the text can't be split into words of the DAWG.
+func (dawg *DAWG) SegmentText(text string) (words []string, ok bool) {
+	// wordsCount[i] is the lowest number of words splitting text[:i] (-1 if it can't be split),
+	// and starts[i] is the start of the last of these words
+	wordsCount := make([]int, len(text)+1)
+	starts := make([]int, len(text)+1)
+	for i := 1; i <= len(text); i++ {
+		wordsCount[i] = -1
+	}
+	for start := 0; start < len(text); start++ {
+		if wordsCount[start] < 0 {
+			continue
+		}
+		dawg.walkPrefixes(text[start:], func(end int) bool {
+			if end > 0 && (wordsCount[start+end] < 0 || wordsCount[start]+1 < wordsCount[start+end]) {
+				wordsCount[start+end] = wordsCount[start] + 1
+				starts[start+end] = start
+			}
+			return true
+		})
+	}
+	if wordsCount[len(text)] < 0 {
+		return nil, false
+	}
+	words = make([]string, wordsCount[len(text)])
+	for end, i := len(text), len(words)-1; end > 0; end, i = starts[end], i-1 {
+		words[i] = text[starts[end]:end]
+	}
+	return words, true
+}
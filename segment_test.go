@@ -0,0 +1,51 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestLongestPrefix(t *testing.T) {
+	dawg := CreateDAWG([]string{"a", "an", "and", "android", "été"})
+
+	for _, test := range []struct {
+		input string
+		word  string
+	}{{"andrew", "and"}, {"androids", "android"}, {"ax", "a"}, {"xa", ""}, {"", ""}, {"étés", "été"}} {
+		if word, n := dawg.LongestPrefix(test.input); word != test.word || n != len(test.word) {
+			t.Error("LongestPrefix failed:", test.input, word, n)
+		}
+	}
+}
+
+func TestCommonPrefixes(t *testing.T) {
+	dawg := CreateDAWG([]string{"a", "an", "and", "android", "bee"})
+
+	if words := dawg.CommonPrefixes("androids"); !slices.Equal(words, []string{"a", "an", "and", "android"}) {
+		t.Error("CommonPrefixes failed:", words)
+	}
+	if words := dawg.CommonPrefixes("be"); words == nil || len(words) != 0 {
+		t.Error("CommonPrefixes without prefix failed:", words)
+	}
+	if words := CreateDAWG([]string{"", "x"}).CommonPrefixes("xy"); !slices.Equal(words, []string{"", "x"}) {
+		t.Error("CommonPrefixes with the empty word failed:", words)
+	}
+}
+
+func TestSegmentText(t *testing.T) {
+	dawg := CreateDAWG([]string{"dawg", "dawgs", "are", "a", "re", "fast", "s", "sare"})
+
+	// "dawgsarefast" is also "dawg sare fast" and "dawg s are fast", but not with fewer words
+	if words, ok := dawg.SegmentText("dawgsarefast"); !ok || len(words) != 3 || words[2] != "fast" {
+		t.Error("SegmentText failed:", words)
+	}
+	if words, ok := dawg.SegmentText("arefast"); !ok || !slices.Equal(words, []string{"are", "fast"}) {
+		t.Error("SegmentText failed:", words)
+	}
+	if words, ok := dawg.SegmentText("dawgsxfast"); ok || words != nil {
+		t.Error("SegmentText should fail:", words)
+	}
+	if words, ok := dawg.SegmentText(""); !ok || len(words) != 0 {
+		t.Error("SegmentText of an empty text failed:", words)
+	}
+}
@@ -0,0 +1,35 @@
+package dawg
+
+// Session is an incremental search-as-you-type session.
+// The prefixes of the DAWG close to the query are kept between keystrokes, so appending or
+// removing a letter only costs the update of these prefixes instead of a whole new search.
+type Session struct {
+	query *Query
+}
+
+// Start a new search-as-you-type session, matching the words starting with a prefix within
+// levenshteinDistance of the query typed so far
+func (dawg *DAWG) NewSession(levenshteinDistance int) *Session {
+	return &Session{query: dawg.NewQuery(levenshteinDistance)}
+}
+
+// Get the query typed so far
+func (session *Session) Query() string {
+	return session.query.String()
+}
+
+// Append a letter to the query
+func (session *Session) Append(char rune) {
+	session.query.Extend(char)
+}
+
+// Remove the last letter of the query, if any
+func (session *Session) Remove() (removed bool) {
+	return session.query.Backspace()
+}
+
+// Get at most maxResults words of the DAWG starting with a prefix close to the query (all of them if
+// maxResults <= 0), sorted by distance then lexicographically
+func (session *Session) Results(maxResults int) []Suggestion {
+	return session.query.Completions(maxResults)
+}
@@ -0,0 +1,33 @@
+package dawg
+
+import "testing"
+
+func TestSession(t *testing.T) {
+	dawg := CreateDAWG([]string{"restaurant", "restaurants", "rest", "result", "test"})
+	session := dawg.NewSession(1)
+
+	for _, char := range "restu" {
+		session.Append(char)
+	}
+	results := session.Results(10)
+	// "restu" -> "resta" (restaurant, restaurants), "resu" (result), "rest" (rest)
+	if session.Query() != "restu" || len(results) != 4 || results[0].Word != "rest" || results[0].Distance != 1 {
+		t.Error("Session failed")
+	}
+
+	results = session.Results(2)
+	if len(results) != 2 || results[0].Word != "rest" || results[1].Word != "restaurant" {
+		t.Error("Session maxResults failed")
+	}
+	if results = session.Results(Unlimited); len(results) != 4 {
+		t.Error("Session without maxResults failed")
+	}
+
+	if !session.Remove() || !session.Remove() || session.Query() != "res" {
+		t.Error("Session remove failed")
+	}
+	results = session.Results(10)
+	if len(results) != 5 || results[0].Distance != 0 || results[4].Word != "test" || results[4].Distance != 1 {
+		t.Error("Session after remove failed")
+	}
+}
@@ -0,0 +1,158 @@
+package dawg
+
+import (
+	"slices"
+	"strings"
+)
+
+// Set is a federation of DAWGs queried as a single dictionary (a base dictionary, a user dictionary and domain
+// terms for example): each query is run on all the members and their results are merged, with the names of the
+// members where each word was found. The members are not merged, so a member which changes often doesn't need
+// the union of the DAWGs to be rebuilt.
+// A Set must not be changed (with Add or Remove) while it is queried by other goroutines.
+type Set struct {
+	names   []string
+	members []*DAWG
+}
+
+// SetMatch is a word found in a Set, with the members where it was found
+type SetMatch struct {
+	Match
+	Sources []string // Names of the members containing the word, in the order they were added to the Set
+}
+
+// Create a set of DAWGs, without members
+func NewSet() *Set {
+	return &Set{}
+}
+
+// Add a DAWG to the set, replacing the member of the same name if any (keeping its place)
+func (set *Set) Add(name string, dawg *DAWG) {
+	if i := slices.Index(set.names, name); i >= 0 {
+		set.members[i] = dawg
+		return
+	}
+	set.names = append(set.names, name)
+	set.members = append(set.members, dawg)
+}
+
+// Remove the member of the given name. Return false if there was no such member.
+func (set *Set) Remove(name string) bool {
+	i := slices.Index(set.names, name)
+	if i < 0 {
+		return false
+	}
+	set.names = slices.Delete(set.names, i, i+1)
+	set.members = slices.Delete(set.members, i, i+1)
+	return true
+}
+
+// Get the names of the members of the set, in the order they were added
+func (set *Set) Names() []string {
+	return slices.Clone(set.names)
+}
+
+// Get the member of the given name (nil if there is no such member)
+func (set *Set) Member(name string) *DAWG {
+	if i := slices.Index(set.names, name); i >= 0 {
+		return set.members[i]
+	}
+	return nil
+}
+
+// Check if a member of the set contains the word
+func (set *Set) Contains(word string) bool {
+	for _, member := range set.members {
+		if member.Contains(word) {
+			return true
+		}
+	}
+	return false
+}
+
+// Get the names of the members containing the word (none if the word is not in the set)
+func (set *Set) Sources(word string) []string {
+	sources := []string{}
+	for i, member := range set.members {
+		if member.Contains(word) {
+			sources = append(sources, set.names[i])
+		}
+	}
+	return sources
+}
+
+// Get the words of the members starting with the prefix, in lexicographic order, each word only once with
+// the members containing it (the distance of the matches being 0). At most max words are returned (all of them
+// if max <= 0).
+func (set *Set) Completions(prefix string, max int) []SetMatch {
+	merged := newSetMerger()
+	for i, member := range set.members {
+		// The first words of the set are among the first words of each member
+		for _, word := range member.Completions(prefix, max) {
+			merged.add(Match{Word: word, End: len(prefix), Matched: len(prefix)}, set.names[i])
+		}
+	}
+	slices.SortFunc(merged.matches, func(a Match, b Match) int {
+		return strings.Compare(a.Word, b.Word)
+	})
+	return merged.result(max)
+}
+
+// Approximate string searching in the members of the set, as with DAWG.SearchWithOptions: the words are sorted
+// by distance, then lexicographically (or by score of the Ranker of the options), each word only once with the
+// members where it was found, and the MaxResults first ones are returned.
+func (set *Set) Search(word string, options SearchOptions) ([]SetMatch, error) {
+	merged := newSetMerger()
+	for i, member := range set.members {
+		// The order of the words doesn't depend on the member, so the best words of the set are among
+		// the best words of each member
+		matches, err := member.SearchWithOptions(word, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			merged.add(match, set.names[i])
+		}
+	}
+	if options.Ranker != nil {
+		merged.matches = rankMatches(merged.matches, options.Ranker, 0, options.compareWords)
+	} else {
+		slices.SortFunc(merged.matches, func(a Match, b Match) int {
+			if a.Distance != b.Distance {
+				return a.Distance - b.Distance
+			}
+			return options.compareWords(a.Word, b.Word)
+		})
+	}
+	return merged.result(options.MaxResults), nil
+}
+
+// A setMerger merges the words found in the members of a Set
+type setMerger struct {
+	matches []Match
+	sources map[string][]string // Members where each word was found
+}
+
+func newSetMerger() *setMerger {
+	return &setMerger{sources: make(map[string][]string)}
+}
+
+// Add a word found in the member of the given name
+func (merger *setMerger) add(match Match, name string) {
+	if _, ok := merger.sources[match.Word]; !ok {
+		merger.matches = append(merger.matches, match)
+	}
+	merger.sources[match.Word] = append(merger.sources[match.Word], name)
+}
+
+// Get the max first words, sorted, with their members (all of them if max <= 0)
+func (merger *setMerger) result(max int) []SetMatch {
+	if max > 0 && len(merger.matches) > max {
+		merger.matches = merger.matches[:max]
+	}
+	result := make([]SetMatch, len(merger.matches))
+	for i, match := range merger.matches {
+		result[i] = SetMatch{Match: match, Sources: merger.sources[match.Word]}
+	}
+	return result
+}
@@ -0,0 +1,63 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSet(t *testing.T) {
+	set := NewSet()
+	set.Add("base", CreateDAWG([]string{"test", "tests", "nest", "note"}))
+	set.Add("user", CreateDAWG([]string{"tess", "test"}))
+	set.Add("domain", CreateDAWG([]string{"teste"}))
+
+	if !set.Contains("tess") || !set.Contains("note") || set.Contains("tes") {
+		t.Error("Contains failed")
+	}
+	if !slices.Equal(set.Sources("test"), []string{"base", "user"}) || len(set.Sources("x")) != 0 {
+		t.Error("Sources failed")
+	}
+
+	completions := set.Completions("tes", 0)
+	expected := []SetMatch{
+		{Match{Word: "tess", End: 3, Matched: 3}, []string{"user"}},
+		{Match{Word: "test", End: 3, Matched: 3}, []string{"base", "user"}},
+		{Match{Word: "teste", End: 3, Matched: 3}, []string{"domain"}},
+		{Match{Word: "tests", End: 3, Matched: 3}, []string{"base"}},
+	}
+	if !slices.EqualFunc(completions, expected, equalSetMatch) {
+		t.Error("Completions failed", completions)
+	}
+	if completions = set.Completions("tes", 2); !slices.EqualFunc(completions, expected[:2], equalSetMatch) {
+		t.Error("Completions max failed", completions)
+	}
+
+	matches, err := set.Search("tese", SearchOptions{Distance: 1, AllowAdd: true, MaxResults: 3})
+	expected = []SetMatch{
+		{newMatch("tess", 1, 4), []string{"user"}},
+		{newMatch("test", 1, 4), []string{"base", "user"}},
+		{newMatch("teste", 1, 4), []string{"domain"}},
+	}
+	if err != nil || !slices.EqualFunc(matches, expected, equalSetMatch) {
+		t.Error("Search failed", matches)
+	}
+
+	ranker := RankerFunc(func(candidate string, distance int) float64 { return float64(-len(candidate)) })
+	matches, err = set.Search("tese", SearchOptions{Distance: 1, AllowAdd: true, MaxResults: 2, Ranker: ranker})
+	if err != nil || len(matches) != 2 || matches[0].Word != "teste" || matches[1].Word != "tess" {
+		t.Error("Search with a ranker failed", matches)
+	}
+
+	// The members can be replaced and removed
+	set.Add("user", CreateDAWG([]string{"toast"}))
+	if set.Contains("tess") || !set.Contains("toast") || !slices.Equal(set.Names(), []string{"base", "user", "domain"}) {
+		t.Error("Add of a member failed")
+	}
+	if !set.Remove("domain") || set.Remove("domain") || set.Contains("teste") || set.Member("base") == nil || set.Member("domain") != nil {
+		t.Error("Remove failed")
+	}
+}
+
+func equalSetMatch(a SetMatch, b SetMatch) bool {
+	return a.Match == b.Match && slices.Equal(a.Sources, b.Sources)
+}
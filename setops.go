@@ -0,0 +1,100 @@
+package dawg
+
+// Create the minimal DAWG of the words of a or b, without listing the words of a and b
+func Union(a *DAWG, b *DAWG) *DAWG {
+	return combineDAWGs(a, b, func(inA bool, inB bool) bool { return inA || inB })
+}
+
+// Create the minimal DAWG of the words in both a and b
+func Intersect(a *DAWG, b *DAWG) *DAWG {
+	return combineDAWGs(a, b, func(inA bool, inB bool) bool { return inA && inB })
+}
+
+// Create the minimal DAWG of the words in a but not in b
+func Difference(a *DAWG, b *DAWG) *DAWG {
+	return combineDAWGs(a, b, func(inA bool, inB bool) bool { return inA && !inB })
+}
+
+// Create the minimal DAWG of the words selected by the operation, by walking both DAWGs at the same time
+func combineDAWGs(a *DAWG, b *DAWG, operation func(inA bool, inB bool) bool) *DAWG {
+	combiner := &combiner{
+		operation: operation,
+		combined:  make(map[statePair]*state),
+		register:  make(map[string]*state),
+		ids:       make(map[*state]uint64),
+	}
+	initialState := combiner.combine(a.initialState, b.initialState)
+	if initialState == nil {
+		initialState = &state{final: false}
+	} else if _, ok := combiner.ids[initialState]; !ok {
+		combiner.ids[initialState] = uint64(len(combiner.ids)) // The initial state could be a duplicate of another state
+	}
+	countWords(initialState, make(map[*state]bool))
+	return &DAWG{initialState: initialState, nodesCount: uint64(max(len(combiner.ids), 1)), maxWordSize: longestWord(initialState, make(map[*state]int))}
+}
+
+// A state of each DAWG, nil if the prefix is not in the DAWG
+type statePair struct {
+	a *state
+	b *state
+}
+
+// A combiner builds the states of a DAWG from the states of two DAWGs
+type combiner struct {
+	operation func(inA bool, inB bool) bool // Whether a word in a and/or in b is in the new DAWG
+	combined  map[statePair]*state          // The state built for each pair of states (nil if no word)
+	register  map[string]*state             // The states built, by signature
+	ids       map[*state]uint64             // Number of the states built, for the signatures
+	key       []byte
+}
+
+// Get the state whose words are those selected by the operation from the words under a and b
+// (nil if there are no such words). Equal states are merged, so the DAWG built is minimal.
+func (combiner *combiner) combine(a *state, b *state) *state {
+	pair := statePair{a, b}
+	if combined, ok := combiner.combined[pair]; ok {
+		return combined
+	}
+	if a == nil && (b == nil || !combiner.operation(false, true)) || b == nil && !combiner.operation(true, false) {
+		return nil // The words under the other state are not selected
+	}
+
+	newState := &state{final: combiner.operation(a != nil && a.final, b != nil && b.final)}
+	var lettersA, lettersB, letters []*letter
+	if a != nil {
+		lettersA = a.letters
+	}
+	if b != nil {
+		lettersB = b.letters
+	}
+	for len(lettersA) > 0 || len(lettersB) > 0 {
+		var char rune
+		var subA, subB *state
+		if len(lettersB) == 0 || len(lettersA) > 0 && lettersA[0].char <= lettersB[0].char {
+			char, subA = lettersA[0].char, lettersA[0].state
+			lettersA = lettersA[1:]
+		}
+		if len(lettersB) > 0 && (subA == nil || lettersB[0].char == char) {
+			char, subB = lettersB[0].char, lettersB[0].state
+			lettersB = lettersB[1:]
+		}
+		if subState := combiner.combine(subA, subB); subState != nil {
+			letters = append(letters, &letter{char: char, state: subState})
+		}
+	}
+	if !newState.final && len(letters) == 0 {
+		combiner.combined[pair] = nil
+		return nil
+	}
+	newState.letters = letters
+
+	combiner.key = newState.signature(combiner.key[:0], combiner.ids)
+	if sameState, ok := combiner.register[string(combiner.key)]; ok {
+		newState = sameState
+	} else {
+		combiner.register[string(combiner.key)] = newState
+		combiner.ids[newState] = uint64(len(combiner.ids))
+	}
+	combiner.combined[pair] = newState
+	return newState
+}
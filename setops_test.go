@@ -0,0 +1,57 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnion(t *testing.T) {
+	a := CreateDAWG([]string{"test", "tes", "nest"})
+	b := CreateDAWG([]string{"test", "rest", "note", ""})
+
+	union := Union(a, b)
+	expected := CreateDAWG([]string{"test", "tes", "nest", "rest", "note", ""})
+	if !union.Equal(expected) || union.nodesCount != expected.nodesCount || union.maxWordSize != 4 {
+		t.Error("Union failed:", strings.Join(union.Completions("", 0), " "))
+	}
+	if err := union.VerifyMinimal(); err != nil {
+		t.Error("Union should be minimal:", err)
+	}
+	if empty := Union(CreateDAWG(nil), CreateDAWG(nil)); empty.nodesCount != 1 || len(empty.Completions("", 0)) != 0 {
+		t.Error("Union of empty DAWGs failed")
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	a := CreateDAWG([]string{"test", "tes", "nest", "", "notes"})
+	b := CreateDAWG([]string{"test", "rest", "note", "", "nest"})
+
+	intersection := Intersect(a, b)
+	expected := CreateDAWG([]string{"test", "nest", ""})
+	if !intersection.Equal(expected) || intersection.nodesCount != expected.nodesCount || intersection.maxWordSize != 4 {
+		t.Error("Intersect failed:", strings.Join(intersection.Completions("", 0), " "))
+	}
+	if err := intersection.VerifyMinimal(); err != nil {
+		t.Error("Intersect should be minimal:", err)
+	}
+	if empty := Intersect(a, CreateDAWG([]string{"other"})); empty.nodesCount != 1 || len(empty.Completions("", 0)) != 0 {
+		t.Error("Intersect failed")
+	}
+}
+
+func TestDifference(t *testing.T) {
+	a := CreateDAWG([]string{"test", "tes", "nest", "", "notes"})
+	b := CreateDAWG([]string{"test", "rest", "note", "", "nest"})
+
+	difference := Difference(a, b)
+	expected := CreateDAWG([]string{"tes", "notes"})
+	if !difference.Equal(expected) || difference.nodesCount != expected.nodesCount || difference.maxWordSize != 5 {
+		t.Error("Difference failed:", strings.Join(difference.Completions("", 0), " "))
+	}
+	if err := difference.VerifyMinimal(); err != nil {
+		t.Error("Difference should be minimal:", err)
+	}
+	if !Difference(b, a).Equal(CreateDAWG([]string{"rest", "note"})) {
+		t.Error("Difference failed")
+	}
+}
@@ -0,0 +1,133 @@
+package dawg
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+// ErrNotSorted is returned (wrapped in a LineError) when the words given to CreateDAWGFromSorted are not sorted
+var ErrNotSorted = errors.New("Words not sorted.")
+
+// Create a new DAWG from an array of words sorted in lexicographic order (duplicates are allowed).
+// The DAWG is built incrementally (Daciuk et al. algorithm) and is minimal at any time, so the trie
+// of all the words is never built: the memory needed is proportional to the size of the DAWG.
+func CreateDAWGFromSorted(words []string) (*DAWG, error) {
+	builder := newSortedBuilder(BuildOptions{})
+	for i, word := range words {
+		if err := builder.add(word, i+1); err != nil {
+			return nil, err
+		}
+	}
+	return builder.dawg(), nil
+}
+
+// A sortedBuilder adds sorted words to a DAWG, minimizing it as the words are added.
+// Only the states of the last word added may not be minimized yet.
+type sortedBuilder struct {
+	options      BuildOptions
+	arena        arena
+	initialState *state
+	register     map[string]*state // The minimized states, by signature
+	ids          map[*state]uint64 // Number of the minimized states, for the signatures
+	key          []byte
+
+	hasWords bool
+	lastWord []rune
+	path     []*state    // path[i] is the state reached by the first i letters of the last word
+	letters  [][]*letter // letters[i] are the letters of path[i], sorted
+
+	nbNodes     uint64 // Number of states
+	trieNodes   uint64 // Number of states created (before minimization)
+	maxWordSize int
+}
+
+// Create a new builder of an empty DAWG
+func newSortedBuilder(options BuildOptions) *sortedBuilder {
+	initialState := &state{final: false}
+	return &sortedBuilder{
+		options:      options,
+		initialState: initialState,
+		register:     make(map[string]*state),
+		ids:          make(map[*state]uint64),
+		path:         []*state{initialState},
+		letters:      [][]*letter{nil},
+		nbNodes:      1,
+		trieNodes:    1,
+	}
+}
+
+// Add the word found at the given line, which must not be lower than the last word added
+func (builder *sortedBuilder) add(word string, line int) error {
+	if builder.options.MaxWordLength > 0 && utf8.RuneCountInString(word) > builder.options.MaxWordLength {
+		return &LineError{Line: line, Err: ErrWordTooLong}
+	}
+	// Comparing the strings compares the runes, UTF-8 preserving the order
+	if builder.hasWords {
+		if last := string(builder.lastWord); word < last {
+			return &LineError{Line: line, Err: ErrNotSorted}
+		} else if word == last && builder.options.Duplicates == RejectDuplicates {
+			return &LineError{Line: line, Err: ErrDuplicate}
+		} else if word == last {
+			return nil
+		}
+	}
+	runes := []rune(word)
+	common := 0
+	for common < len(runes) && common < len(builder.lastWord) && runes[common] == builder.lastWord[common] {
+		common++
+	}
+	// The states after the common prefix can't change anymore
+	builder.minimize(common)
+	for _, char := range runes[common:] {
+		newState := builder.arena.newState()
+		depth := len(builder.path) - 1
+		builder.letters[depth] = append(builder.letters[depth], builder.arena.newLetter(char, newState))
+		builder.path = append(builder.path, newState)
+		builder.letters = append(builder.letters, nil)
+		builder.nbNodes++
+		builder.trieNodes++
+	}
+	builder.path[len(builder.path)-1].final = true
+	builder.hasWords, builder.lastWord = true, runes
+	builder.maxWordSize = max(builder.maxWordSize, len(runes))
+	return nil
+}
+
+// Minimize the states of the path deeper than depth: each of them is replaced by an equal state of
+// the register, or added to the register
+func (builder *sortedBuilder) minimize(depth int) {
+	for i := len(builder.path) - 1; i > depth; i-- {
+		curState := builder.path[i]
+		curState.letters = builder.letters[i]
+		builder.key = curState.signature(builder.key[:0], builder.ids)
+		if sameState, ok := builder.register[string(builder.key)]; ok {
+			parentLetters := builder.letters[i-1]
+			parentLetters[len(parentLetters)-1].state = sameState
+			builder.arena.free(curState)
+			builder.nbNodes--
+		} else {
+			builder.register[string(builder.key)] = curState
+			builder.ids[curState] = uint64(len(builder.ids))
+		}
+	}
+	builder.path = builder.path[:depth+1]
+	builder.letters = builder.letters[:depth+1]
+}
+
+// Minimize the last word, and get the DAWG
+func (builder *sortedBuilder) dawg() *DAWG {
+	builder.minimize(0)
+	builder.initialState.letters = builder.letters[0]
+	countWords(builder.initialState, make(map[*state]bool))
+	return &DAWG{initialState: relocate(builder.initialState), nodesCount: builder.nbNodes, trieNodesCount: builder.trieNodes, maxWordSize: builder.maxWordSize}
+}
+
+// Get a builder of a trie with the words added so far, to add words in any order
+func (builder *sortedBuilder) trieBuilder() *builder {
+	trieBuilder := newBuilder(builder.options)
+	walkSorted(builder.dawg().initialState, nil, func(word []rune) bool {
+		trieBuilder.add(string(word), 0) // Already checked
+		return true
+	})
+	return trieBuilder
+}
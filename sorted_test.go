@@ -0,0 +1,46 @@
+package dawg
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestCreateDAWGFromSorted(t *testing.T) {
+	words := []string{"", "tes", "test", "test", "test2", "tese", "nest", "note", "日本"}
+	sort.Strings(words)
+	dawg, err := CreateDAWGFromSorted(words)
+	if err != nil {
+		t.Fatal("CreateDAWGFromSorted failed:", err)
+	}
+	expected := CreateDAWG(words)
+	if !dawg.Equal(expected) || dawg.nodesCount != expected.nodesCount || dawg.maxWordSize != 5 {
+		t.Error("CreateDAWGFromSorted failed")
+	}
+	if err := dawg.VerifyMinimal(); err != nil {
+		t.Error("CreateDAWGFromSorted should be minimal:", err)
+	}
+	if found, _ := dawg.Search("tess", 1, 10, false, false); len(found) != 2 {
+		t.Error("Search failed after CreateDAWGFromSorted")
+	}
+
+	_, err = CreateDAWGFromSorted([]string{"b", "c", "a"})
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 3 || !errors.Is(err, ErrNotSorted) {
+		t.Error("CreateDAWGFromSorted should fail on unsorted words")
+	}
+}
+
+func TestCreateDAWGUnsorted(t *testing.T) {
+	// Sorted at first, then through a trie
+	dawg := CreateDAWG([]string{"nest", "test", "tese", "note", "tes"})
+	sorted, _ := CreateDAWGFromSorted([]string{"nest", "note", "tes", "tese", "test"})
+	if err := dawg.VerifyMinimal(); err != nil || dawg.nodesCount != sorted.nodesCount || !dawg.Equal(sorted) {
+		t.Error("CreateDAWG failed:", err)
+	}
+	for _, word := range []string{"nest", "test", "tese", "note", "tes"} {
+		if !dawg.Contains(word) {
+			t.Error("CreateDAWG lost", word)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package dawg
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SpellCheckerOptions configures a SpellChecker
+type SpellCheckerOptions struct {
+	SuggestOptions SuggestOptions // Options used to search the suggestions (the Distance is tuned by word size by default)
+	// If not set, a capitalized ("Word") or upper case ("WORD") word is correct when its lower case version
+	// is in the DAWG, and its suggestions are capitalized or upper case too
+	CaseSensitive bool
+}
+
+// SpellChecker checks the spelling of words and suggests corrections with the words of a DAWG,
+// handling the normalization and the case of the words and the ranking of the suggestions
+type SpellChecker struct {
+	dawg    *DAWG
+	options SpellCheckerOptions
+}
+
+// Create a spell checker using the words of the DAWG
+func NewSpellChecker(dawg *DAWG, options SpellCheckerOptions) *SpellChecker {
+	return &SpellChecker{dawg: dawg, options: options}
+}
+
+// Check if the word is correctly spelled
+func (checker *SpellChecker) Check(word string) bool {
+	word = checker.options.SuggestOptions.normalize(word)
+	if checker.dawg.Contains(word) {
+		return true
+	}
+	if !checker.options.CaseSensitive {
+		for _, variant := range caseVariants(word) {
+			if checker.dawg.Contains(variant) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Get at most n corrections of the word (all of them if n <= 0), best first (a correct word is usually its own
+// first suggestion).
+// The suggestions are the ones of SuggestWithOptions: the allowed distance grows with the size of the word,
+// and the frequent words come first if the DAWG was built with frequencies.
+func (checker *SpellChecker) Suggest(word string, n int) []string {
+	options := checker.options.SuggestOptions
+	word = options.normalize(word)
+	queries := []string{word}
+	wordCase := caseOf(word)
+	if !checker.options.CaseSensitive && wordCase != lowerCase {
+		queries = append(queries, strings.ToLower(word))
+	}
+	found := make(map[string]Suggestion)
+	for _, query := range queries {
+		for _, suggestion := range checker.dawg.SuggestWithOptions(query, n, options) {
+			if previous, ok := found[suggestion.Word]; !ok || suggestion.Score < previous.Score {
+				found[suggestion.Word] = suggestion
+			}
+		}
+	}
+
+	words := make([]string, 0, max(n, 0))
+	seen := make(map[string]bool)
+	for _, suggestion := range rankSuggestions(found, len(found)) {
+		suggested := suggestion.Word
+		if !checker.options.CaseSensitive {
+			suggested = withCase(suggested, wordCase)
+		}
+		if !seen[suggested] {
+			seen[suggested] = true
+			words = append(words, suggested)
+		}
+		if len(words) == n {
+			break
+		}
+	}
+	return words
+}
+
+// Case of the letters of a word
+type letterCase int
+
+const (
+	lowerCase letterCase = iota // "word"
+	titleCase                   // "Word"
+	upperCase                   // "WORD"
+	mixedCase                   // "WoRd"
+)
+
+// Get the case of the letters of the word
+func caseOf(word string) letterCase {
+	letters, upper, upperFirst := 0, 0, false
+	for i, char := range word {
+		if !unicode.IsLetter(char) {
+			continue
+		}
+		letters++
+		if unicode.IsUpper(char) || unicode.IsTitle(char) {
+			upper++
+			upperFirst = upperFirst || i == 0
+		}
+	}
+	switch {
+	case upper == 0:
+		return lowerCase
+	case upper == letters && letters > 1:
+		return upperCase
+	case upper == 1 && upperFirst:
+		return titleCase
+	}
+	return mixedCase
+}
+
+// Get the versions of a capitalized or upper case word which may be in a dictionary instead of it
+func caseVariants(word string) []string {
+	switch caseOf(word) {
+	case titleCase:
+		return []string{strings.ToLower(word)}
+	case upperCase:
+		lower := strings.ToLower(word)
+		return []string{lower, withCase(lower, titleCase)}
+	}
+	return nil
+}
+
+// Get the word with the case of another word (the upper case letters of a capitalized word are kept)
+func withCase(word string, wordCase letterCase) string {
+	switch wordCase {
+	case titleCase:
+		first, size := utf8.DecodeRuneInString(word)
+		return string(unicode.ToTitle(first)) + word[size:]
+	case upperCase:
+		return strings.ToUpper(word)
+	}
+	return word
+}
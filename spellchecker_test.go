@@ -0,0 +1,44 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSpellChecker(t *testing.T) {
+	dawg := CreateDAWG([]string{"hello", "help", "held", "Paris", "NASA", "test", "tests", "nest"})
+	checker := NewSpellChecker(dawg, SpellCheckerOptions{})
+
+	for _, word := range []string{"hello", "Hello", "HELLO", "Paris", "PARIS", "NASA"} {
+		if !checker.Check(word) {
+			t.Error("Check failed:", word)
+		}
+	}
+	for _, word := range []string{"helo", "paris", "nasa", "HeLLo"} {
+		if checker.Check(word) {
+			t.Error("Check should fail:", word)
+		}
+	}
+	if NewSpellChecker(dawg, SpellCheckerOptions{CaseSensitive: true}).Check("Hello") {
+		t.Error("Case sensitive Check failed")
+	}
+
+	if suggestions := checker.Suggest("tesst", 2); !slices.Equal(suggestions, []string{"test", "nest"}) {
+		t.Error("Suggest failed:", suggestions)
+	}
+	if suggestions := checker.Suggest("Helo", 2); !slices.Equal(suggestions, []string{"Held", "Hello"}) {
+		t.Error("Suggest of a capitalized word failed:", suggestions)
+	}
+	if suggestions := checker.Suggest("PARIZ", 1); !slices.Equal(suggestions, []string{"PARIS"}) {
+		t.Error("Suggest of an upper case word failed:", suggestions)
+	}
+	if suggestions := checker.Suggest("test", 1); !slices.Equal(suggestions, []string{"test"}) || len(checker.Suggest("test", 0)) != 3 {
+		t.Error("Suggest of a correct word failed:", suggestions)
+	}
+
+	// Only the words at distance 1 with a stricter budget
+	strict := NewSpellChecker(dawg, SpellCheckerOptions{SuggestOptions: SuggestOptions{Distance: func(int) int { return 1 }}})
+	if suggestions := strict.Suggest("helpp", 5); !slices.Equal(suggestions, []string{"help"}) {
+		t.Error("Suggest with a distance failed:", suggestions)
+	}
+}
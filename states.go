@@ -0,0 +1,92 @@
+package dawg
+
+import "iter"
+
+// Get the number of the state reached by the prefix, as numbered by the serializations (Save, WriteDot,
+// WriteGraphJSON...): the states are numbered breadth-first from the initial state 0, following the letters in
+// rune order. ok is false if no word starts with the prefix.
+// The states are numbered on each call, so the whole DAWG is walked.
+func (dawg *DAWG) StateOf(prefix string) (id uint, ok bool) {
+	curState := dawg.initialState
+	for _, char := range prefix {
+		curLetter := curState.getletter(char)
+		if curLetter == nil {
+			return 0, false
+		}
+		curState = curLetter.state
+	}
+	_, numbers := numberStates(dawg.initialState)
+	return uint(numbers[curState]), true
+}
+
+// Iterate over the words passing through the state with the number (see StateOf), in lexicographic order:
+// the words starting with one of the prefixes reaching the state, and ending with one of the suffixes leaving it.
+// It shows which words share the state after the minimization, to understand why states were merged.
+// Nothing is iterated over if there is no state with the number.
+func (dawg *DAWG) WordsThroughState(id uint) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		states, _ := numberStates(dawg.initialState)
+		if id >= uint(len(states)) {
+			return
+		}
+		walker := &stateWalker{target: states[id], reaches: make(map[*state]bool)}
+		walker.walk(dawg.initialState, nil, false, func(word []rune) bool {
+			return yield(string(word))
+		})
+	}
+}
+
+// A stateWalker walks the words passing through a state
+type stateWalker struct {
+	target  *state
+	reaches map[*state]bool // If the state leads to the target, for the states already checked
+}
+
+// Check if the target can be reached from the state
+func (walker *stateWalker) leadsToTarget(curState *state) bool {
+	if curState == walker.target {
+		return true
+	}
+	reaches, ok := walker.reaches[curState]
+	if !ok {
+		for _, curLetter := range curState.letters {
+			if reaches = walker.leadsToTarget(curLetter.state); reaches {
+				break
+			}
+		}
+		walker.reaches[curState] = reaches
+	}
+	return reaches
+}
+
+// Walk the words from the state, only following the letters leading to the target until it is passed.
+// Return false if fn stopped the walk.
+func (walker *stateWalker) walk(curState *state, word []rune, passed bool, fn func(word []rune) bool) bool {
+	passed = passed || curState == walker.target
+	if passed && curState.final && !fn(word) {
+		return false
+	}
+	for _, curLetter := range curState.letters {
+		if passed || walker.leadsToTarget(curLetter.state) {
+			if !walker.walk(curLetter.state, append(word, curLetter.char), passed, fn) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Get the first prefix leading from the state to the target, in lexicographic order, appended to prefix
+// (the target must be reachable from the state)
+func (walker *stateWalker) firstPrefix(curState *state, prefix []rune) []rune {
+	for curState != walker.target {
+		for _, curLetter := range curState.letters {
+			if walker.leadsToTarget(curLetter.state) {
+				prefix = append(prefix, curLetter.char)
+				curState = curLetter.state
+				break
+			}
+		}
+	}
+	return prefix
+}
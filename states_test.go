@@ -0,0 +1,45 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWordsThroughState(t *testing.T) {
+	dawg := CreateDAWG([]string{"cat", "cats", "bat", "bats", "car", "ca"})
+
+	// "ca" and "ba" don't lead to the same state ("ca" is a word, "car" too), "cat" and "bat" do
+	id, ok := dawg.StateOf("cat")
+	if other, _ := dawg.StateOf("bat"); !ok || other != id {
+		t.Fatal("StateOf failed")
+	}
+	if words := slices.Collect(dawg.WordsThroughState(id)); !slices.Equal(words, []string{"bat", "bats", "cat", "cats"}) {
+		t.Error("WordsThroughState failed", words)
+	}
+	id, _ = dawg.StateOf("ca")
+	if words := slices.Collect(dawg.WordsThroughState(id)); !slices.Equal(words, []string{"ca", "car", "cat", "cats"}) {
+		t.Error("WordsThroughState of a final state failed", words)
+	}
+	if words := slices.Collect(dawg.WordsThroughState(0)); !slices.Equal(words, slices.Collect(dawg.Words())) {
+		t.Error("WordsThroughState of the initial state failed", words)
+	}
+	for word := range dawg.WordsThroughState(0) {
+		if word != "bat" {
+			t.Error("WordsThroughState stop failed", word)
+		}
+		break
+	}
+
+	if _, ok := dawg.StateOf("x"); ok {
+		t.Error("StateOf of a missing prefix failed")
+	}
+	if words := slices.Collect(dawg.WordsThroughState(1000)); words != nil {
+		t.Error("WordsThroughState of a missing state failed", words)
+	}
+
+	// The numbers are the ones of the serializations
+	states, numbers := numberStates(dawg.initialState)
+	if id, _ := dawg.StateOf("cats"); states[id] != dawg.initialState.getletter('c').state.getletter('a').state.getletter('t').state.getletter('s').state || uint(numbers[states[id]]) != id {
+		t.Error("StateOf numbering failed")
+	}
+}
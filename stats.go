@@ -0,0 +1,176 @@
+package dawg
+
+import "unsafe"
+
+// Stats describes the structure of a DAWG
+type Stats struct {
+	Nodes       uint64
+	Transitions uint64
+	Words       uint64
+	Levels      []LevelStats // Levels[i] describes the states at depth i
+	WordLengths []uint64     // WordLengths[i] is the number of words of i letters
+
+	// Compression of the trie into the DAWG.
+	// The trie is only known when the DAWG has been built, not when it has been loaded from a file:
+	// TrieNodes and TrieBytes are 0 otherwise.
+	TrieNodes uint64 // Number of nodes of the trie, before its compression
+	TrieBytes uint64 // Approximate memory used by the trie
+	Bytes     uint64 // Approximate memory used by the DAWG
+}
+
+// LevelStats describes the states at a given depth of a DAWG.
+// The depth of a state is the length of the shortest path from the initial state to it.
+type LevelStats struct {
+	States      uint64
+	Transitions uint64 // Transitions going out of these states
+}
+
+// Compute statistics on the structure of the DAWG
+func (dawg *DAWG) Stats() (stats Stats) {
+	// Breadth-first traversal, to get the depth of each state
+	visited := map[*state]bool{dawg.initialState: true}
+	for level := []*state{dawg.initialState}; len(level) > 0; {
+		var levelStats LevelStats
+		var nextLevel []*state
+		for _, curState := range level {
+			levelStats.States++
+			levelStats.Transitions += uint64(len(curState.letters))
+			for _, curLetter := range curState.letters {
+				if !visited[curLetter.state] {
+					visited[curLetter.state] = true
+					nextLevel = append(nextLevel, curLetter.state)
+				}
+			}
+		}
+		stats.Levels = append(stats.Levels, levelStats)
+		stats.Nodes += levelStats.States
+		stats.Transitions += levelStats.Transitions
+		level = nextLevel
+	}
+
+	stats.WordLengths = wordLengths(dawg.initialState, make(map[*state][]uint64))
+	for _, count := range stats.WordLengths {
+		stats.Words += count
+	}
+
+	stats.Bytes = graphBytes(stats.Nodes, stats.Transitions)
+	if dawg.trieNodesCount != 0 {
+		// Each node of a trie, except its root, has exactly one incoming transition
+		stats.TrieNodes = dawg.trieNodesCount
+		stats.TrieBytes = graphBytes(stats.TrieNodes, stats.TrieNodes-1)
+	}
+	return
+}
+
+// Get the number of states of the DAWG
+func (dawg *DAWG) NodesCount() uint64 {
+	return dawg.nodesCount
+}
+
+// Get the number of transitions (letters) of the DAWG. They are counted by walking the DAWG.
+func (dawg *DAWG) EdgesCount() uint64 {
+	var edges uint64
+	visited := map[*state]bool{dawg.initialState: true}
+	for pending := []*state{dawg.initialState}; len(pending) > 0; {
+		curState := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		edges += uint64(len(curState.letters))
+		for _, curLetter := range curState.letters {
+			if !visited[curLetter.state] {
+				visited[curLetter.state] = true
+				pending = append(pending, curLetter.state)
+			}
+		}
+	}
+	return edges
+}
+
+// Get the number of words of the DAWG (kept up to date by the builds and the changes of the words)
+func (dawg *DAWG) WordsCount() uint64 {
+	return dawg.initialState.wordsCount
+}
+
+// Get the approximate memory used by the states and the letters of the DAWG, in bytes
+func (dawg *DAWG) ApproxMemory() uint64 {
+	return graphBytes(dawg.nodesCount, dawg.EdgesCount())
+}
+
+// Get the length of the longest word of the DAWG, in runes
+func (dawg *DAWG) LongestWordLen() int {
+	return dawg.maxWordSize
+}
+
+// Get the length of the shortest word of the DAWG, in runes (0 if the DAWG has no words).
+// The states are walked breadth-first, up to the first final state.
+func (dawg *DAWG) ShortestWordLen() int {
+	visited := map[*state]bool{dawg.initialState: true}
+	for length, level := 0, []*state{dawg.initialState}; len(level) > 0; length++ {
+		var nextLevel []*state
+		for _, curState := range level {
+			if curState.final {
+				return length
+			}
+			for _, curLetter := range curState.letters {
+				if !visited[curLetter.state] {
+					visited[curLetter.state] = true
+					nextLevel = append(nextLevel, curLetter.state)
+				}
+			}
+		}
+		level = nextLevel
+	}
+	return 0
+}
+
+// Get the number of words of each length: histogram[i] is the number of words of i runes
+// (the histogram stops at the longest word)
+func (dawg *DAWG) LengthHistogram() (histogram []uint64) {
+	return wordLengths(dawg.initialState, make(map[*state][]uint64))
+}
+
+// Get the number of bytes saved by the compression of the trie into the DAWG (0 if the trie is unknown)
+func (stats Stats) BytesSaved() uint64 {
+	if stats.TrieBytes < stats.Bytes {
+		return 0
+	}
+	return stats.TrieBytes - stats.Bytes
+}
+
+// Approximate memory used by a graph of nodes and transitions
+func graphBytes(nodes uint64, transitions uint64) uint64 {
+	return nodes*uint64(unsafe.Sizeof(state{})) + transitions*uint64(unsafe.Sizeof(letter{})+unsafe.Sizeof(&letter{}))
+}
+
+// Get the number of words of each length under the state (memoized in lengths)
+func wordLengths(curState *state, lengths map[*state][]uint64) []uint64 {
+	if counts, ok := lengths[curState]; ok {
+		return counts
+	}
+	counts := []uint64{0}
+	if curState.final {
+		counts[0] = 1
+	}
+	for _, curLetter := range curState.letters {
+		for i, count := range wordLengths(curLetter.state, lengths) {
+			for len(counts) <= i+1 {
+				counts = append(counts, 0)
+			}
+			counts[i+1] += count
+		}
+	}
+	lengths[curState] = counts
+	return counts
+}
+
+// Get the length of the longest word under the state (memoized in lengths)
+func longestWord(curState *state, lengths map[*state]int) int {
+	if length, ok := lengths[curState]; ok {
+		return length
+	}
+	length := 0
+	for _, curLetter := range curState.letters {
+		length = max(length, longestWord(curLetter.state, lengths)+1)
+	}
+	lengths[curState] = length
+	return length
+}
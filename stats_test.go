@@ -0,0 +1,53 @@
+package dawg
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "rest", "nest", "note", "no"})
+
+	stats := dawg.Stats()
+	if stats.Nodes != dawg.nodesCount || stats.Words != 5 || len(stats.Levels) != 5 || len(stats.WordLengths) != 5 {
+		t.Fatal("Stats failed")
+	}
+	if stats.Levels[0] != (LevelStats{States: 1, Transitions: 3}) || stats.Levels[1].States != 2 {
+		t.Error("Stats levels failed")
+	}
+	if stats.WordLengths[2] != 1 || stats.WordLengths[4] != 4 {
+		t.Error("Stats word lengths failed")
+	}
+	// The trie has 16 nodes: the root, 4 for test, rest and nest, and 3 for note (no is a prefix of note)
+	if stats.TrieNodes != 16 || stats.Nodes != 8 || stats.TrieBytes <= stats.Bytes || stats.BytesSaved() != stats.TrieBytes-stats.Bytes {
+		t.Error("Stats compression failed")
+	}
+}
+
+func TestStatsAccessors(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "rest", "nest", "note", "no"})
+	stats := dawg.Stats()
+	if dawg.NodesCount() != stats.Nodes || dawg.EdgesCount() != stats.Transitions || dawg.WordsCount() != 5 || dawg.ApproxMemory() != stats.Bytes {
+		t.Error("Stats accessors failed")
+	}
+
+	dawg.Add("nests")
+	dawg.Remove("rest")
+	stats = dawg.Stats()
+	if dawg.NodesCount() != stats.Nodes || dawg.EdgesCount() != stats.Transitions || dawg.WordsCount() != 5 {
+		t.Error("Stats accessors after changes failed")
+	}
+}
+
+func TestWordLengths(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "rest", "nest", "note", "no", "testing"})
+	if dawg.LongestWordLen() != 7 || dawg.ShortestWordLen() != 2 {
+		t.Error("Longest and shortest words failed")
+	}
+	histogram := dawg.LengthHistogram()
+	if len(histogram) != 8 || histogram[2] != 1 || histogram[4] != 4 || histogram[7] != 1 || histogram[3] != 0 {
+		t.Error("LengthHistogram failed")
+	}
+
+	empty := CreateDAWG(nil)
+	if empty.LongestWordLen() != 0 || empty.ShortestWordLen() != 0 || len(empty.LengthHistogram()) != 1 {
+		t.Error("Lengths of an empty DAWG failed")
+	}
+}
@@ -0,0 +1,76 @@
+package dawg
+
+import (
+	"slices"
+	"unicode/utf8"
+)
+
+// Get a new DAWG of the words starting with the prefix, without the prefix ("te" gives "st" and "xt" for "test"
+// and "text", and "" for "te"). The states under the prefix are copied, without walking the words, so the new DAWG
+// is minimal and independent of this one (to shard a large DAWG, for example). The frequencies and the flags, if any, are kept.
+func (dawg *DAWG) Sub(prefix string) *DAWG {
+	return dawg.sub(prefix, false)
+}
+
+// Same as Sub, keeping the prefix at the start of the words ("te" gives "test" and "text")
+func (dawg *DAWG) SubWithPrefix(prefix string) *DAWG {
+	return dawg.sub(prefix, true)
+}
+
+// Get a new DAWG of the words starting with the prefix, with or without the prefix
+func (dawg *DAWG) sub(prefix string, keepPrefix bool) *DAWG {
+	index, prefixState := dawg.rank(prefix)
+	if prefixState == nil || prefixState.wordsCount == 0 {
+		sub := &DAWG{initialState: &state{final: false}, nodesCount: 1}
+		if dawg.frequencies != nil {
+			sub.frequencies = []uint64{}
+		}
+		if dawg.flags != nil {
+			sub.flags = []uint32{}
+		}
+		return sub
+	}
+
+	copies := make(map[*state]*state)
+	initialState := copySubStates(prefixState, copies)
+	sub := &DAWG{initialState: initialState, nodesCount: uint64(len(copies)), maxWordSize: longestWord(initialState, make(map[*state]int))}
+	if keepPrefix {
+		// A chain of states for the letters of the prefix. No state of the copy can be equivalent to them,
+		// as its words would be shorter than the words under them.
+		runes := []rune(prefix)
+		for i := len(runes) - 1; i >= 0; i-- {
+			previous := &state{final: false, wordsCount: initialState.wordsCount}
+			previous.letters = []*letter{{char: runes[i], state: initialState}}
+			initialState = previous
+		}
+		sub.initialState = initialState
+		sub.nodesCount += uint64(len(runes))
+		sub.maxWordSize += utf8.RuneCountInString(prefix)
+	}
+	if dawg.frequencies != nil {
+		sub.frequencies = slices.Clone(dawg.frequencies[index : index+uint(prefixState.wordsCount)])
+		sub.maxFrequency = slices.Max(append(sub.frequencies, 0))
+	}
+	if dawg.flags != nil {
+		sub.flags = slices.Clone(dawg.flags[index : index+uint(prefixState.wordsCount)])
+	}
+	return sub
+}
+
+// Get a copy of the state and of the states under it, each state being copied once (copies holds the copy of each state)
+func copySubStates(curState *state, copies map[*state]*state) *state {
+	if copied, ok := copies[curState]; ok {
+		return copied
+	}
+	copied := &state{final: curState.final, wordsCount: curState.wordsCount}
+	letters := curState.letters
+	copyLetters := make([]letter, len(letters))
+	sortedLetters := make([]*letter, len(letters))
+	for i, curLetter := range letters {
+		copyLetters[i] = letter{char: curLetter.char, state: copySubStates(curLetter.state, copies)}
+		sortedLetters[i] = &copyLetters[i]
+	}
+	copied.letters = sortedLetters
+	copies[curState] = copied
+	return copied
+}
@@ -0,0 +1,42 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSub(t *testing.T) {
+	dawg := CreateDAWG([]string{"te", "test", "tests", "text", "rest", "nest", "日本", "日本語"})
+
+	sub := dawg.Sub("te")
+	if !slices.Equal(slices.Collect(sub.Words()), []string{"", "st", "sts", "xt"}) || sub.Verify() != nil {
+		t.Error("Sub failed:", slices.Collect(sub.Words()), sub.Verify())
+	}
+	sub = dawg.SubWithPrefix("te")
+	if !slices.Equal(slices.Collect(sub.Words()), []string{"te", "test", "tests", "text"}) || sub.Verify() != nil {
+		t.Error("SubWithPrefix failed:", slices.Collect(sub.Words()), sub.Verify())
+	}
+	sub = dawg.SubWithPrefix("日")
+	if !slices.Equal(slices.Collect(sub.Words()), []string{"日本", "日本語"}) || sub.Verify() != nil || sub.LongestWordLen() != 3 {
+		t.Error("SubWithPrefix failed with a multibyte prefix")
+	}
+	if sub = dawg.Sub(""); !sub.Equal(dawg) || sub.Verify() != nil {
+		t.Error("Sub failed with an empty prefix")
+	}
+	if sub = dawg.Sub("x"); sub.WordsCount() != 0 || sub.Verify() != nil {
+		t.Error("Sub failed with a missing prefix")
+	}
+
+	// The sub DAWG is independent
+	sub = dawg.Sub("te")
+	sub.Add("a")
+	if !dawg.Contains("test") || dawg.Contains("a") || sub.Verify() != nil || dawg.Verify() != nil {
+		t.Error("Sub failed: the DAWGs are not independent")
+	}
+
+	withFrequencies := CreateDAWGWithFrequencies(map[string]uint64{"rest": 1, "test": 3, "text": 2, "vest": 5})
+	sub = withFrequencies.Sub("te")
+	if sub.Frequency("st") != 3 || sub.Frequency("xt") != 2 || sub.maxFrequency != 3 {
+		t.Error("Sub failed with frequencies")
+	}
+}
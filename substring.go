@@ -0,0 +1,71 @@
+package dawg
+
+import (
+	"slices"
+	"strings"
+)
+
+// Separator between the end and the beginning of a word in the paths of a SubstringIndex
+const substringSeparator = '\x00'
+
+// SubstringIndex finds the words of a DAWG containing a substring without scanning all the words.
+// It is a permuterm index: for each word w = u.v (v not empty), its DAWG contains the path v ◇ u,
+// so the words containing s are found under the paths starting with s.
+// Changing the DAWG after the index is built is not supported.
+type SubstringIndex struct {
+	dawg      *DAWG
+	rotations *DAWG // The rotations of the words of dawg
+}
+
+// Build the substring index of the words of the DAWG.
+// Each word of n letters adds n paths, so the index is usually several times bigger than the DAWG.
+func (dawg *DAWG) WithSubstrings() *SubstringIndex {
+	var rotations []string
+	walkSorted(dawg.initialState, nil, func(word []rune) bool {
+		for i := range word {
+			rotations = append(rotations, string(word[i:])+string(substringSeparator)+string(word[:i]))
+		}
+		return true
+	})
+	// Sorted, the paths are added to a minimal DAWG without building their trie
+	slices.Sort(rotations)
+	builder := newSortedBuilder(BuildOptions{})
+	for _, rotation := range rotations {
+		builder.add(rotation, 0) // Can't fail, the paths being sorted
+	}
+	return &SubstringIndex{dawg: dawg, rotations: builder.dawg()}
+}
+
+// Check if at least one word of the DAWG contains the substring
+func (index *SubstringIndex) ContainsSubstring(substring string) bool {
+	if substring == "" {
+		return index.dawg.WordsCount() > 0
+	}
+	return !strings.ContainsRune(substring, substringSeparator) && index.rotations.HasPrefix(substring)
+}
+
+// Get the words of the DAWG containing the substring, in lexicographic order.
+// At most max words are returned (all of them if max <= 0).
+func (index *SubstringIndex) FindWordsContaining(substring string, max int) []string {
+	if substring == "" {
+		return index.dawg.Completions("", max)
+	}
+	words := []string{}
+	if strings.ContainsRune(substring, substringSeparator) {
+		return words
+	}
+	// A word containing the substring several times has several paths starting with it
+	found := make(map[string]bool)
+	for _, rotation := range index.rotations.Completions(substring, 0) {
+		end, start, _ := strings.Cut(rotation, string(substringSeparator))
+		if word := start + end; !found[word] {
+			found[word] = true
+			words = append(words, word)
+		}
+	}
+	slices.Sort(words)
+	if max > 0 && len(words) > max {
+		words = words[:max]
+	}
+	return words
+}
@@ -0,0 +1,28 @@
+package dawg
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSubstringIndex(t *testing.T) {
+	dawg := CreateDAWG([]string{"puzzle", "puzzles", "nuzzle", "dazzle", "zzz", "bee"})
+	index := dawg.WithSubstrings()
+
+	if words := index.FindWordsContaining("zzl", 0); !slices.Equal(words, []string{"dazzle", "nuzzle", "puzzle", "puzzles"}) {
+		t.Error("FindWordsContaining failed:", words)
+	}
+	if words := index.FindWordsContaining("zz", 2); !slices.Equal(words, []string{"dazzle", "nuzzle"}) {
+		t.Error("FindWordsContaining max failed:", words)
+	}
+	// "zzz" contains "zz" twice
+	if words := index.FindWordsContaining("zz", 0); len(words) != 5 || words[4] != "zzz" {
+		t.Error("FindWordsContaining failed:", words)
+	}
+	if words := index.FindWordsContaining("", 0); len(words) != 6 {
+		t.Error("FindWordsContaining of the empty string failed:", words)
+	}
+	if !index.ContainsSubstring("ee") || !index.ContainsSubstring("puzzles") || index.ContainsSubstring("zb") || index.ContainsSubstring("e\x00b") {
+		t.Error("ContainsSubstring failed")
+	}
+}
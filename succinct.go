@@ -0,0 +1,209 @@
+package dawg
+
+import (
+	"iter"
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+// SuccinctDAWG is an immutable DAWG encoded with as few bits as possible, for the very large lexicons.
+// The degrees of the nodes are encoded in unary in a bit vector (LOUDS: for each node, as many ones as
+// edges then a zero), so the edges of a node are found with select queries instead of being indexed.
+// The states of a DAWG are shared, so the nodes the edges lead to are stored, with as many bits as needed
+// to number the nodes, as are the runes (indexes in the alphabet of the DAWG) and the numbers of words.
+// It supports the same queries as CompactDAWG, a few times slower.
+type SuccinctDAWG struct {
+	louds       bitVector
+	finals      []uint64 // Bit set of the final nodes
+	alphabet    []rune   // The runes of the edges, sorted
+	labels      packedInts
+	targets     packedInts
+	wordsCounts packedInts
+}
+
+// Create the succinct version of the DAWG (see SuccinctDAWG), with the same numbers of nodes and edges
+func (compact *CompactDAWG) Succinct() *SuccinctDAWG {
+	nodesCount, edgesCount := uint64(len(compact.wordsCounts)), uint64(len(compact.chars))
+	succinct := &SuccinctDAWG{finals: slices.Clone(compact.finals)}
+
+	louds := make([]uint64, (nodesCount+edgesCount+63)/64)
+	position := uint64(0)
+	for node := range nodesCount {
+		for range compact.firstEdges[node+1] - compact.firstEdges[node] {
+			louds[position/64] |= 1 << (position % 64)
+			position++
+		}
+		position++ // Zero ending the node
+	}
+	succinct.louds = newBitVector(louds)
+
+	succinct.alphabet = slices.Clone(compact.chars)
+	slices.Sort(succinct.alphabet)
+	succinct.alphabet = slices.Compact(succinct.alphabet)
+	succinct.labels = newPackedInts(edgesCount, uint64(len(succinct.alphabet)))
+	succinct.targets = newPackedInts(edgesCount, nodesCount)
+	for edge, char := range compact.chars {
+		label, _ := slices.BinarySearch(succinct.alphabet, char)
+		succinct.labels.set(uint64(edge), uint64(label))
+		succinct.targets.set(uint64(edge), uint64(compact.targets[edge]))
+	}
+	succinct.wordsCounts = newPackedInts(nodesCount, compact.wordsCounts[0]+1)
+	for node, count := range compact.wordsCounts {
+		succinct.wordsCounts.set(uint64(node), count)
+	}
+	return succinct
+}
+
+// Get the approximate memory used by the DAWG, in bytes
+func (succinct *SuccinctDAWG) Bytes() uint64 {
+	return 8*uint64(len(succinct.louds.words)+len(succinct.louds.ranks)+len(succinct.finals)+len(succinct.labels.data)+
+		len(succinct.targets.data)+len(succinct.wordsCounts.data)) + 4*uint64(len(succinct.alphabet))
+}
+
+// Check if the node is final
+func (succinct *SuccinctDAWG) final(node uint32) bool {
+	return succinct.finals[node/64]&(1<<(node%64)) != 0
+}
+
+// Get the range of the edges of the node: the ones before the zeros ending the previous node and the node
+func (succinct *SuccinctDAWG) edges(node uint32) (first uint32, last uint32) {
+	if node > 0 {
+		first = uint32(succinct.louds.select0(uint64(node)-1) - uint64(node-1))
+	}
+	last = uint32(succinct.louds.select0(uint64(node)) - uint64(node))
+	return
+}
+
+// Get the rune of the edge
+func (succinct *SuccinctDAWG) char(edge uint32) rune {
+	return succinct.alphabet[succinct.labels.get(uint64(edge))]
+}
+
+// Get the node the edge leads to
+func (succinct *SuccinctDAWG) target(edge uint32) uint32 {
+	return uint32(succinct.targets.get(uint64(edge)))
+}
+
+// Get the number of words under the node
+func (succinct *SuccinctDAWG) wordsCount(node uint32) uint64 {
+	return succinct.wordsCounts.get(uint64(node))
+}
+
+// Check if the word is in the DAWG
+func (succinct *SuccinctDAWG) Contains(word string) bool {
+	return flatContains(succinct, word)
+}
+
+// Check if at least one word of the DAWG starts with the prefix
+func (succinct *SuccinctDAWG) HasPrefix(prefix string) bool {
+	_, ok := flatPrefixNode(succinct, prefix)
+	return ok
+}
+
+// Count the words of the DAWG starting with the prefix (the prefix itself included)
+func (succinct *SuccinctDAWG) CountWithPrefix(prefix string) int {
+	return flatCountWithPrefix(succinct, prefix)
+}
+
+// Get the number of words of the DAWG
+func (succinct *SuccinctDAWG) WordsCount() uint64 {
+	return succinct.wordsCount(0)
+}
+
+// Get the words of the DAWG starting with the prefix, in lexicographic order.
+// At most max words are returned (all of them if max <= 0).
+func (succinct *SuccinctDAWG) Completions(prefix string, max int) []string {
+	return flatCompletions(succinct, prefix, max)
+}
+
+// Iterate over all the words of the DAWG, in lexicographic order
+func (succinct *SuccinctDAWG) Words() iter.Seq[string] {
+	return flatWords(succinct)
+}
+
+// Get the index of the word among the words of the DAWG sorted in lexicographic order (see DAWG.Index)
+func (succinct *SuccinctDAWG) Index(word string) (index uint, ok bool) {
+	return flatIndex(succinct, word)
+}
+
+// Get the word at the index among the words of the DAWG sorted in lexicographic order (the reverse of Index)
+func (succinct *SuccinctDAWG) WordAt(index uint) (word string, ok bool) {
+	return flatWordAt(succinct, index)
+}
+
+// Number of bits of the blocks of a bitVector whose ranks are stored
+const rankBlockSize = 512
+
+// A bitVector answers select queries on a sequence of bits, with an overhead of 12.5%
+type bitVector struct {
+	words []uint64
+	ranks []uint64 // Number of ones before each block of rankBlockSize bits
+}
+
+// Create a bitVector of the bits
+func newBitVector(words []uint64) bitVector {
+	vector := bitVector{words: words}
+	var ones uint64
+	for i, word := range words {
+		if i%(rankBlockSize/64) == 0 {
+			vector.ranks = append(vector.ranks, ones)
+		}
+		ones += uint64(bits.OnesCount64(word))
+	}
+	return vector
+}
+
+// Get the position of the zero of the given rank (the first zero has the rank 0).
+// The zero must exist.
+func (vector *bitVector) select0(rank uint64) uint64 {
+	// The last block with at most rank zeros before it
+	block := sort.Search(len(vector.ranks), func(block int) bool {
+		return uint64(block)*rankBlockSize-vector.ranks[block] > rank
+	}) - 1
+	rank -= uint64(block)*rankBlockSize - vector.ranks[block]
+	for i := block * rankBlockSize / 64; ; i++ {
+		zeros := ^vector.words[i]
+		if count := uint64(bits.OnesCount64(zeros)); rank >= count {
+			rank -= count
+			continue
+		}
+		for range rank {
+			zeros &= zeros - 1
+		}
+		return uint64(i)*64 + uint64(bits.TrailingZeros64(zeros))
+	}
+}
+
+// packedInts is an array of integers stored with the number of bits of the largest one
+type packedInts struct {
+	bits uint64
+	data []uint64
+}
+
+// Create an array of count integers lower than limit
+func newPackedInts(count uint64, limit uint64) packedInts {
+	size := uint64(max(bits.Len64(limit-1), 1))
+	return packedInts{bits: size, data: make([]uint64, (count*size+63)/64)}
+}
+
+// Get the integer at the index
+func (ints *packedInts) get(index uint64) uint64 {
+	position := index * ints.bits
+	word, offset := position/64, position%64
+	value := ints.data[word] >> offset
+	if offset+ints.bits > 64 {
+		value |= ints.data[word+1] << (64 - offset)
+	}
+	return value & (1<<ints.bits - 1)
+}
+
+// Set the integer at the index, which must be 0
+func (ints *packedInts) set(index uint64, value uint64) {
+	position := index * ints.bits
+	word, offset := position/64, position%64
+	ints.data[word] |= value << offset
+	if offset+ints.bits > 64 {
+		ints.data[word+1] |= value >> (64 - offset)
+	}
+}
@@ -0,0 +1,77 @@
+package dawg
+
+import (
+	"math/rand"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func TestSuccinct(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	words := []string{"", "été"}
+	for i := 0; i < 3000; i++ {
+		words = append(words, strconv.Itoa(random.Intn(1000000)))
+	}
+	dawg := CreateDAWG(words)
+	compact := dawg.Compact()
+	succinct := compact.Succinct()
+
+	sorted := slices.Collect(dawg.Words())
+	if !slices.Equal(slices.Collect(succinct.Words()), sorted) || succinct.WordsCount() != uint64(len(sorted)) {
+		t.Fatal("Words failed")
+	}
+	for _, lexicon := range []Lexicon{dawg, compact, succinct} {
+		for i, word := range sorted {
+			if !lexicon.Contains(word) || !lexicon.HasPrefix(word) {
+				t.Fatal("Contains failed")
+			}
+			if index, ok := lexicon.Index(word); !ok || index != uint(i) {
+				t.Fatal("Index failed")
+			}
+			if found, ok := lexicon.WordAt(uint(i)); !ok || found != word {
+				t.Fatal("WordAt failed")
+			}
+		}
+		if lexicon.Contains("1234567") || lexicon.HasPrefix("x") || lexicon.CountWithPrefix("12") != dawg.CountWithPrefix("12") {
+			t.Error("Contains failed")
+		}
+		if !slices.Equal(lexicon.Completions("12", 5), dawg.Completions("12", 5)) {
+			t.Error("Completions failed")
+		}
+	}
+
+	if succinct.Bytes() >= dawg.Stats().Bytes/4 {
+		t.Error("Succinct size failed")
+	}
+}
+
+func TestBitVector(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	words := make([]uint64, 40)
+	var zeros []uint64
+	for i := range words {
+		words[i] = random.Uint64()
+		for bit := range uint64(64) {
+			if words[i]&(1<<bit) == 0 {
+				zeros = append(zeros, uint64(i)*64+bit)
+			}
+		}
+	}
+	vector := newBitVector(words)
+	for rank, position := range zeros {
+		if vector.select0(uint64(rank)) != position {
+			t.Fatal("select0 failed")
+		}
+	}
+
+	ints := newPackedInts(100, 1000)
+	for i := range uint64(100) {
+		ints.set(i, i*10)
+	}
+	for i := range uint64(100) {
+		if ints.get(i) != i*10 {
+			t.Fatal("packedInts failed")
+		}
+	}
+}
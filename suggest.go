@@ -0,0 +1,151 @@
+package dawg
+
+import (
+	"sort"
+	"strings"
+)
+
+// Cost of substituting a letter by the letter of an adjacent key, when a keyboard layout is used
+const adjacentKeyCost = 0.5
+
+// Suggestion is a correction proposed for a misspelled input
+// (Word may contain spaces if the input did, Distance being the Levenshtein distance between the input and Word)
+type Suggestion struct {
+	Match
+	Score float64 // Weighted edit cost between the input and Word (plus a cost for rare words, see CreateDAWGWithFrequencies), the lower the better
+}
+
+// SuggestOptions configures how suggestions are searched and ranked
+type SuggestOptions struct {
+	Layout     *KeyboardLayout // If not nil, substituting a letter by an adjacent key is cheaper than any other substitution
+	Confusions *ConfusionSet   // If not nil, graphemes of the set can be replaced by each other at the cost registered in the set
+	// Maximum distance of the suggestions, depending on the number of letters of the word
+	// (if nil, 1 up to 4 letters and 2 for longer words)
+	Distance func(wordSize int) int
+	// If not nil, the Score of the suggestions is the score of the Ranker instead of their weighted edit cost
+	Ranker Ranker
+	// Normalization of the input, to match the normalization of the words of the DAWG (see BuildOptions)
+	Normalize       func(word string) string
+	StripDiacritics bool
+	FoldCase        bool
+}
+
+// Suggest corrections for the input, best suggestions first.
+// If the input is a single word, the suggestions are the words of the DAWG close to it.
+// If the input contains spaces, the suggestions are the phrases obtained by joining two
+// consecutive tokens into a word of the DAWG ("some thing" -> "something"), the removed
+// space counting as one edit.
+// If the DAWG was built with frequencies, frequent words come before rarer words needing as many edits.
+// At most k suggestions are returned (all of them if k <= 0).
+func (dawg *DAWG) Suggest(input string, k int) []Suggestion {
+	return dawg.SuggestWithOptions(input, k, SuggestOptions{})
+}
+
+// Same as Suggest, with options to tune the search and the ranking of the suggestions
+func (dawg *DAWG) SuggestWithOptions(input string, k int, options SuggestOptions) []Suggestion {
+	input = options.normalize(input)
+	tokens := strings.Fields(input)
+	found := make(map[string]Suggestion)
+	if len(tokens) == 1 {
+		query := []rune(tokens[0])
+		start := strings.Index(input, tokens[0])
+		walkWeighted(dawg.initialState, query, float64(options.distance(len(query))), options.editCosts(), func(word []rune, cost float64) bool {
+			suggestion := Suggestion{Match: Match{Word: string(word), Distance: levenshtein(query, word), Start: start, End: start + len(tokens[0]), Matched: len(string(word))}}
+			suggestion.Score = cost + dawg.frequencyCost(suggestion.Word)
+			found[suggestion.Word] = suggestion
+			return true
+		})
+	} else {
+		dawg.suggestJoins(input, tokens, found, options)
+	}
+	if options.Ranker != nil {
+		for word, suggestion := range found {
+			suggestion.Score = options.Ranker.Score(word, suggestion.Distance)
+			found[word] = suggestion
+		}
+	}
+	return rankSuggestions(found, k)
+}
+
+// Add to found the phrases where two consecutive tokens of the input are joined into a word of the DAWG,
+// with the span of the two tokens in the input
+func (dawg *DAWG) suggestJoins(input string, tokens []string, found map[string]Suggestion, options SuggestOptions) {
+	phrase := []rune(strings.Join(tokens, " "))
+	starts := make([]int, len(tokens)) // Offsets of the tokens in the input
+	for i, offset := 0, 0; i < len(tokens); i++ {
+		starts[i] = offset + strings.Index(input[offset:], tokens[i])
+		offset = starts[i] + len(tokens[i])
+	}
+	for i := 0; i < len(tokens)-1; i++ {
+		joined := []rune(tokens[i] + tokens[i+1])
+		// The concatenation itself, or a near-miss of it
+		walkWeighted(dawg.initialState, joined, float64(options.distance(len(joined))-1), options.editCosts(), func(word []rune, cost float64) bool {
+			candidate := make([]string, 0, len(tokens)-1)
+			candidate = append(candidate, tokens[:i]...)
+			candidate = append(candidate, string(word))
+			candidate = append(candidate, tokens[i+2:]...)
+			suggestion := Suggestion{Match: Match{Word: strings.Join(candidate, " "), Start: starts[i], End: starts[i+1] + len(tokens[i+1])}}
+			suggestion.Distance = levenshtein(phrase, []rune(suggestion.Word))
+			suggestion.Matched = len(suggestion.Word)
+			suggestion.Score = cost + 1 + dawg.frequencyCost(string(word))
+			if previous, ok := found[suggestion.Word]; !ok || suggestion.Score < previous.Score {
+				found[suggestion.Word] = suggestion
+			}
+			return true
+		})
+	}
+}
+
+// Costs of the edit operations used to search suggestions
+func (options SuggestOptions) editCosts() editCosts {
+	costs := editCosts{model: unitCostModel{}, confusions: options.Confusions}
+	if options.Layout != nil {
+		costs.model = keyboardCostModel{layout: options.Layout}
+	}
+	return costs
+}
+
+// CostModel where substituting a letter by the letter of an adjacent key is cheaper than any other edit
+type keyboardCostModel struct {
+	unitCostModel
+	layout *KeyboardLayout
+}
+
+func (model keyboardCostModel) SubstCost(a rune, b rune) float64 {
+	if model.layout.Adjacent(a, b) {
+		return adjacentKeyCost
+	}
+	return 1
+}
+
+// Maximum distance allowed when looking for suggestions, depending on the word size
+func (options SuggestOptions) distance(wordSize int) int {
+	if options.Distance != nil {
+		return options.Distance(wordSize)
+	}
+	if wordSize <= 4 {
+		return 1
+	}
+	return 2
+}
+
+// Sort the suggestions by score, distance, then lexicographically, and keep the k first (all of them if k <= 0)
+func rankSuggestions(found map[string]Suggestion, k int) []Suggestion {
+	suggestions := make([]Suggestion, 0, len(found))
+	for _, suggestion := range found {
+		suggestions = append(suggestions, suggestion)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score < suggestions[j].Score
+		}
+		if suggestions[i].Distance != suggestions[j].Distance {
+			return suggestions[i].Distance < suggestions[j].Distance
+		}
+		return suggestions[i].Word < suggestions[j].Word
+	})
+	if k > 0 && len(suggestions) > k {
+		suggestions = suggestions[:k]
+	}
+	return suggestions
+}
@@ -0,0 +1,69 @@
+package dawg
+
+import "testing"
+
+func TestSuggest(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tests", "rest", "nest", "note", "something", "some", "thing"})
+
+	suggestions := dawg.Suggest("tesst", 2)
+	if len(suggestions) != 2 || suggestions[0].Word != "test" || suggestions[0].Distance != 1 || suggestions[1].Word != "nest" {
+		t.Error("Suggest failed")
+	}
+	if all := dawg.Suggest("tesst", 0); len(all) != 4 || all[3].Word != "tests" {
+		t.Error("Suggest without k failed", all)
+	}
+
+	if suggestions[0].Start != 0 || suggestions[0].End != 5 || suggestions[0].Matched != 4 {
+		t.Error("Suggest span failed", suggestions[0].Match)
+	}
+
+	suggestions = dawg.Suggest("some thing", 10)
+	if len(suggestions) != 1 || suggestions[0].Word != "something" || suggestions[0].Distance != 1 {
+		t.Error("Join suggestion failed")
+	}
+	// The span of the words joined
+	if suggestions = dawg.Suggest("a  some thing b", 10); len(suggestions) == 0 || suggestions[0].Word != "a something b" || suggestions[0].Start != 3 || suggestions[0].End != 13 {
+		t.Error("Join suggestion span failed", suggestions)
+	}
+
+	suggestions = dawg.Suggest("some thinq", 10)
+	if len(suggestions) != 1 || suggestions[0].Word != "something" || suggestions[0].Distance != 2 {
+		t.Error("Near-miss join suggestion failed")
+	}
+}
+
+func TestSuggestKeyboardLayout(t *testing.T) {
+	dawg := CreateDAWG([]string{"cat", "car", "cap"})
+
+	if !QWERTY.Adjacent('t', 'r') || QWERTY.Adjacent('t', 'p') || !AZERTY.Adjacent('a', 'z') || !QWERTZ.Adjacent('T', 'Z') {
+		t.Error("Keyboard layout failed")
+	}
+
+	// 't' -> 'p' and 't' -> 'r' cost the same without a layout, but 'r' is next to 't' on a QWERTY keyboard
+	suggestions := dawg.SuggestWithOptions("cat", 3, SuggestOptions{Layout: QWERTY})
+	if len(suggestions) != 3 || suggestions[0].Word != "cat" || suggestions[1].Word != "car" || suggestions[1].Score != 0.5 || suggestions[2].Word != "cap" {
+		t.Error("Suggest with keyboard layout failed")
+	}
+}
+
+func TestSuggestConfusionSet(t *testing.T) {
+	dawg := CreateDAWG([]string{"photograph", "phonograph", "field", "filed"})
+
+	confusions := NewConfusionSet()
+	confusions.AddPair("f", "ph", 0.25)
+	confusions.AddGroup(0.5, "ie", "ei")
+
+	if suggestions := dawg.Suggest("fotograf", 5); len(suggestions) != 0 {
+		t.Error("Suggest without confusion set failed")
+	}
+
+	suggestions := dawg.SuggestWithOptions("fotograf", 5, SuggestOptions{Confusions: confusions})
+	if len(suggestions) != 2 || suggestions[0].Word != "photograph" || suggestions[0].Score != 0.5 || suggestions[0].Distance != 4 {
+		t.Error("Suggest with confusion set failed")
+	}
+
+	suggestions = dawg.SuggestWithOptions("feild", 5, SuggestOptions{Confusions: confusions})
+	if len(suggestions) != 2 || suggestions[0].Word != "field" || suggestions[0].Score != 0.5 {
+		t.Error("Suggest with confusion group failed")
+	}
+}
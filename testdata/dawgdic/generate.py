@@ -0,0 +1,6 @@
+# Generate words.dawg with the DAWG Python package (pip install DAWG), which uses the dawgdic C++ library.
+# Run from this directory: python3 generate.py
+import dawg
+
+with open("words.txt", encoding="utf-8") as words:
+    dawg.DAWG(words.read().split("\n")[:-1]).save("words.dawg")
@@ -0,0 +1,42 @@
+package dawg
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Call fn for each word of the text, with its start and end offsets (in bytes).
+// A word is a sequence of letters, possibly containing apostrophes between letters ("don't").
+func scanWords(text string, fn func(word string, start int, end int)) {
+	start := -1
+	for i, r := range text {
+		if isWordLetter(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && isApostrophe(r) {
+			if next, _ := utf8.DecodeRuneInString(text[i+utf8.RuneLen(r):]); isWordLetter(next) {
+				continue
+			}
+		}
+		if start >= 0 {
+			fn(text[start:i], start, i)
+			start = -1
+		}
+	}
+	if start >= 0 {
+		fn(text[start:], start, len(text))
+	}
+}
+
+// Check if the rune can be part of a word
+func isWordLetter(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r)
+}
+
+// Check if the rune is an apostrophe
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
@@ -0,0 +1,74 @@
+package dawg
+
+import (
+	"container/heap"
+	"runtime"
+	"sync"
+)
+
+// Find, for each query, the k nearest words of the DAWG within levenshteinDistance of it (all of them if k <= 0),
+// sorted by distance then lexicographically.
+// The queries are spread over parallel workers, each one reusing its buffers from one query
+// to the next, and the search of each query is narrowed as soon as k words have been found.
+func (dawg *DAWG) TopK(queries []string, k int, levenshteinDistance int) [][]Suggestion {
+	results := make([][]Suggestion, len(queries))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < min(runtime.GOMAXPROCS(0), len(queries)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			walker := &walker{costs: unitCosts}
+			for index := range indexes {
+				results[index] = dawg.topK(walker, []rune(queries[index]), k, levenshteinDistance)
+			}
+		}()
+	}
+	for i := range queries {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	return results
+}
+
+// Find the k nearest words of query with the given walker
+func (dawg *DAWG) topK(walker *walker, query []rune, k int, levenshteinDistance int) []Suggestion {
+	best := make(suggestionHeap, 0, max(k, 0))
+	walker.walk(dawg.initialState, query, float64(levenshteinDistance), func(word []rune, cost float64) bool {
+		suggestion := Suggestion{Match: newMatch(string(word), int(cost), runesSize(query)), Score: cost}
+		if k <= 0 || len(best) < k {
+			heap.Push(&best, suggestion)
+		} else if best.less(suggestion, best[0]) {
+			best[0] = suggestion
+			heap.Fix(&best, 0)
+		}
+		if len(best) == k {
+			// Only words at most as far as the worst kept word can still be kept
+			walker.maxCost = best[0].Score
+		}
+		return true
+	})
+	results := make([]Suggestion, len(best))
+	for i := len(best) - 1; i >= 0; i-- {
+		results[i] = heap.Pop(&best).(Suggestion)
+	}
+	return results
+}
+
+// Max-heap of suggestions, the worst suggestion being on top
+type suggestionHeap []Suggestion
+
+func (h suggestionHeap) less(a Suggestion, b Suggestion) bool {
+	return a.Score < b.Score || a.Score == b.Score && a.Word < b.Word
+}
+func (h suggestionHeap) Len() int            { return len(h) }
+func (h suggestionHeap) Less(i, j int) bool  { return h.less(h[j], h[i]) }
+func (h suggestionHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *suggestionHeap) Push(x interface{}) { *h = append(*h, x.(Suggestion)) }
+func (h *suggestionHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
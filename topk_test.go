@@ -0,0 +1,26 @@
+package dawg
+
+import "testing"
+
+func TestTopK(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note"})
+
+	results := dawg.TopK([]string{"test", "nose", "xyz"}, 2, 2)
+	if len(results) != 3 {
+		t.Fatal("TopK failed")
+	}
+	if len(results[0]) != 2 || results[0][0].Word != "test" || results[0][0].Distance != 0 || results[0][1].Word != "nest" {
+		t.Error("TopK failed")
+	}
+	if len(results[1]) != 2 || results[1][0].Word != "note" || results[1][1].Word != "nest" || results[1][1].Distance != 2 {
+		t.Error("TopK failed")
+	}
+	if len(results[2]) != 0 {
+		t.Error("TopK failed")
+	}
+
+	// All the words within the distance
+	if results = dawg.TopK([]string{"test"}, 0, 1); len(results[0]) != 5 || results[0][0].Word != "test" || results[0][4].Word != "test2" {
+		t.Error("TopK without k failed", results)
+	}
+}
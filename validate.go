@@ -0,0 +1,70 @@
+package dawg
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Errors returned (wrapped in a LineError) for the invalid words, in strict mode (see BuildOptions.Strict)
+var (
+	ErrInvalidUTF8      = errors.New("Invalid UTF-8.")
+	ErrSpace            = errors.New("Leading or trailing space.")
+	ErrControlCharacter = errors.New("Control character.")
+)
+
+// Check that the word found at the given line is valid UTF-8, without leading or trailing spaces
+// nor control characters. The error is a LineError locating the first invalid byte of the word.
+func validateWord(word string, line int) *LineError {
+	for i := 0; i < len(word); {
+		char, size := utf8.DecodeRuneInString(word[i:])
+		switch {
+		case char == utf8.RuneError && size == 1:
+			return &LineError{Line: line, Byte: i + 1, Err: ErrInvalidUTF8}
+		case unicode.IsSpace(char) && (i == 0 || i+size == len(word)):
+			return &LineError{Line: line, Byte: i + 1, Err: ErrSpace}
+		case unicode.IsControl(char):
+			return &LineError{Line: line, Byte: i + 1, Err: ErrControlCharacter}
+		}
+		i += size
+	}
+	return nil
+}
+
+// Validate the word found at the given line, as configured by the options.
+// skip is set if the word is invalid but must be skipped.
+func (options BuildOptions) validate(word string, line int) (skip bool, err error) {
+	if !options.Strict {
+		return false, nil
+	}
+	if lineErr := validateWord(word, line); lineErr != nil {
+		if !options.SkipInvalid {
+			return false, lineErr
+		}
+		if options.OnInvalid != nil {
+			options.OnInvalid(lineErr)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// Get the word to add for the line, as configured by the options: filtered, validated then normalized.
+// skip is set if the line must be skipped.
+func (options BuildOptions) prepare(text string, line int) (word string, skip bool, err error) {
+	if options.SkipBlankLines && strings.TrimSpace(text) == "" || options.SkipComments && strings.HasPrefix(text, "#") {
+		return "", true, nil
+	}
+	word = text
+	if options.Filter != nil {
+		var keep bool
+		if word, keep = options.Filter(word); !keep {
+			return "", true, nil
+		}
+	}
+	if skip, err = options.validate(word, line); skip || err != nil {
+		return "", skip, err
+	}
+	return options.normalize(word), false, nil
+}
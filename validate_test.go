@@ -0,0 +1,55 @@
+package dawg
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestStrictMode(t *testing.T) {
+	words := "test\nnot\xffe\n tests\nnote \nno\x00te\nvote\n"
+
+	dawg, err := CreateDAWGFromReader(strings.NewReader(words), BuildOptions{})
+	if err != nil || dawg.CountWithPrefix("") != 6 {
+		t.Error("Non strict mode failed")
+	}
+
+	_, err = CreateDAWGFromReader(strings.NewReader(words), BuildOptions{Strict: true})
+	var lineErr *LineError
+	if !errors.As(err, &lineErr) || lineErr.Line != 2 || lineErr.Byte != 4 || !errors.Is(err, ErrInvalidUTF8) || err.Error() != "Line 2, byte 4: Invalid UTF-8." {
+		t.Error("Strict mode failed")
+	}
+
+	var skipped []*LineError
+	options := BuildOptions{Strict: true, SkipInvalid: true, OnInvalid: func(err *LineError) { skipped = append(skipped, err) }}
+	dawg, err = CreateDAWGFromReader(strings.NewReader(words), options)
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"test", "vote"})) {
+		t.Error("Skipping invalid words failed")
+	}
+	if len(skipped) != 4 || skipped[1].Line != 3 || skipped[1].Byte != 1 || !errors.Is(skipped[1], ErrSpace) ||
+		skipped[2].Byte != 5 || !errors.Is(skipped[2], ErrSpace) || skipped[3].Byte != 3 || !errors.Is(skipped[3], ErrControlCharacter) {
+		t.Error("Counting invalid words failed")
+	}
+}
+
+func TestLineFilters(t *testing.T) {
+	words := "# A comment\ntest\n\n  \nTests [plural]\n#tag\n"
+	options := BuildOptions{SkipBlankLines: true, SkipComments: true, FoldCase: true, Filter: func(word string) (string, bool) {
+		word, _, _ = strings.Cut(word, " [")
+		return word, word != "skip"
+	}}
+	dawg, err := CreateDAWGFromReader(strings.NewReader(words+"skip\n"), options)
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"test", "tests"})) {
+		t.Error("Line filters failed")
+	}
+
+	dawg, err = CreateDAWGFromReader(strings.NewReader(words), BuildOptions{})
+	if err != nil || !dawg.Contains("") || !dawg.Contains("#tag") || !dawg.Contains("Tests [plural]") {
+		t.Error("No line filters failed")
+	}
+
+	dawg, err = CreateDAWGFromCSV(strings.NewReader("# word,count\nthe,3\n"), CSVOptions{BuildOptions: BuildOptions{SkipComments: true}, FrequencyColumn: 2})
+	if err != nil || !dawg.Equal(CreateDAWG([]string{"the"})) {
+		t.Error("CSV comments failed")
+	}
+}
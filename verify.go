@@ -0,0 +1,93 @@
+package dawg
+
+import "fmt"
+
+// Check the integrity of the DAWG, after loading it from an untrusted source or changing its words:
+//   - its graph is acyclic, and each of its states leads to a word (except the initial state of an empty DAWG)
+//   - the letters of each state are sorted and can be found
+//   - the counts are up to date: states, words under each state, longest word, frequencies, flags
+//   - the states tracked to change the words are the states of the graph, with the right number of letters going to them
+//   - no two states are equivalent (see VerifyMinimal)
+//
+// The error describes the first problem found, with a prefix leading to the incorrect state.
+func (dawg *DAWG) Verify() error {
+	if prefix, found := findCycle(dawg.initialState, nil, make(map[*state]bool)); found {
+		return fmt.Errorf("The DAWG has a cycle: the state reached by %q can be reached again from itself.", string(prefix))
+	}
+
+	// Walk the states breadth-first, remembering a prefix leading to each of them
+	states := []*state{dawg.initialState}
+	prefixes := map[*state]string{dawg.initialState: ""}
+	inDegrees := make(map[*state]int)
+	for i := 0; i < len(states); i++ {
+		curState := states[i]
+		letters := curState.letters
+		var count uint64
+		if curState.final {
+			count = 1
+		}
+		for j, curLetter := range letters {
+			if j > 0 && curLetter.char <= letters[j-1].char {
+				return fmt.Errorf("The DAWG is incorrect: the letters of the state reached by %q are not sorted.", prefixes[curState])
+			}
+			if curState.getletter(curLetter.char) != curLetter {
+				return fmt.Errorf("The DAWG is incorrect: the letter %q of the state reached by %q can't be found.", curLetter.char, prefixes[curState])
+			}
+			count += curLetter.state.wordsCount // Checked when the state is reached by the walk
+			inDegrees[curLetter.state]++
+			if _, ok := prefixes[curLetter.state]; !ok {
+				prefixes[curLetter.state] = prefixes[curState] + string(curLetter.char)
+				states = append(states, curLetter.state)
+			}
+		}
+		if count != curState.wordsCount {
+			return fmt.Errorf("The DAWG is incorrect: the state reached by %q has %d words under it, not %d.", prefixes[curState], count, curState.wordsCount)
+		}
+		if count == 0 && curState != dawg.initialState {
+			return fmt.Errorf("The DAWG is incorrect: the state reached by %q doesn't lead to any word.", prefixes[curState])
+		}
+	}
+
+	if uint64(len(states)) != dawg.nodesCount {
+		return fmt.Errorf("The DAWG is incorrect: it has %d states, not %d.", len(states), dawg.nodesCount)
+	}
+	if longest := longestWord(dawg.initialState, make(map[*state]int)); longest != dawg.maxWordSize {
+		return fmt.Errorf("The DAWG is incorrect: its longest word has %d letters, not %d.", longest, dawg.maxWordSize)
+	}
+	if dawg.frequencies != nil && uint64(len(dawg.frequencies)) != dawg.WordsCount() {
+		return fmt.Errorf("The DAWG is incorrect: it has %d frequencies for %d words.", len(dawg.frequencies), dawg.WordsCount())
+	}
+	if dawg.flags != nil && uint64(len(dawg.flags)) != dawg.WordsCount() {
+		return fmt.Errorf("The DAWG is incorrect: it has %d flags for %d words.", len(dawg.flags), dawg.WordsCount())
+	}
+	if index := dawg.mutable; index != nil {
+		for curState, inDegree := range index.inDegrees {
+			if _, ok := prefixes[curState]; !ok {
+				return fmt.Errorf("The DAWG is incorrect: a state tracked to change the words can't be reached.")
+			}
+			if inDegree != inDegrees[curState] {
+				return fmt.Errorf("The DAWG is incorrect: the state reached by %q has %d letters going to it, not %d.", prefixes[curState], inDegrees[curState], inDegree)
+			}
+		}
+		if len(index.inDegrees) != len(inDegrees) {
+			return fmt.Errorf("The DAWG is incorrect: %d states are tracked to change the words, not %d.", len(index.inDegrees), len(inDegrees))
+		}
+	}
+	return dawg.VerifyMinimal()
+}
+
+// Find a path from the state going twice through the same state, and get its prefix up to the second time.
+// onPath holds the states of the path to the state (mapped to true) and the states already checked (mapped to false).
+func findCycle(curState *state, prefix []rune, onPath map[*state]bool) ([]rune, bool) {
+	if visiting, ok := onPath[curState]; ok {
+		return prefix, visiting
+	}
+	onPath[curState] = true
+	for _, curLetter := range curState.letters {
+		if cycle, found := findCycle(curLetter.state, append(prefix, curLetter.char), onPath); found {
+			return cycle, true
+		}
+	}
+	onPath[curState] = false
+	return nil, false
+}
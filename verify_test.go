@@ -0,0 +1,93 @@
+package dawg
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	words := []string{"test", "tests", "rest", "nest", "note", "日本"}
+	dawg := CreateDAWG(words)
+	if err := dawg.Verify(); err != nil {
+		t.Error("Verify failed:", err)
+	}
+	if err := CreateDAWG(nil).Verify(); err != nil {
+		t.Error("Verify failed on an empty DAWG:", err)
+	}
+	sorted, _ := CreateDAWGFromSorted([]string{"a", "ab", "b"})
+	if err := sorted.Verify(); err != nil {
+		t.Error("Verify failed on a sorted build:", err)
+	}
+	withFrequencies := CreateDAWGWithFrequencies(map[string]uint64{"test": 3, "rest": 1})
+	if err := withFrequencies.Verify(); err != nil {
+		t.Error("Verify failed with frequencies:", err)
+	}
+
+	// After changes of the words
+	dawg.Add("nests")
+	dawg.Add("tent")
+	dawg.Remove("tests")
+	dawg.Remove("日本")
+	if err := dawg.Verify(); err != nil {
+		t.Error("Verify failed after changes:", err)
+	}
+	concurrent := NewConcurrentDAWG(CreateDAWG(words))
+	concurrent.Add("notes")
+	concurrent.Remove("rest")
+	if err := concurrent.Snapshot().Verify(); err != nil {
+		t.Error("Verify failed after concurrent changes:", err)
+	}
+	data, _ := dawg.MarshalBinary()
+	decoded := &DAWG{}
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded.Verify() != nil {
+		t.Error("Verify failed after a decoding:", err)
+	}
+}
+
+func TestVerifyIncorrect(t *testing.T) {
+	words := []string{"test", "tests", "rest", "nest", "note"}
+	for _, incorrect := range []struct {
+		message string
+		corrupt func(dawg *DAWG)
+	}{
+		{"cycle", func(dawg *DAWG) {
+			dawg.prefixState("tes").getletter('t').state = dawg.prefixState("t")
+		}},
+		{"doesn't lead to any word", func(dawg *DAWG) {
+			dawg.prefixState("no").addLetter(&letter{char: 'x', state: &state{}})
+		}},
+		{"not sorted", func(dawg *DAWG) {
+			letters := dawg.initialState.letters
+			letters[1], letters[2] = letters[2], letters[1]
+		}},
+		{"words under it", func(dawg *DAWG) {
+			dawg.prefixState("re").wordsCount++
+		}},
+		{"states, not", func(dawg *DAWG) {
+			dawg.nodesCount++
+		}},
+		{"longest word", func(dawg *DAWG) {
+			dawg.maxWordSize = 4
+		}},
+		{"not minimal", func(dawg *DAWG) {
+			duplicate := *dawg.prefixState("r")
+			dawg.initialState.addLetter(&letter{char: 'x', state: &duplicate})
+			dawg.initialState.wordsCount += duplicate.wordsCount
+			dawg.nodesCount++
+		}},
+	} {
+		dawg := CreateDAWG(words)
+		incorrect.corrupt(dawg)
+		if err := dawg.Verify(); err == nil || !strings.Contains(err.Error(), incorrect.message) {
+			t.Error("Verify should fail:", incorrect.message, err)
+		}
+	}
+
+	// A state forgotten by the index of the states
+	dawg := CreateDAWG(words)
+	dawg.Add("tent")
+	delete(dawg.mutable.inDegrees, dawg.prefixState("tent"))
+	if err := dawg.Verify(); err == nil {
+		t.Error("Verify should fail with an incorrect index")
+	}
+}
@@ -0,0 +1,29 @@
+package dawg
+
+// CostModel gives the cost of each edit operation of a weighted search.
+// All the costs must be positive or zero.
+type CostModel interface {
+	SubstCost(a rune, b rune) float64 // Cost of replacing the letter a of the query by the letter b
+	InsertCost(r rune) float64        // Cost of inserting the letter r in the query
+	DeleteCost(r rune) float64        // Cost of deleting the letter r of the query
+}
+
+// Approximate string searching in the DAWG, with non-uniform edit costs (OCR post-correction for example).
+// The words found are those whose cheapest edit sequence from word costs at most maxCost,
+// sorted by cost, then by Levenshtein distance, then lexicographically.
+// At most maxResults words are returned (all of them if maxResults <= 0).
+// Multi-letter substitutions (such as "rn" -> "m") can be registered in a ConfusionSet, see SearchWithCostsAndConfusions.
+func (dawg *DAWG) SearchWithCosts(word string, model CostModel, maxCost float64, maxResults int) []Suggestion {
+	return dawg.SearchWithCostsAndConfusions(word, model, nil, maxCost, maxResults)
+}
+
+// Same as SearchWithCosts, with the multi-letter substitutions of the confusion set (which may be nil)
+func (dawg *DAWG) SearchWithCostsAndConfusions(word string, model CostModel, confusions *ConfusionSet, maxCost float64, maxResults int) []Suggestion {
+	query := []rune(word)
+	found := make(map[string]Suggestion)
+	walkWeighted(dawg.initialState, query, maxCost, editCosts{model: model, confusions: confusions}, func(word []rune, cost float64) bool {
+		found[string(word)] = Suggestion{Match: newMatch(string(word), levenshtein(query, word), runesSize(query)), Score: cost}
+		return true
+	})
+	return rankSuggestions(found, maxResults)
+}
@@ -0,0 +1,47 @@
+package dawg
+
+import "testing"
+
+// Costs of the typical OCR errors
+type ocrCostModel struct{}
+
+func (ocrCostModel) SubstCost(a rune, b rune) float64 {
+	if a == '1' && b == 'l' || a == '0' && b == 'o' {
+		return 0.2
+	}
+	return 1
+}
+func (ocrCostModel) InsertCost(r rune) float64 { return 1 }
+func (ocrCostModel) DeleteCost(r rune) float64 {
+	if r == '.' {
+		return 0.1
+	}
+	return 1
+}
+
+func TestSearchWithCosts(t *testing.T) {
+	dawg := CreateDAWG([]string{"hello", "hollo", "modern", "modem", "help"})
+
+	results := dawg.SearchWithCosts("he11o", ocrCostModel{}, 0.5, 0)
+	if len(results) != 1 || results[0].Word != "hello" || results[0].Distance != 2 || results[0].Score < 0.39 || results[0].Score > 0.41 {
+		t.Error("SearchWithCosts failed:", results)
+	}
+	results = dawg.SearchWithCosts("h.e.1p", ocrCostModel{}, 0.5, 0)
+	if len(results) != 1 || results[0].Word != "help" {
+		t.Error("SearchWithCosts failed:", results)
+	}
+	results = dawg.SearchWithCosts("he11o", ocrCostModel{}, 2, 0)
+	if len(results) != 2 || results[0].Word != "hello" || results[1].Word != "hollo" {
+		t.Error("SearchWithCosts failed:", results)
+	}
+	if results = dawg.SearchWithCosts("he11o", ocrCostModel{}, 2, 1); len(results) != 1 {
+		t.Error("SearchWithCosts failed:", results)
+	}
+
+	confusions := NewConfusionSet()
+	confusions.AddPair("rn", "m", 0.3)
+	results = dawg.SearchWithCostsAndConfusions("rnodem", ocrCostModel{}, confusions, 0.5, 0)
+	if len(results) != 1 || results[0].Word != "modem" {
+		t.Error("SearchWithCostsAndConfusions failed:", results)
+	}
+}
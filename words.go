@@ -0,0 +1,107 @@
+package dawg
+
+import (
+	"bufio"
+	"io"
+	"iter"
+	"unicode/utf8"
+)
+
+// Iterate over all the words of the DAWG, in lexicographic order.
+// The words are built as the iteration goes, the DAWG is never fully enumerated if the iteration stops early.
+func (dawg *DAWG) Words() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		walkSorted(dawg.initialState, nil, func(word []rune) bool {
+			return yield(string(word))
+		})
+	}
+}
+
+// Get a page of the words of the DAWG, in lexicographic order: at most max words after the page of the token,
+// and the token of the next page ("" if there are no more words). See CompletionsPage.
+func (dawg *DAWG) WordsPage(token string, max int) (words []string, next string, err error) {
+	return dawg.CompletionsPage("", token, max)
+}
+
+// Write all the words of the DAWG to w, one per line, in lexicographic order: the word list the DAWG
+// was created from (without its duplicates), which can be read again by CreateDAWGFromReader.
+// The words are written as they are walked, so the list is never fully held in memory.
+func (dawg *DAWG) WriteWords(w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	var line []byte
+	var err error
+	walkSorted(dawg.initialState, nil, func(word []rune) bool {
+		line = line[:0]
+		for _, char := range word {
+			line = utf8.AppendRune(line, char)
+		}
+		_, err = writer.Write(append(line, '\n'))
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return writer.Flush()
+}
+
+// Walk the DAWG depth-first, in lexicographic order, calling fn for each prefix of the words
+// (starting with the empty prefix), with isFinal set if the prefix is a word itself.
+// If fn returns false, the words starting with the prefix are skipped.
+func (dawg *DAWG) Walk(fn func(prefix string, isFinal bool) bool) {
+	walkPrefixes(dawg.initialState, nil, fn)
+}
+
+// Recursive part of Walk
+func walkPrefixes(curState *state, prefix []rune, fn func(prefix string, isFinal bool) bool) {
+	if !fn(string(prefix), curState.final) {
+		return
+	}
+	for _, curLetter := range curState.letters {
+		walkPrefixes(curLetter.state, append(prefix, curLetter.char), fn)
+	}
+}
+
+// Iterate over the words of the DAWG in [lo, hi), in lexicographic order (no upper bound if hi is empty).
+// Only the states leading to words in the range are walked, so a short range is quick to iterate over.
+func (dawg *DAWG) Between(lo string, hi string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		bounds := wordRange{lo: []rune(lo), hi: []rune(hi), unbounded: hi == ""}
+		bounds.walk(dawg.initialState, nil, true, !bounds.unbounded, func(word []rune) bool {
+			return yield(string(word))
+		})
+	}
+}
+
+// Bounds of the words walked by Between
+type wordRange struct {
+	lo, hi    []rune
+	unbounded bool // No upper bound
+}
+
+// Call fn for each word in the range under the state, in lexicographic order, until it returns false.
+// atLo (atHi) is set if the word is a prefix of lo (hi), so the next letters are bounded.
+func (bounds *wordRange) walk(curState *state, word []rune, atLo bool, atHi bool, fn func(word []rune) bool) bool {
+	depth := len(word)
+	if atHi && depth == len(bounds.hi) {
+		return true // The words starting with hi are not in the range
+	}
+	// A prefix of lo is lower than lo, a prefix of hi is lower than hi
+	if curState.final && (!atLo || depth == len(bounds.lo)) && !fn(word) {
+		return false
+	}
+	for _, curLetter := range curState.letters {
+		letterAtLo := atLo && depth < len(bounds.lo)
+		if letterAtLo && curLetter.char < bounds.lo[depth] {
+			continue
+		}
+		if atHi && curLetter.char > bounds.hi[depth] {
+			break
+		}
+		letterAtLo = letterAtLo && curLetter.char == bounds.lo[depth]
+		letterAtHi := atHi && curLetter.char == bounds.hi[depth]
+		if !bounds.walk(curLetter.state, append(word, curLetter.char), letterAtLo, letterAtHi, fn) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+package dawg
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "日本"})
+
+	expected := []string{"nest", "note", "tes", "tese", "test", "test2", "日本"}
+	var words []string
+	for word := range dawg.Words() {
+		words = append(words, word)
+	}
+	if len(words) != len(expected) {
+		t.Fatal("Words failed")
+	}
+	for i := range words {
+		if words[i] != expected[i] {
+			t.Error("Words failed")
+		}
+	}
+
+	words = nil
+	for word := range dawg.Words() {
+		if word == "tes" {
+			break
+		}
+		words = append(words, word)
+	}
+	if len(words) != 2 {
+		t.Error("Words break failed")
+	}
+}
+
+func TestWriteWords(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "test2", "tes", "note", "日本", "test"})
+
+	var builder strings.Builder
+	if err := dawg.WriteWords(&builder); err != nil {
+		t.Fatal(err)
+	}
+	if builder.String() != "nest\nnote\ntes\ntese\ntest\ntest2\n日本\n" {
+		t.Error("WriteWords failed")
+	}
+
+	read, err := CreateDAWGFromReader(strings.NewReader(builder.String()), BuildOptions{})
+	if err != nil || !read.Equal(dawg) {
+		t.Error("WriteWords round trip failed")
+	}
+
+	var empty strings.Builder
+	if err := CreateDAWG(nil).WriteWords(&empty); err != nil || empty.Len() != 0 {
+		t.Error("WriteWords of an empty DAWG failed")
+	}
+}
+
+func TestWalk(t *testing.T) {
+	dawg := CreateDAWG([]string{"test", "tese", "nest", "tes", "note"})
+
+	var prefixes, words []string
+	dawg.Walk(func(prefix string, isFinal bool) bool {
+		prefixes = append(prefixes, prefix)
+		if isFinal {
+			words = append(words, prefix)
+		}
+		return prefix != "n" // Skip the words starting with n
+	})
+	expected := []string{"", "n", "t", "te", "tes", "tese", "test"}
+	if len(prefixes) != len(expected) || len(words) != 3 || words[0] != "tes" {
+		t.Fatal("Walk failed")
+	}
+	for i := range prefixes {
+		if prefixes[i] != expected[i] {
+			t.Error("Walk failed")
+		}
+	}
+}
+
+func TestBetween(t *testing.T) {
+	words := []string{"", "a", "ab", "abc", "abd", "b", "ba", "bb", "c", "éa"}
+	dawg := CreateDAWG(words)
+	for _, bounds := range [][2]string{{"", ""}, {"ab", "b"}, {"abc", "bb"}, {"aa", "ba"}, {"abcd", "c"}, {"b", "b"}, {"c", "a"}, {"bz", ""}, {"", "ab"}} {
+		var expected []string
+		for _, word := range words {
+			if word >= bounds[0] && (bounds[1] == "" || word < bounds[1]) {
+				expected = append(expected, word)
+			}
+		}
+		if found := slices.Collect(dawg.Between(bounds[0], bounds[1])); !slices.Equal(found, expected) {
+			t.Error("Between failed for", bounds, found, expected)
+		}
+	}
+
+	for word := range dawg.Between("a", "") {
+		if word != "a" {
+			t.Error("Between with break failed")
+		}
+		break
+	}
+}